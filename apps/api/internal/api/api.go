@@ -10,195 +10,2269 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/oapi-codegen/runtime"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
 const (
 	BearerAuthScopes = "BearerAuth.Scopes"
 )
 
+// Defines values for ContentFormat.
+const (
+	Markdown ContentFormat = "markdown"
+	Plain    ContentFormat = "plain"
+)
+
+// Defines values for DiffLineOp.
+const (
+	Delete DiffLineOp = "delete"
+	Equal  DiffLineOp = "equal"
+	Insert DiffLineOp = "insert"
+)
+
+// Defines values for MediaStatus.
+const (
+	Failed     MediaStatus = "failed"
+	Pending    MediaStatus = "pending"
+	Processing MediaStatus = "processing"
+	Ready      MediaStatus = "ready"
+)
+
+// Defines values for MediaVariantName.
+const (
+	MediaVariantNameLarge     MediaVariantName = "large"
+	MediaVariantNameMedium    MediaVariantName = "medium"
+	MediaVariantNameSmall     MediaVariantName = "small"
+	MediaVariantNameThumbnail MediaVariantName = "thumbnail"
+)
+
+// Defines values for NotificationType.
+const (
+	NotificationTypeComment    NotificationType = "comment"
+	NotificationTypeFollow     NotificationType = "follow"
+	NotificationTypeMention    NotificationType = "mention"
+	NotificationTypeModeration NotificationType = "moderation"
+	NotificationTypeReaction   NotificationType = "reaction"
+)
+
+// Defines values for PostVisibility.
+const (
+	Followers PostVisibility = "followers"
+	Private   PostVisibility = "private"
+	Public    PostVisibility = "public"
+	Unlisted  PostVisibility = "unlisted"
+)
+
+// Defines values for ReportAction.
+const (
+	DeletePost    ReportAction = "delete_post"
+	Dismiss       ReportAction = "dismiss"
+	HidePost      ReportAction = "hide_post"
+	SuspendAuthor ReportAction = "suspend_author"
+)
+
+// Defines values for ReportReason.
+const (
+	Harassment     ReportReason = "harassment"
+	Hate           ReportReason = "hate"
+	Misinformation ReportReason = "misinformation"
+	Other          ReportReason = "other"
+	Sexual         ReportReason = "sexual"
+	Spam           ReportReason = "spam"
+	Violence       ReportReason = "violence"
+)
+
+// Defines values for ReportStatus.
+const (
+	Claimed  ReportStatus = "claimed"
+	Open     ReportStatus = "open"
+	Resolved ReportStatus = "resolved"
+)
+
+// Defines values for UserRole.
+const (
+	UserRoleAdmin     UserRole = "admin"
+	UserRoleModerator UserRole = "moderator"
+	UserRoleUser      UserRole = "user"
+)
+
+// Defines values for GetMediaMediaIdParamsVariant.
+const (
+	GetMediaMediaIdParamsVariantLarge     GetMediaMediaIdParamsVariant = "large"
+	GetMediaMediaIdParamsVariantMedium    GetMediaMediaIdParamsVariant = "medium"
+	GetMediaMediaIdParamsVariantSmall     GetMediaMediaIdParamsVariant = "small"
+	GetMediaMediaIdParamsVariantThumbnail GetMediaMediaIdParamsVariant = "thumbnail"
+)
+
+// Defines values for GetPostsPostIdCommentsParamsSort.
+const (
+	Newest GetPostsPostIdCommentsParamsSort = "newest"
+	Top    GetPostsPostIdCommentsParamsSort = "top"
+)
+
 // AuthToken defines model for AuthToken.
 type AuthToken struct {
 	AccessToken  *string `json:"accessToken,omitempty"`
 	RefreshToken *string `json:"refreshToken,omitempty"`
 }
 
-// CreatePostRequest defines model for CreatePostRequest.
-type CreatePostRequest struct {
-	AuthorId string `json:"authorId"`
-	Content  string `json:"content"`
-	Title    string `json:"title"`
-}
+// Comment defines model for Comment.
+type Comment struct {
+	// AuthorId ID of the author, missing once the author deleted their account
+	AuthorId  *string   `json:"authorId,omitempty"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"createdAt"`
+	Id        string    `json:"id"`
+
+	// Mentions Mentions of existing Users in the content, in order
+	Mentions []Mention `json:"mentions"`
+
+	// ParentId ID of the Comment this Comment replies to
+	ParentId *string `json:"parentId,omitempty"`
+	PostId   string  `json:"postId"`
+
+	// RepliesCount Number of direct replies to the Comment
+	RepliesCount int       `json:"repliesCount"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// ContentFormat How the content of the Post is written, plain text or Markdown
+type ContentFormat string
+
+// CreateCommentRequest defines model for CreateCommentRequest.
+type CreateCommentRequest struct {
+	Content string `json:"content"`
+
+	// ParentId ID of the Comment to reply to
+	ParentId *string `json:"parentId,omitempty"`
+}
+
+// CreatePollRequest defines model for CreatePollRequest.
+type CreatePollRequest struct {
+	// ClosesAt Time the Poll stops accepting votes, in the future
+	ClosesAt time.Time `json:"closesAt"`
+
+	// MultipleChoice Whether Users can vote for more than one option
+	MultipleChoice *bool `json:"multipleChoice,omitempty"`
+
+	// Options Texts of the options, in order
+	Options []string `json:"options"`
+}
+
+// CreatePollVoteRequest defines model for CreatePollVoteRequest.
+type CreatePollVoteRequest struct {
+	// OptionIds IDs of the options to vote for, exactly one unless the Poll is multiple choice
+	OptionIds []string `json:"optionIds"`
+}
+
+// CreatePostRequest defines model for CreatePostRequest.
+type CreatePostRequest struct {
+	AuthorId string `json:"authorId"`
+	Content  string `json:"content"`
+
+	// ContentFormat How the content of the Post is written, plain text or Markdown
+	ContentFormat *ContentFormat `json:"contentFormat,omitempty"`
+
+	// MediaIds IDs of up to 4 unattached Media uploaded by the current User, in the order they are shown
+	MediaIds *[]string          `json:"mediaIds,omitempty"`
+	Poll     *CreatePollRequest `json:"poll,omitempty"`
+
+	// QuotedPostId ID of a public Post to quote
+	QuotedPostId *string `json:"quotedPostId,omitempty"`
+
+	// Tags Tags of the Post, hashtags found in the content are added automatically
+	Tags  *[]string `json:"tags,omitempty"`
+	Title string    `json:"title"`
+
+	// Visibility Who can read the Post. Followers-only Posts are readable by the followers of the author, private Posts only by the author. Unlisted Posts are readable by anyone with their ID but never listed
+	Visibility *PostVisibility `json:"visibility,omitempty"`
+}
+
+// CreateReportRequest defines model for CreateReportRequest.
+type CreateReportRequest struct {
+	// Details Free text explaining the Report
+	Details *string `json:"details,omitempty"`
+
+	// Reason Why the Post was reported
+	Reason ReportReason `json:"reason"`
+}
+
+// CursorPaginatedNotifications defines model for CursorPaginatedNotifications.
+type CursorPaginatedNotifications struct {
+	Items []Notification `json:"items"`
+
+	// NextCursor Cursor to fetch the next page, absent on the last page
+	NextCursor *string `json:"nextCursor,omitempty"`
+}
+
+// CursorPaginatedPosts defines model for CursorPaginatedPosts.
+type CursorPaginatedPosts struct {
+	Items []Post `json:"items"`
+
+	// NextCursor Cursor to fetch the next page, absent on the last page
+	NextCursor *string `json:"nextCursor,omitempty"`
+}
+
+// CursorPaginatedReports defines model for CursorPaginatedReports.
+type CursorPaginatedReports struct {
+	Items []Report `json:"items"`
+
+	// NextCursor Cursor to fetch the next page, absent on the last page
+	NextCursor *string `json:"nextCursor,omitempty"`
+}
+
+// DiffLine defines model for DiffLine.
+type DiffLine struct {
+	Op   DiffLineOp `json:"op"`
+	Text string     `json:"text"`
+}
+
+// DiffLineOp defines model for DiffLine.Op.
+type DiffLineOp string
+
+// GeneralError defines model for GeneralError.
+type GeneralError struct {
+	// FieldErrors Validation errors for specific fields
+	FieldErrors *map[string]string `json:"fieldErrors,omitempty"`
+
+	// Message A description of the error
+	Message string `json:"message"`
+}
+
+// LinkPreview Preview card of a link in the content, read from the OpenGraph or Twitter card metadata of the page
+type LinkPreview struct {
+	Description *string `json:"description,omitempty"`
+
+	// ImageUrl URL of the image of the page, which is not proxied
+	ImageUrl *string `json:"imageUrl,omitempty"`
+	SiteName *string `json:"siteName,omitempty"`
+	Title    string  `json:"title"`
+	Url      string  `json:"url"`
+}
+
+// LoginRequest defines model for LoginRequest.
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// Media Uploaded file. Media that are not attached to a Post in time are deleted
+type Media struct {
+	// Blurhash BlurHash placeholder of the image, once processed
+	Blurhash *string `json:"blurhash,omitempty"`
+
+	// ContentType Content type sniffed from the uploaded file
+	ContentType string    `json:"contentType"`
+	CreatedAt   time.Time `json:"createdAt"`
+
+	// Height Height of the image in pixels, once processed
+	Height    *int   `json:"height,omitempty"`
+	Id        string `json:"id"`
+	SizeBytes int64  `json:"sizeBytes"`
+
+	// Status Processing state of Media. Images are processed in the background after the upload, other Media are ready right away
+	Status MediaStatus `json:"status"`
+
+	// Url Signed URL to download the file, which expires after a while. Only set once the Media is ready
+	Url *string `json:"url,omitempty"`
+
+	// Variants Resized copies of the image, smallest first. Empty until the image is processed
+	Variants []MediaVariant `json:"variants"`
+
+	// Width Width of the image in pixels, once processed
+	Width *int `json:"width,omitempty"`
+}
+
+// MediaStatus Processing state of Media. Images are processed in the background after the upload, other Media are ready right away
+type MediaStatus string
+
+// MediaVariant Resized copy of an image, the thumbnail is cropped to a square
+type MediaVariant struct {
+	ContentType string           `json:"contentType"`
+	Height      int              `json:"height"`
+	Name        MediaVariantName `json:"name"`
+	SizeBytes   int64            `json:"sizeBytes"`
+
+	// Url Signed URL to download the variant, which expires after a while
+	Url   string `json:"url"`
+	Width int    `json:"width"`
+}
+
+// MediaVariantName defines model for MediaVariant.Name.
+type MediaVariantName string
+
+// Mention Mention of a User in the content. offset and length count UTF-16 code units, as string indexes do in JavaScript, Kotlin and Swift's NSString
+type Mention struct {
+	// Handle Handle of the mentioned User, without the @
+	Handle string `json:"handle"`
+
+	// Length Length of the mention including the @
+	Length int `json:"length"`
+
+	// Offset Position of the @ in the content
+	Offset int    `json:"offset"`
+	UserId string `json:"userId"`
+}
+
+// Notification Group of the Notifications of the same type on the same target, e.g. the reactions on a Post. The ID, target and payload are those of the latest Notification of the group
+type Notification struct {
+	// ActorIds IDs of the latest Users who caused the Notifications, most recent first and at most three
+	ActorIds []string `json:"actorIds"`
+
+	// ActorsCount Number of Users who caused the Notifications, e.g. "A and 5 others liked your post"
+	ActorsCount int     `json:"actorsCount"`
+	CommentId   *string `json:"commentId,omitempty"`
+
+	// CreatedAt Time of the latest Notification of the group
+	CreatedAt time.Time `json:"createdAt"`
+	Id        string    `json:"id"`
+
+	// IsRead Whether all Notifications of the group were read
+	IsRead bool `json:"isRead"`
+
+	// Payload Details of a Notification that depend on its type
+	Payload NotificationPayload `json:"payload"`
+	PostId  *string             `json:"postId,omitempty"`
+
+	// Type What a Notification is about
+	Type NotificationType `json:"type"`
+}
+
+// NotificationPayload Details of a Notification that depend on its type
+type NotificationPayload struct {
+	// Action Action a moderator took when resolving a Report
+	Action *ReportAction `json:"action,omitempty"`
+
+	// Emoji Emoji of the latest reaction notification
+	Emoji *string `json:"emoji,omitempty"`
+
+	// ReportId ID of the resolved Report of a moderation notification
+	ReportId *string `json:"reportId,omitempty"`
+}
+
+// NotificationType What a Notification is about
+type NotificationType string
+
+// NotificationsUnreadCount defines model for NotificationsUnreadCount.
+type NotificationsUnreadCount struct {
+	// Count Number of groups with unread Notifications
+	Count int `json:"count"`
+}
+
+// PaginatedComments defines model for PaginatedComments.
+type PaginatedComments struct {
+	Items []Comment `json:"items"`
+
+	// NextCursor Cursor to fetch the next page, absent on the last page
+	NextCursor *string `json:"nextCursor,omitempty"`
+}
+
+// PaginatedPostRevisions defines model for PaginatedPostRevisions.
+type PaginatedPostRevisions struct {
+	Items []PostRevision `json:"items"`
+
+	// Limit Limit of items per page
+	Limit *int `json:"limit,omitempty"`
+
+	// Offset Offset of the current page
+	Offset *int `json:"offset,omitempty"`
+
+	// Total Total number of revisions of the Post
+	Total int `json:"total"`
+}
+
+// PaginatedPosts defines model for PaginatedPosts.
+type PaginatedPosts struct {
+	Items []Post `json:"items"`
+
+	// Limit Limit of items per page
+	Limit *int `json:"limit,omitempty"`
+
+	// Offset Offset of the current page
+	Offset *int `json:"offset,omitempty"`
+
+	// Total Total number of posts matching the query
+	Total int `json:"total"`
+}
+
+// PaginatedReactions defines model for PaginatedReactions.
+type PaginatedReactions struct {
+	Items []Reaction `json:"items"`
+
+	// Limit Limit of items per page
+	Limit *int `json:"limit,omitempty"`
+
+	// Offset Offset of the current page
+	Offset *int `json:"offset,omitempty"`
+
+	// Total Total number of matching Reactions
+	Total int `json:"total"`
+}
+
+// PaginatedUserProfiles defines model for PaginatedUserProfiles.
+type PaginatedUserProfiles struct {
+	Items []UserProfile `json:"items"`
+
+	// NextCursor Cursor to fetch the next page, absent on the last page
+	NextCursor *string `json:"nextCursor,omitempty"`
+}
+
+// Poll Poll attached to a Post. Vote counts are only returned once the current User voted or the Poll closed
+type Poll struct {
+	ClosesAt time.Time `json:"closesAt"`
+
+	// IsClosed Whether the Poll stopped accepting votes
+	IsClosed bool `json:"isClosed"`
+
+	// MultipleChoice Whether Users can vote for more than one option
+	MultipleChoice bool `json:"multipleChoice"`
+
+	// MyVotes IDs of the options the current User voted for
+	MyVotes []string     `json:"myVotes"`
+	Options []PollOption `json:"options"`
+
+	// VotersCount Number of Users who voted, absent while results are hidden
+	VotersCount *int `json:"votersCount,omitempty"`
+}
+
+// PollOption defines model for PollOption.
+type PollOption struct {
+	Id   string `json:"id"`
+	Text string `json:"text"`
+
+	// VotesCount Number of votes for the option, absent while results are hidden
+	VotesCount *int `json:"votesCount,omitempty"`
+}
+
+// PopularTag defines model for PopularTag.
+type PopularTag struct {
+	Name string `json:"name"`
+
+	// PostsCount Number of recent Posts with the Tag
+	PostsCount int `json:"postsCount"`
+}
+
+// PopularTags defines model for PopularTags.
+type PopularTags struct {
+	Items []PopularTag `json:"items"`
+}
+
+// Post defines model for Post.
+type Post struct {
+	AuthorId string `json:"authorId"`
+
+	// CommentsCount Number of Comments on the Post, including replies
+	CommentsCount int    `json:"commentsCount"`
+	Content       string `json:"content"`
+
+	// ContentFormat How the content of the Post is written, plain text or Markdown
+	ContentFormat ContentFormat `json:"contentFormat"`
+
+	// ContentHtml Content rendered to sanitized HTML, links are marked rel="nofollow"
+	ContentHtml string     `json:"contentHtml"`
+	CreatedAt   *time.Time `json:"createdAt,omitempty"`
+
+	// DeletedAt Time the Post was moved to the trash
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+
+	// EditedAt Time of the latest edit of the Post
+	EditedAt *time.Time `json:"editedAt,omitempty"`
+	Id       string     `json:"id"`
+
+	// IsBookmarked Whether the current User bookmarked the Post
+	IsBookmarked bool `json:"isBookmarked"`
+
+	// IsEdited Whether the Post has been changed since it was created
+	IsEdited bool `json:"isEdited"`
+
+	// IsPinned Whether the Post is pinned to the profile of its author
+	IsPinned bool `json:"isPinned"`
+
+	// IsReposted Whether the current User reposted the Post
+	IsReposted bool `json:"isReposted"`
+
+	// LinkPreviews Previews of the first links in the content, in order. Links are fetched in the background, so previews appear some time after the Post was saved
+	LinkPreviews []LinkPreview `json:"linkPreviews"`
+
+	// Media Media attached to the Post, in order
+	Media []Media `json:"media"`
+
+	// Mentions Mentions of existing Users in the content, in order
+	Mentions []Mention `json:"mentions"`
+
+	// MyReactions Emojis the current User reacted with
+	MyReactions []string `json:"myReactions"`
+
+	// Poll Poll attached to a Post. Vote counts are only returned once the current User voted or the Poll closed
+	Poll       *Poll `json:"poll,omitempty"`
+	QuotedPost *Post `json:"quotedPost,omitempty"`
+
+	// QuotedPostId ID of the Post quoted by the Post. quotedPost is absent when the quoted Post was deleted or cannot be read by the current User
+	QuotedPostId *string `json:"quotedPostId,omitempty"`
+
+	// ReactionCounts Number of Reactions on the Post by emoji
+	ReactionCounts map[string]int `json:"reactionCounts"`
+
+	// RepostedAt Time of the repost that brought the Post into the feed, only set on feed items
+	RepostedAt *time.Time `json:"repostedAt,omitempty"`
+
+	// RepostedBy ID of the User whose repost brought the Post into the feed, only set on feed items
+	RepostedBy *string `json:"repostedBy,omitempty"`
+
+	// RepostsCount Number of Users who reposted the Post
+	RepostsCount int `json:"repostsCount"`
+
+	// Tags Tags of the Post, including hashtags found in the content
+	Tags      []string   `json:"tags"`
+	Title     string     `json:"title"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+
+	// Version Version of the Post, incremented on every update
+	Version int `json:"version"`
+
+	// Visibility Who can read the Post. Followers-only Posts are readable by the followers of the author, private Posts only by the author. Unlisted Posts are readable by anyone with their ID but never listed
+	Visibility PostVisibility `json:"visibility"`
+}
+
+// PostRevision defines model for PostRevision.
+type PostRevision struct {
+	Content string `json:"content"`
+
+	// ContentFormat How the content of the Post is written, plain text or Markdown
+	ContentFormat ContentFormat `json:"contentFormat"`
+
+	// ContentHtml Content rendered to sanitized HTML
+	ContentHtml string    `json:"contentHtml"`
+	CreatedAt   time.Time `json:"createdAt"`
+
+	// EditorId ID of the User who made the change
+	EditorId *string `json:"editorId,omitempty"`
+
+	// Revision Sequential number of the revision, starting from 1
+	Revision int    `json:"revision"`
+	Title    string `json:"title"`
+}
+
+// PostRevisionDetails defines model for PostRevisionDetails.
+type PostRevisionDetails struct {
+	ContentDiff []DiffLine `json:"contentDiff"`
+
+	// PreviousRevision Revision the diff is computed against, absent for the first revision
+	PreviousRevision *int         `json:"previousRevision,omitempty"`
+	Revision         PostRevision `json:"revision"`
+	TitleDiff        []DiffLine   `json:"titleDiff"`
+}
+
+// PostStats defines model for PostStats.
+type PostStats struct {
+	PostId string `json:"postId"`
+
+	// TotalViews Number of views of the Post, each User is counted once per day
+	TotalViews int `json:"totalViews"`
+
+	// Views Views per day, oldest first
+	Views []PostViewsDay `json:"views"`
+}
+
+// PostViewsDay defines model for PostViewsDay.
+type PostViewsDay struct {
+	// Date UTC day
+	Date openapi_types.Date `json:"date"`
+
+	// Views Number of Users who viewed the Post on the day
+	Views int `json:"views"`
+}
+
+// PostVisibility Who can read the Post. Followers-only Posts are readable by the followers of the author, private Posts only by the author. Unlisted Posts are readable by anyone with their ID but never listed
+type PostVisibility string
+
+// Reaction defines model for Reaction.
+type Reaction struct {
+	CreatedAt time.Time `json:"createdAt"`
+	Emoji     string    `json:"emoji"`
+
+	// UserId ID of the User who reacted
+	UserId string `json:"userId"`
+}
+
+// RegisterRequest defines model for RegisterRequest.
+type RegisterRequest struct {
+	Email string `json:"email"`
+
+	// Handle 1 to 30 lowercase letters, digits and underscores. Derived from the email address when absent
+	Handle   *string `json:"handle,omitempty"`
+	Password string  `json:"password"`
+}
+
+// Report defines model for Report.
+type Report struct {
+	// Action Action a moderator took when resolving a Report
+	Action    *ReportAction `json:"action,omitempty"`
+	ClaimedAt *time.Time    `json:"claimedAt,omitempty"`
+
+	// ClaimedBy ID of the moderator who claimed the Report
+	ClaimedBy *string   `json:"claimedBy,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	Details   *string   `json:"details,omitempty"`
+	Id        string    `json:"id"`
+	PostId    string    `json:"postId"`
+
+	// Reason Why the Post was reported
+	Reason ReportReason `json:"reason"`
+
+	// ReporterId ID of the User who reported the Post, absent when the Post was flagged by the content filter
+	ReporterId *string    `json:"reporterId,omitempty"`
+	ResolvedAt *time.Time `json:"resolvedAt,omitempty"`
+
+	// ResolvedBy ID of the moderator who resolved the Report
+	ResolvedBy *string `json:"resolvedBy,omitempty"`
+
+	// Status Open Reports wait in the moderation queue until a moderator claims and then resolves them
+	Status ReportStatus `json:"status"`
+}
+
+// ReportAction Action a moderator took when resolving a Report
+type ReportAction string
+
+// ReportReason Why the Post was reported
+type ReportReason string
+
+// ReportStatus Open Reports wait in the moderation queue until a moderator claims and then resolves them
+type ReportStatus string
+
+// ResolveReportRequest defines model for ResolveReportRequest.
+type ResolveReportRequest struct {
+	// Action Action a moderator took when resolving a Report
+	Action ReportAction `json:"action"`
+}
+
+// UpdateCommentRequest defines model for UpdateCommentRequest.
+type UpdateCommentRequest struct {
+	Content string `json:"content"`
+}
+
+// UpdatePostRequest defines model for UpdatePostRequest.
+type UpdatePostRequest struct {
+	Content *string `json:"content,omitempty"`
+
+	// ContentFormat How the content of the Post is written, plain text or Markdown
+	ContentFormat *ContentFormat `json:"contentFormat,omitempty"`
+
+	// Tags Tags of the Post, hashtags found in the content are added automatically
+	Tags  *[]string `json:"tags,omitempty"`
+	Title *string   `json:"title,omitempty"`
+
+	// Visibility Who can read the Post. Followers-only Posts are readable by the followers of the author, private Posts only by the author. Unlisted Posts are readable by anyone with their ID but never listed
+	Visibility *PostVisibility `json:"visibility,omitempty"`
+}
+
+// User defines model for User.
+type User struct {
+	Email string `json:"email"`
+
+	// FollowersCount Number of Users following the User
+	FollowersCount int `json:"followersCount"`
+
+	// FollowingCount Number of Users the User follows
+	FollowingCount int `json:"followingCount"`
+
+	// Handle Unique name of the User to mention them with, e.g. @jane_doe
+	Handle string `json:"handle"`
+	Id     string `json:"id"`
+
+	// Role Moderators and admins can work on the moderation queue
+	Role UserRole `json:"role"`
+}
+
+// UserRole Moderators and admins can work on the moderation queue
+type UserRole string
+
+// UserProfile Public profile of a User
+type UserProfile struct {
+	CreatedAt time.Time `json:"createdAt"`
+
+	// FollowersCount Number of Users following the User
+	FollowersCount int `json:"followersCount"`
+
+	// FollowingCount Number of Users the User follows
+	FollowingCount int `json:"followingCount"`
+
+	// Handle Unique name of the User to mention them with, e.g. @jane_doe
+	Handle string `json:"handle"`
+	Id     string `json:"id"`
+
+	// IsFollowing Whether the current User follows the User
+	IsFollowing bool `json:"isFollowing"`
+}
+
+// IfMatch defines model for IfMatch.
+type IfMatch = string
+
+// PostPreconditionFailed defines model for PostPreconditionFailed.
+type PostPreconditionFailed = Post
+
+// GetFeedParams defines parameters for GetFeed.
+type GetFeedParams struct {
+	// Cursor Cursor returned with the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Maximum number of items to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// PostMediaMultipartBody defines parameters for PostMedia.
+type PostMediaMultipartBody struct {
+	File openapi_types.File `json:"file"`
+}
+
+// GetMediaMediaIdParams defines parameters for GetMediaMediaId.
+type GetMediaMediaIdParams struct {
+	// Variant Name of the variant to download instead of the original
+	Variant *GetMediaMediaIdParamsVariant `form:"variant,omitempty" json:"variant,omitempty"`
+
+	// Expires Unix time after which the URL expires
+	Expires int64 `form:"expires" json:"expires"`
+
+	// Signature Signature of the URL
+	Signature string `form:"signature" json:"signature"`
+
+	// Range Byte ranges to download
+	Range *string `json:"Range,omitempty"`
+}
+
+// GetMediaMediaIdParamsVariant defines parameters for GetMediaMediaId.
+type GetMediaMediaIdParamsVariant string
+
+// GetModerationReportsParams defines parameters for GetModerationReports.
+type GetModerationReportsParams struct {
+	// Status Status of the Reports to list
+	Status *ReportStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// Cursor Cursor returned with the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Maximum number of items to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetNotificationsParams defines parameters for GetNotifications.
+type GetNotificationsParams struct {
+	// Unread Only list groups with unread Notifications
+	Unread *bool `form:"unread,omitempty" json:"unread,omitempty"`
+
+	// Cursor Cursor returned with the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Maximum number of items to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetPostsParams defines parameters for GetPosts.
+type GetPostsParams struct {
+	// Offset Number of items to skip before starting to collect the result set
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Limit Maximum number of items to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// DeletePostsPostIdParams defines parameters for DeletePostsPostId.
+type DeletePostsPostIdParams struct {
+	// IfMatch ETag of the Post version the change is based on. When present, the change is rejected with 412 if the Post has been modified since.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// PatchPostsPostIdParams defines parameters for PatchPostsPostId.
+type PatchPostsPostIdParams struct {
+	// IfMatch ETag of the Post version the change is based on. When present, the change is rejected with 412 if the Post has been modified since.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// GetPostsPostIdCommentsParams defines parameters for GetPostsPostIdComments.
+type GetPostsPostIdCommentsParams struct {
+	// ParentId ID of the Comment to list replies of, top level Comments are listed when absent
+	ParentId *string `form:"parentId,omitempty" json:"parentId,omitempty"`
+
+	// Sort Order of the Comments, top orders by number of replies
+	Sort *GetPostsPostIdCommentsParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Cursor Cursor returned with the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Maximum number of items to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetPostsPostIdCommentsParamsSort defines parameters for GetPostsPostIdComments.
+type GetPostsPostIdCommentsParamsSort string
+
+// PutPostsPostIdPinParams defines parameters for PutPostsPostIdPin.
+type PutPostsPostIdPinParams struct {
+	// Position Position of the Post among the pinned Posts, starting at 1. Defaults to after the last pinned Post
+	Position *int `form:"position,omitempty" json:"position,omitempty"`
+}
+
+// GetPostsPostIdReactionsParams defines parameters for GetPostsPostIdReactions.
+type GetPostsPostIdReactionsParams struct {
+	// Emoji Only list Reactions with this emoji
+	Emoji *string `form:"emoji,omitempty" json:"emoji,omitempty"`
+
+	// Offset Number of items to skip before starting to collect the result set
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Limit Maximum number of items to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetPostsPostIdRevisionsParams defines parameters for GetPostsPostIdRevisions.
+type GetPostsPostIdRevisionsParams struct {
+	// Offset Number of items to skip before starting to collect the result set
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Limit Maximum number of items to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetPostsPostIdStatsParams defines parameters for GetPostsPostIdStats.
+type GetPostsPostIdStatsParams struct {
+	// Days Number of days to return views for, including today
+	Days *int `form:"days,omitempty" json:"days,omitempty"`
+}
+
+// GetTagsPopularParams defines parameters for GetTagsPopular.
+type GetTagsPopularParams struct {
+	// Days Number of days to count Posts of
+	Days *int `form:"days,omitempty" json:"days,omitempty"`
+
+	// Limit Maximum number of items to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetTagsTagPostsParams defines parameters for GetTagsTagPosts.
+type GetTagsTagPostsParams struct {
+	// Cursor Cursor returned with the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Maximum number of items to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetUsersMeBookmarksParams defines parameters for GetUsersMeBookmarks.
+type GetUsersMeBookmarksParams struct {
+	// Cursor Cursor returned with the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Maximum number of items to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetUsersMeTrashParams defines parameters for GetUsersMeTrash.
+type GetUsersMeTrashParams struct {
+	// Offset Number of items to skip before starting to collect the result set
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Limit Maximum number of items to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetUsersUserIdFollowersParams defines parameters for GetUsersUserIdFollowers.
+type GetUsersUserIdFollowersParams struct {
+	// Cursor Cursor returned with the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Maximum number of items to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetUsersUserIdFollowingParams defines parameters for GetUsersUserIdFollowing.
+type GetUsersUserIdFollowingParams struct {
+	// Cursor Cursor returned with the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Maximum number of items to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetUsersUserIdPostsParams defines parameters for GetUsersUserIdPosts.
+type GetUsersUserIdPostsParams struct {
+	// Cursor Cursor returned with the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Maximum number of items to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// PostAuthLoginJSONRequestBody defines body for PostAuthLogin for application/json ContentType.
+type PostAuthLoginJSONRequestBody = LoginRequest
+
+// PostAuthRegisterJSONRequestBody defines body for PostAuthRegister for application/json ContentType.
+type PostAuthRegisterJSONRequestBody = RegisterRequest
+
+// PostMediaMultipartRequestBody defines body for PostMedia for multipart/form-data ContentType.
+type PostMediaMultipartRequestBody PostMediaMultipartBody
+
+// PostModerationReportsReportIdResolveJSONRequestBody defines body for PostModerationReportsReportIdResolve for application/json ContentType.
+type PostModerationReportsReportIdResolveJSONRequestBody = ResolveReportRequest
+
+// PostPostsJSONRequestBody defines body for PostPosts for application/json ContentType.
+type PostPostsJSONRequestBody = CreatePostRequest
+
+// PatchPostsPostIdJSONRequestBody defines body for PatchPostsPostId for application/json ContentType.
+type PatchPostsPostIdJSONRequestBody = UpdatePostRequest
+
+// PostPostsPostIdCommentsJSONRequestBody defines body for PostPostsPostIdComments for application/json ContentType.
+type PostPostsPostIdCommentsJSONRequestBody = CreateCommentRequest
+
+// PatchPostsPostIdCommentsCommentIdJSONRequestBody defines body for PatchPostsPostIdCommentsCommentId for application/json ContentType.
+type PatchPostsPostIdCommentsCommentIdJSONRequestBody = UpdateCommentRequest
+
+// PostPostsPostIdPollVotesJSONRequestBody defines body for PostPostsPostIdPollVotes for application/json ContentType.
+type PostPostsPostIdPollVotesJSONRequestBody = CreatePollVoteRequest
+
+// PostPostsPostIdReportsJSONRequestBody defines body for PostPostsPostIdReports for application/json ContentType.
+type PostPostsPostIdReportsJSONRequestBody = CreateReportRequest
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Log in user
+	// (POST /auth/login)
+	PostAuthLogin(ctx echo.Context) error
+	// Refresh JWT token
+	// (POST /auth/refresh)
+	PostAuthRefresh(ctx echo.Context) error
+	// Register a new user
+	// (POST /auth/register)
+	PostAuthRegister(ctx echo.Context) error
+	// Get home timeline
+	// (GET /feed)
+	GetFeed(ctx echo.Context, params GetFeedParams) error
+	// Upload Media
+	// (POST /media)
+	PostMedia(ctx echo.Context) error
+	// Download Media
+	// (GET /media/{mediaId})
+	GetMediaMediaId(ctx echo.Context, mediaId string, params GetMediaMediaIdParams) error
+	// List Reports
+	// (GET /moderation/reports)
+	GetModerationReports(ctx echo.Context, params GetModerationReportsParams) error
+	// Claim Report
+	// (POST /moderation/reports/{reportId}/claim)
+	PostModerationReportsReportIdClaim(ctx echo.Context, reportId string) error
+	// Resolve Report
+	// (POST /moderation/reports/{reportId}/resolve)
+	PostModerationReportsReportIdResolve(ctx echo.Context, reportId string) error
+	// List Notifications
+	// (GET /notifications)
+	GetNotifications(ctx echo.Context, params GetNotificationsParams) error
+	// Mark all Notifications read
+	// (POST /notifications/read)
+	PostNotificationsRead(ctx echo.Context) error
+	// Count unread Notifications
+	// (GET /notifications/unread-count)
+	GetNotificationsUnreadCount(ctx echo.Context) error
+	// Mark Notification read
+	// (POST /notifications/{notificationId}/read)
+	PostNotificationsNotificationIdRead(ctx echo.Context, notificationId string) error
+	// Ping the server
+	// (GET /ping)
+	GetPing(ctx echo.Context) error
+	// List Posts
+	// (GET /posts)
+	GetPosts(ctx echo.Context, params GetPostsParams) error
+	// Create a new Post
+	// (POST /posts)
+	PostPosts(ctx echo.Context) error
+	// Delete Post
+	// (DELETE /posts/{postId})
+	DeletePostsPostId(ctx echo.Context, postId string, params DeletePostsPostIdParams) error
+	// Get Post by ID
+	// (GET /posts/{postId})
+	GetPostsPostId(ctx echo.Context, postId string) error
+	// Update Post
+	// (PATCH /posts/{postId})
+	PatchPostsPostId(ctx echo.Context, postId string, params PatchPostsPostIdParams) error
+	// Remove Bookmark of Post
+	// (DELETE /posts/{postId}/bookmark)
+	DeletePostsPostIdBookmark(ctx echo.Context, postId string) error
+	// Bookmark Post
+	// (PUT /posts/{postId}/bookmark)
+	PutPostsPostIdBookmark(ctx echo.Context, postId string) error
+	// List Comments of Post
+	// (GET /posts/{postId}/comments)
+	GetPostsPostIdComments(ctx echo.Context, postId string, params GetPostsPostIdCommentsParams) error
+	// Create a new Comment
+	// (POST /posts/{postId}/comments)
+	PostPostsPostIdComments(ctx echo.Context, postId string) error
+	// Delete Comment
+	// (DELETE /posts/{postId}/comments/{commentId})
+	DeletePostsPostIdCommentsCommentId(ctx echo.Context, postId string, commentId string) error
+	// Get Comment by ID
+	// (GET /posts/{postId}/comments/{commentId})
+	GetPostsPostIdCommentsCommentId(ctx echo.Context, postId string, commentId string) error
+	// Update Comment
+	// (PATCH /posts/{postId}/comments/{commentId})
+	PatchPostsPostIdCommentsCommentId(ctx echo.Context, postId string, commentId string) error
+	// Unpin Post
+	// (DELETE /posts/{postId}/pin)
+	DeletePostsPostIdPin(ctx echo.Context, postId string) error
+	// Pin Post
+	// (PUT /posts/{postId}/pin)
+	PutPostsPostIdPin(ctx echo.Context, postId string, params PutPostsPostIdPinParams) error
+	// Vote in Poll
+	// (POST /posts/{postId}/poll/votes)
+	PostPostsPostIdPollVotes(ctx echo.Context, postId string) error
+	// List Reactions on Post
+	// (GET /posts/{postId}/reactions)
+	GetPostsPostIdReactions(ctx echo.Context, postId string, params GetPostsPostIdReactionsParams) error
+	// Remove Reaction from Post
+	// (DELETE /posts/{postId}/reactions/{emoji})
+	DeletePostsPostIdReactionsEmoji(ctx echo.Context, postId string, emoji string) error
+	// React to Post
+	// (PUT /posts/{postId}/reactions/{emoji})
+	PutPostsPostIdReactionsEmoji(ctx echo.Context, postId string, emoji string) error
+	// Report Post
+	// (POST /posts/{postId}/reports)
+	PostPostsPostIdReports(ctx echo.Context, postId string) error
+	// Remove Repost of Post
+	// (DELETE /posts/{postId}/repost)
+	DeletePostsPostIdRepost(ctx echo.Context, postId string) error
+	// Repost Post
+	// (PUT /posts/{postId}/repost)
+	PutPostsPostIdRepost(ctx echo.Context, postId string) error
+	// Restore Post from trash
+	// (POST /posts/{postId}/restore)
+	PostPostsPostIdRestore(ctx echo.Context, postId string) error
+	// List revisions of Post
+	// (GET /posts/{postId}/revisions)
+	GetPostsPostIdRevisions(ctx echo.Context, postId string, params GetPostsPostIdRevisionsParams) error
+	// Get Post revision with diff
+	// (GET /posts/{postId}/revisions/{revision})
+	GetPostsPostIdRevisionsRevision(ctx echo.Context, postId string, revision int) error
+	// Restore Post to revision
+	// (POST /posts/{postId}/revisions/{revision}/restore)
+	PostPostsPostIdRevisionsRevisionRestore(ctx echo.Context, postId string, revision int) error
+	// Get Post statistics
+	// (GET /posts/{postId}/stats)
+	GetPostsPostIdStats(ctx echo.Context, postId string, params GetPostsPostIdStatsParams) error
+	// List popular Tags
+	// (GET /tags/popular)
+	GetTagsPopular(ctx echo.Context, params GetTagsPopularParams) error
+	// List Posts with Tag
+	// (GET /tags/{tag}/posts)
+	GetTagsTagPosts(ctx echo.Context, tag string, params GetTagsTagPostsParams) error
+	// Get current user
+	// (GET /users/me)
+	GetUsersMe(ctx echo.Context) error
+	// List bookmarked Posts of current user
+	// (GET /users/me/bookmarks)
+	GetUsersMeBookmarks(ctx echo.Context, params GetUsersMeBookmarksParams) error
+	// List trashed Posts of current user
+	// (GET /users/me/trash)
+	GetUsersMeTrash(ctx echo.Context, params GetUsersMeTrashParams) error
+	// Get User profile by ID
+	// (GET /users/{userId})
+	GetUsersUserId(ctx echo.Context, userId string) error
+	// Unblock User
+	// (DELETE /users/{userId}/block)
+	DeleteUsersUserIdBlock(ctx echo.Context, userId string) error
+	// Block User
+	// (PUT /users/{userId}/block)
+	PutUsersUserIdBlock(ctx echo.Context, userId string) error
+	// Unfollow User
+	// (DELETE /users/{userId}/follow)
+	DeleteUsersUserIdFollow(ctx echo.Context, userId string) error
+	// Follow User
+	// (PUT /users/{userId}/follow)
+	PutUsersUserIdFollow(ctx echo.Context, userId string) error
+	// List followers of User
+	// (GET /users/{userId}/followers)
+	GetUsersUserIdFollowers(ctx echo.Context, userId string, params GetUsersUserIdFollowersParams) error
+	// List Users followed by User
+	// (GET /users/{userId}/following)
+	GetUsersUserIdFollowing(ctx echo.Context, userId string, params GetUsersUserIdFollowingParams) error
+	// Unmute User
+	// (DELETE /users/{userId}/mute)
+	DeleteUsersUserIdMute(ctx echo.Context, userId string) error
+	// Mute User
+	// (PUT /users/{userId}/mute)
+	PutUsersUserIdMute(ctx echo.Context, userId string) error
+	// List Posts of User
+	// (GET /users/{userId}/posts)
+	GetUsersUserIdPosts(ctx echo.Context, userId string, params GetUsersUserIdPostsParams) error
+}
+
+// ServerInterfaceWrapper converts echo contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler ServerInterface
+}
+
+// PostAuthLogin converts echo context to params.
+func (w *ServerInterfaceWrapper) PostAuthLogin(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostAuthLogin(ctx)
+	return err
+}
+
+// PostAuthRefresh converts echo context to params.
+func (w *ServerInterfaceWrapper) PostAuthRefresh(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostAuthRefresh(ctx)
+	return err
+}
+
+// PostAuthRegister converts echo context to params.
+func (w *ServerInterfaceWrapper) PostAuthRegister(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostAuthRegister(ctx)
+	return err
+}
+
+// GetFeed converts echo context to params.
+func (w *ServerInterfaceWrapper) GetFeed(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetFeedParams
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetFeed(ctx, params)
+	return err
+}
+
+// PostMedia converts echo context to params.
+func (w *ServerInterfaceWrapper) PostMedia(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostMedia(ctx)
+	return err
+}
+
+// GetMediaMediaId converts echo context to params.
+func (w *ServerInterfaceWrapper) GetMediaMediaId(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "mediaId" -------------
+	var mediaId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "mediaId", ctx.Param("mediaId"), &mediaId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter mediaId: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetMediaMediaIdParams
+	// ------------- Optional query parameter "variant" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "variant", ctx.QueryParams(), &params.Variant)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter variant: %s", err))
+	}
+
+	// ------------- Required query parameter "expires" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "expires", ctx.QueryParams(), &params.Expires)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter expires: %s", err))
+	}
+
+	// ------------- Required query parameter "signature" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "signature", ctx.QueryParams(), &params.Signature)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter signature: %s", err))
+	}
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "Range" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Range")]; found {
+		var Range string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for Range, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Range", valueList[0], &Range, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter Range: %s", err))
+		}
+
+		params.Range = &Range
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetMediaMediaId(ctx, mediaId, params)
+	return err
+}
+
+// GetModerationReports converts echo context to params.
+func (w *ServerInterfaceWrapper) GetModerationReports(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetModerationReportsParams
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", ctx.QueryParams(), &params.Status)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter status: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetModerationReports(ctx, params)
+	return err
+}
+
+// PostModerationReportsReportIdClaim converts echo context to params.
+func (w *ServerInterfaceWrapper) PostModerationReportsReportIdClaim(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "reportId" -------------
+	var reportId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "reportId", ctx.Param("reportId"), &reportId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter reportId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostModerationReportsReportIdClaim(ctx, reportId)
+	return err
+}
+
+// PostModerationReportsReportIdResolve converts echo context to params.
+func (w *ServerInterfaceWrapper) PostModerationReportsReportIdResolve(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "reportId" -------------
+	var reportId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "reportId", ctx.Param("reportId"), &reportId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter reportId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostModerationReportsReportIdResolve(ctx, reportId)
+	return err
+}
+
+// GetNotifications converts echo context to params.
+func (w *ServerInterfaceWrapper) GetNotifications(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetNotificationsParams
+	// ------------- Optional query parameter "unread" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "unread", ctx.QueryParams(), &params.Unread)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter unread: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetNotifications(ctx, params)
+	return err
+}
+
+// PostNotificationsRead converts echo context to params.
+func (w *ServerInterfaceWrapper) PostNotificationsRead(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostNotificationsRead(ctx)
+	return err
+}
+
+// GetNotificationsUnreadCount converts echo context to params.
+func (w *ServerInterfaceWrapper) GetNotificationsUnreadCount(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetNotificationsUnreadCount(ctx)
+	return err
+}
+
+// PostNotificationsNotificationIdRead converts echo context to params.
+func (w *ServerInterfaceWrapper) PostNotificationsNotificationIdRead(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "notificationId" -------------
+	var notificationId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "notificationId", ctx.Param("notificationId"), &notificationId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter notificationId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostNotificationsNotificationIdRead(ctx, notificationId)
+	return err
+}
+
+// GetPing converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPing(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetPing(ctx)
+	return err
+}
+
+// GetPosts converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPosts(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPostsParams
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetPosts(ctx, params)
+	return err
+}
+
+// PostPosts converts echo context to params.
+func (w *ServerInterfaceWrapper) PostPosts(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostPosts(ctx)
+	return err
+}
+
+// DeletePostsPostId converts echo context to params.
+func (w *ServerInterfaceWrapper) DeletePostsPostId(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "postId" -------------
+	var postId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postId", ctx.Param("postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter postId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeletePostsPostIdParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Match: %s", err))
+		}
+
+		params.IfMatch = &IfMatch
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeletePostsPostId(ctx, postId, params)
+	return err
+}
+
+// GetPostsPostId converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPostsPostId(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "postId" -------------
+	var postId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postId", ctx.Param("postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter postId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetPostsPostId(ctx, postId)
+	return err
+}
+
+// PatchPostsPostId converts echo context to params.
+func (w *ServerInterfaceWrapper) PatchPostsPostId(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "postId" -------------
+	var postId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postId", ctx.Param("postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter postId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PatchPostsPostIdParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Match: %s", err))
+		}
+
+		params.IfMatch = &IfMatch
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PatchPostsPostId(ctx, postId, params)
+	return err
+}
+
+// DeletePostsPostIdBookmark converts echo context to params.
+func (w *ServerInterfaceWrapper) DeletePostsPostIdBookmark(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "postId" -------------
+	var postId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postId", ctx.Param("postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter postId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeletePostsPostIdBookmark(ctx, postId)
+	return err
+}
+
+// PutPostsPostIdBookmark converts echo context to params.
+func (w *ServerInterfaceWrapper) PutPostsPostIdBookmark(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "postId" -------------
+	var postId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postId", ctx.Param("postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter postId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PutPostsPostIdBookmark(ctx, postId)
+	return err
+}
+
+// GetPostsPostIdComments converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPostsPostIdComments(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "postId" -------------
+	var postId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postId", ctx.Param("postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter postId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPostsPostIdCommentsParams
+	// ------------- Optional query parameter "parentId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "parentId", ctx.QueryParams(), &params.ParentId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter parentId: %s", err))
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", ctx.QueryParams(), &params.Sort)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sort: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetPostsPostIdComments(ctx, postId, params)
+	return err
+}
+
+// PostPostsPostIdComments converts echo context to params.
+func (w *ServerInterfaceWrapper) PostPostsPostIdComments(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "postId" -------------
+	var postId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postId", ctx.Param("postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter postId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostPostsPostIdComments(ctx, postId)
+	return err
+}
+
+// DeletePostsPostIdCommentsCommentId converts echo context to params.
+func (w *ServerInterfaceWrapper) DeletePostsPostIdCommentsCommentId(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "postId" -------------
+	var postId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postId", ctx.Param("postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter postId: %s", err))
+	}
+
+	// ------------- Path parameter "commentId" -------------
+	var commentId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "commentId", ctx.Param("commentId"), &commentId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter commentId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeletePostsPostIdCommentsCommentId(ctx, postId, commentId)
+	return err
+}
+
+// GetPostsPostIdCommentsCommentId converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPostsPostIdCommentsCommentId(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "postId" -------------
+	var postId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postId", ctx.Param("postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter postId: %s", err))
+	}
+
+	// ------------- Path parameter "commentId" -------------
+	var commentId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "commentId", ctx.Param("commentId"), &commentId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter commentId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetPostsPostIdCommentsCommentId(ctx, postId, commentId)
+	return err
+}
+
+// PatchPostsPostIdCommentsCommentId converts echo context to params.
+func (w *ServerInterfaceWrapper) PatchPostsPostIdCommentsCommentId(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "postId" -------------
+	var postId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postId", ctx.Param("postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter postId: %s", err))
+	}
+
+	// ------------- Path parameter "commentId" -------------
+	var commentId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "commentId", ctx.Param("commentId"), &commentId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter commentId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PatchPostsPostIdCommentsCommentId(ctx, postId, commentId)
+	return err
+}
+
+// DeletePostsPostIdPin converts echo context to params.
+func (w *ServerInterfaceWrapper) DeletePostsPostIdPin(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "postId" -------------
+	var postId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postId", ctx.Param("postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter postId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeletePostsPostIdPin(ctx, postId)
+	return err
+}
+
+// PutPostsPostIdPin converts echo context to params.
+func (w *ServerInterfaceWrapper) PutPostsPostIdPin(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "postId" -------------
+	var postId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postId", ctx.Param("postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter postId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PutPostsPostIdPinParams
+	// ------------- Optional query parameter "position" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "position", ctx.QueryParams(), &params.Position)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter position: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PutPostsPostIdPin(ctx, postId, params)
+	return err
+}
+
+// PostPostsPostIdPollVotes converts echo context to params.
+func (w *ServerInterfaceWrapper) PostPostsPostIdPollVotes(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "postId" -------------
+	var postId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postId", ctx.Param("postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter postId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostPostsPostIdPollVotes(ctx, postId)
+	return err
+}
+
+// GetPostsPostIdReactions converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPostsPostIdReactions(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "postId" -------------
+	var postId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postId", ctx.Param("postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter postId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPostsPostIdReactionsParams
+	// ------------- Optional query parameter "emoji" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "emoji", ctx.QueryParams(), &params.Emoji)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter emoji: %s", err))
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetPostsPostIdReactions(ctx, postId, params)
+	return err
+}
+
+// DeletePostsPostIdReactionsEmoji converts echo context to params.
+func (w *ServerInterfaceWrapper) DeletePostsPostIdReactionsEmoji(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "postId" -------------
+	var postId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postId", ctx.Param("postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter postId: %s", err))
+	}
+
+	// ------------- Path parameter "emoji" -------------
+	var emoji string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "emoji", ctx.Param("emoji"), &emoji, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter emoji: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeletePostsPostIdReactionsEmoji(ctx, postId, emoji)
+	return err
+}
+
+// PutPostsPostIdReactionsEmoji converts echo context to params.
+func (w *ServerInterfaceWrapper) PutPostsPostIdReactionsEmoji(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "postId" -------------
+	var postId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postId", ctx.Param("postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter postId: %s", err))
+	}
+
+	// ------------- Path parameter "emoji" -------------
+	var emoji string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "emoji", ctx.Param("emoji"), &emoji, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter emoji: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PutPostsPostIdReactionsEmoji(ctx, postId, emoji)
+	return err
+}
+
+// PostPostsPostIdReports converts echo context to params.
+func (w *ServerInterfaceWrapper) PostPostsPostIdReports(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "postId" -------------
+	var postId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postId", ctx.Param("postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter postId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostPostsPostIdReports(ctx, postId)
+	return err
+}
+
+// DeletePostsPostIdRepost converts echo context to params.
+func (w *ServerInterfaceWrapper) DeletePostsPostIdRepost(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "postId" -------------
+	var postId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postId", ctx.Param("postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter postId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeletePostsPostIdRepost(ctx, postId)
+	return err
+}
+
+// PutPostsPostIdRepost converts echo context to params.
+func (w *ServerInterfaceWrapper) PutPostsPostIdRepost(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "postId" -------------
+	var postId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postId", ctx.Param("postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter postId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PutPostsPostIdRepost(ctx, postId)
+	return err
+}
+
+// PostPostsPostIdRestore converts echo context to params.
+func (w *ServerInterfaceWrapper) PostPostsPostIdRestore(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "postId" -------------
+	var postId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postId", ctx.Param("postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter postId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostPostsPostIdRestore(ctx, postId)
+	return err
+}
+
+// GetPostsPostIdRevisions converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPostsPostIdRevisions(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "postId" -------------
+	var postId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postId", ctx.Param("postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter postId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPostsPostIdRevisionsParams
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetPostsPostIdRevisions(ctx, postId, params)
+	return err
+}
+
+// GetPostsPostIdRevisionsRevision converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPostsPostIdRevisionsRevision(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "postId" -------------
+	var postId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postId", ctx.Param("postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter postId: %s", err))
+	}
+
+	// ------------- Path parameter "revision" -------------
+	var revision int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "revision", ctx.Param("revision"), &revision, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter revision: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetPostsPostIdRevisionsRevision(ctx, postId, revision)
+	return err
+}
+
+// PostPostsPostIdRevisionsRevisionRestore converts echo context to params.
+func (w *ServerInterfaceWrapper) PostPostsPostIdRevisionsRevisionRestore(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "postId" -------------
+	var postId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postId", ctx.Param("postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter postId: %s", err))
+	}
+
+	// ------------- Path parameter "revision" -------------
+	var revision int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "revision", ctx.Param("revision"), &revision, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter revision: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostPostsPostIdRevisionsRevisionRestore(ctx, postId, revision)
+	return err
+}
+
+// GetPostsPostIdStats converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPostsPostIdStats(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "postId" -------------
+	var postId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postId", ctx.Param("postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter postId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPostsPostIdStatsParams
+	// ------------- Optional query parameter "days" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "days", ctx.QueryParams(), &params.Days)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter days: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetPostsPostIdStats(ctx, postId, params)
+	return err
+}
+
+// GetTagsPopular converts echo context to params.
+func (w *ServerInterfaceWrapper) GetTagsPopular(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTagsPopularParams
+	// ------------- Optional query parameter "days" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "days", ctx.QueryParams(), &params.Days)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter days: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
 
-// GeneralError defines model for GeneralError.
-type GeneralError struct {
-	// FieldErrors Validation errors for specific fields
-	FieldErrors *map[string]string `json:"fieldErrors,omitempty"`
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
 
-	// Message A description of the error
-	Message string `json:"message"`
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetTagsPopular(ctx, params)
+	return err
 }
 
-// LoginRequest defines model for LoginRequest.
-type LoginRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
-}
+// GetTagsTagPosts converts echo context to params.
+func (w *ServerInterfaceWrapper) GetTagsTagPosts(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "tag" -------------
+	var tag string
 
-// PaginatedPosts defines model for PaginatedPosts.
-type PaginatedPosts struct {
-	Items []Post `json:"items"`
+	err = runtime.BindStyledParameterWithOptions("simple", "tag", ctx.Param("tag"), &tag, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter tag: %s", err))
+	}
 
-	// Limit Limit of items per page
-	Limit *int `json:"limit,omitempty"`
+	ctx.Set(BearerAuthScopes, []string{})
 
-	// Offset Offset of the current page
-	Offset *int `json:"offset,omitempty"`
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTagsTagPostsParams
+	// ------------- Optional query parameter "cursor" -------------
 
-	// Total Total number of posts matching the query
-	Total int `json:"total"`
-}
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
 
-// Post defines model for Post.
-type Post struct {
-	AuthorId  string     `json:"authorId"`
-	Content   string     `json:"content"`
-	CreatedAt *time.Time `json:"createdAt,omitempty"`
-	Id        string     `json:"id"`
-	Title     string     `json:"title"`
-	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
-}
+	// ------------- Optional query parameter "limit" -------------
 
-// RegisterRequest defines model for RegisterRequest.
-type RegisterRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
-}
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
 
-// UpdatePostRequest defines model for UpdatePostRequest.
-type UpdatePostRequest struct {
-	Content *string `json:"content,omitempty"`
-	Title   *string `json:"title,omitempty"`
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetTagsTagPosts(ctx, tag, params)
+	return err
 }
 
-// User defines model for User.
-type User struct {
-	Email string `json:"email"`
-	Id    string `json:"id"`
+// GetUsersMe converts echo context to params.
+func (w *ServerInterfaceWrapper) GetUsersMe(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetUsersMe(ctx)
+	return err
 }
 
-// GetPostsParams defines parameters for GetPosts.
-type GetPostsParams struct {
-	// Offset Number of items to skip before starting to collect the result set
-	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+// GetUsersMeBookmarks converts echo context to params.
+func (w *ServerInterfaceWrapper) GetUsersMeBookmarks(ctx echo.Context) error {
+	var err error
 
-	// Limit Maximum number of items to return
-	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersMeBookmarksParams
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetUsersMeBookmarks(ctx, params)
+	return err
 }
 
-// PostAuthLoginJSONRequestBody defines body for PostAuthLogin for application/json ContentType.
-type PostAuthLoginJSONRequestBody = LoginRequest
+// GetUsersMeTrash converts echo context to params.
+func (w *ServerInterfaceWrapper) GetUsersMeTrash(ctx echo.Context) error {
+	var err error
 
-// PostAuthRegisterJSONRequestBody defines body for PostAuthRegister for application/json ContentType.
-type PostAuthRegisterJSONRequestBody = RegisterRequest
+	ctx.Set(BearerAuthScopes, []string{})
 
-// PostPostsJSONRequestBody defines body for PostPosts for application/json ContentType.
-type PostPostsJSONRequestBody = CreatePostRequest
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersMeTrashParams
+	// ------------- Optional query parameter "offset" -------------
 
-// PatchPostsPostIdJSONRequestBody defines body for PatchPostsPostId for application/json ContentType.
-type PatchPostsPostIdJSONRequestBody = UpdatePostRequest
+	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+	}
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// Log in user
-	// (POST /auth/login)
-	PostAuthLogin(ctx echo.Context) error
-	// Refresh JWT token
-	// (POST /auth/refresh)
-	PostAuthRefresh(ctx echo.Context) error
-	// Register a new user
-	// (POST /auth/register)
-	PostAuthRegister(ctx echo.Context) error
-	// Ping the server
-	// (GET /ping)
-	GetPing(ctx echo.Context) error
-	// List Posts
-	// (GET /posts)
-	GetPosts(ctx echo.Context, params GetPostsParams) error
-	// Create a new Post
-	// (POST /posts)
-	PostPosts(ctx echo.Context) error
-	// Delete Post
-	// (DELETE /posts/{postId})
-	DeletePostsPostId(ctx echo.Context, postId string) error
-	// Get Post by ID
-	// (GET /posts/{postId})
-	GetPostsPostId(ctx echo.Context, postId string) error
-	// Update Post
-	// (PATCH /posts/{postId})
-	PatchPostsPostId(ctx echo.Context, postId string) error
-	// Get current user
-	// (GET /users/me)
-	GetUsersMe(ctx echo.Context) error
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetUsersMeTrash(ctx, params)
+	return err
 }
 
-// ServerInterfaceWrapper converts echo contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler ServerInterface
+// GetUsersUserId converts echo context to params.
+func (w *ServerInterfaceWrapper) GetUsersUserId(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "userId" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", ctx.Param("userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter userId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetUsersUserId(ctx, userId)
+	return err
 }
 
-// PostAuthLogin converts echo context to params.
-func (w *ServerInterfaceWrapper) PostAuthLogin(ctx echo.Context) error {
+// DeleteUsersUserIdBlock converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteUsersUserIdBlock(ctx echo.Context) error {
 	var err error
+	// ------------- Path parameter "userId" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", ctx.Param("userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter userId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.PostAuthLogin(ctx)
+	err = w.Handler.DeleteUsersUserIdBlock(ctx, userId)
 	return err
 }
 
-// PostAuthRefresh converts echo context to params.
-func (w *ServerInterfaceWrapper) PostAuthRefresh(ctx echo.Context) error {
+// PutUsersUserIdBlock converts echo context to params.
+func (w *ServerInterfaceWrapper) PutUsersUserIdBlock(ctx echo.Context) error {
 	var err error
+	// ------------- Path parameter "userId" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", ctx.Param("userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter userId: %s", err))
+	}
 
 	ctx.Set(BearerAuthScopes, []string{})
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.PostAuthRefresh(ctx)
+	err = w.Handler.PutUsersUserIdBlock(ctx, userId)
 	return err
 }
 
-// PostAuthRegister converts echo context to params.
-func (w *ServerInterfaceWrapper) PostAuthRegister(ctx echo.Context) error {
+// DeleteUsersUserIdFollow converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteUsersUserIdFollow(ctx echo.Context) error {
 	var err error
+	// ------------- Path parameter "userId" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", ctx.Param("userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter userId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.PostAuthRegister(ctx)
+	err = w.Handler.DeleteUsersUserIdFollow(ctx, userId)
 	return err
 }
 
-// GetPing converts echo context to params.
-func (w *ServerInterfaceWrapper) GetPing(ctx echo.Context) error {
+// PutUsersUserIdFollow converts echo context to params.
+func (w *ServerInterfaceWrapper) PutUsersUserIdFollow(ctx echo.Context) error {
 	var err error
+	// ------------- Path parameter "userId" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", ctx.Param("userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter userId: %s", err))
+	}
 
 	ctx.Set(BearerAuthScopes, []string{})
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetPing(ctx)
+	err = w.Handler.PutUsersUserIdFollow(ctx, userId)
 	return err
 }
 
-// GetPosts converts echo context to params.
-func (w *ServerInterfaceWrapper) GetPosts(ctx echo.Context) error {
+// GetUsersUserIdFollowers converts echo context to params.
+func (w *ServerInterfaceWrapper) GetUsersUserIdFollowers(ctx echo.Context) error {
 	var err error
+	// ------------- Path parameter "userId" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", ctx.Param("userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter userId: %s", err))
+	}
 
 	ctx.Set(BearerAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetPostsParams
-	// ------------- Optional query parameter "offset" -------------
+	var params GetUsersUserIdFollowersParams
+	// ------------- Optional query parameter "cursor" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
 	}
 
 	// ------------- Optional query parameter "limit" -------------
@@ -209,83 +2283,111 @@ func (w *ServerInterfaceWrapper) GetPosts(ctx echo.Context) error {
 	}
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetPosts(ctx, params)
+	err = w.Handler.GetUsersUserIdFollowers(ctx, userId, params)
 	return err
 }
 
-// PostPosts converts echo context to params.
-func (w *ServerInterfaceWrapper) PostPosts(ctx echo.Context) error {
+// GetUsersUserIdFollowing converts echo context to params.
+func (w *ServerInterfaceWrapper) GetUsersUserIdFollowing(ctx echo.Context) error {
 	var err error
+	// ------------- Path parameter "userId" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", ctx.Param("userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter userId: %s", err))
+	}
 
 	ctx.Set(BearerAuthScopes, []string{})
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersUserIdFollowingParams
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.PostPosts(ctx)
+	err = w.Handler.GetUsersUserIdFollowing(ctx, userId, params)
 	return err
 }
 
-// DeletePostsPostId converts echo context to params.
-func (w *ServerInterfaceWrapper) DeletePostsPostId(ctx echo.Context) error {
+// DeleteUsersUserIdMute converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteUsersUserIdMute(ctx echo.Context) error {
 	var err error
-	// ------------- Path parameter "postId" -------------
-	var postId string
+	// ------------- Path parameter "userId" -------------
+	var userId string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "postId", ctx.Param("postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", ctx.Param("userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter postId: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter userId: %s", err))
 	}
 
 	ctx.Set(BearerAuthScopes, []string{})
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.DeletePostsPostId(ctx, postId)
+	err = w.Handler.DeleteUsersUserIdMute(ctx, userId)
 	return err
 }
 
-// GetPostsPostId converts echo context to params.
-func (w *ServerInterfaceWrapper) GetPostsPostId(ctx echo.Context) error {
+// PutUsersUserIdMute converts echo context to params.
+func (w *ServerInterfaceWrapper) PutUsersUserIdMute(ctx echo.Context) error {
 	var err error
-	// ------------- Path parameter "postId" -------------
-	var postId string
+	// ------------- Path parameter "userId" -------------
+	var userId string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "postId", ctx.Param("postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", ctx.Param("userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter postId: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter userId: %s", err))
 	}
 
 	ctx.Set(BearerAuthScopes, []string{})
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetPostsPostId(ctx, postId)
+	err = w.Handler.PutUsersUserIdMute(ctx, userId)
 	return err
 }
 
-// PatchPostsPostId converts echo context to params.
-func (w *ServerInterfaceWrapper) PatchPostsPostId(ctx echo.Context) error {
+// GetUsersUserIdPosts converts echo context to params.
+func (w *ServerInterfaceWrapper) GetUsersUserIdPosts(ctx echo.Context) error {
 	var err error
-	// ------------- Path parameter "postId" -------------
-	var postId string
+	// ------------- Path parameter "userId" -------------
+	var userId string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "postId", ctx.Param("postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", ctx.Param("userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter postId: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter userId: %s", err))
 	}
 
 	ctx.Set(BearerAuthScopes, []string{})
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.PatchPostsPostId(ctx, postId)
-	return err
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersUserIdPostsParams
+	// ------------- Optional query parameter "cursor" -------------
 
-// GetUsersMe converts echo context to params.
-func (w *ServerInterfaceWrapper) GetUsersMe(ctx echo.Context) error {
-	var err error
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
 
-	ctx.Set(BearerAuthScopes, []string{})
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetUsersMe(ctx)
+	err = w.Handler.GetUsersUserIdPosts(ctx, userId, params)
 	return err
 }
 
@@ -320,12 +2422,57 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	router.POST(baseURL+"/auth/login", wrapper.PostAuthLogin)
 	router.POST(baseURL+"/auth/refresh", wrapper.PostAuthRefresh)
 	router.POST(baseURL+"/auth/register", wrapper.PostAuthRegister)
+	router.GET(baseURL+"/feed", wrapper.GetFeed)
+	router.POST(baseURL+"/media", wrapper.PostMedia)
+	router.GET(baseURL+"/media/:mediaId", wrapper.GetMediaMediaId)
+	router.GET(baseURL+"/moderation/reports", wrapper.GetModerationReports)
+	router.POST(baseURL+"/moderation/reports/:reportId/claim", wrapper.PostModerationReportsReportIdClaim)
+	router.POST(baseURL+"/moderation/reports/:reportId/resolve", wrapper.PostModerationReportsReportIdResolve)
+	router.GET(baseURL+"/notifications", wrapper.GetNotifications)
+	router.POST(baseURL+"/notifications/read", wrapper.PostNotificationsRead)
+	router.GET(baseURL+"/notifications/unread-count", wrapper.GetNotificationsUnreadCount)
+	router.POST(baseURL+"/notifications/:notificationId/read", wrapper.PostNotificationsNotificationIdRead)
 	router.GET(baseURL+"/ping", wrapper.GetPing)
 	router.GET(baseURL+"/posts", wrapper.GetPosts)
 	router.POST(baseURL+"/posts", wrapper.PostPosts)
 	router.DELETE(baseURL+"/posts/:postId", wrapper.DeletePostsPostId)
 	router.GET(baseURL+"/posts/:postId", wrapper.GetPostsPostId)
 	router.PATCH(baseURL+"/posts/:postId", wrapper.PatchPostsPostId)
+	router.DELETE(baseURL+"/posts/:postId/bookmark", wrapper.DeletePostsPostIdBookmark)
+	router.PUT(baseURL+"/posts/:postId/bookmark", wrapper.PutPostsPostIdBookmark)
+	router.GET(baseURL+"/posts/:postId/comments", wrapper.GetPostsPostIdComments)
+	router.POST(baseURL+"/posts/:postId/comments", wrapper.PostPostsPostIdComments)
+	router.DELETE(baseURL+"/posts/:postId/comments/:commentId", wrapper.DeletePostsPostIdCommentsCommentId)
+	router.GET(baseURL+"/posts/:postId/comments/:commentId", wrapper.GetPostsPostIdCommentsCommentId)
+	router.PATCH(baseURL+"/posts/:postId/comments/:commentId", wrapper.PatchPostsPostIdCommentsCommentId)
+	router.DELETE(baseURL+"/posts/:postId/pin", wrapper.DeletePostsPostIdPin)
+	router.PUT(baseURL+"/posts/:postId/pin", wrapper.PutPostsPostIdPin)
+	router.POST(baseURL+"/posts/:postId/poll/votes", wrapper.PostPostsPostIdPollVotes)
+	router.GET(baseURL+"/posts/:postId/reactions", wrapper.GetPostsPostIdReactions)
+	router.DELETE(baseURL+"/posts/:postId/reactions/:emoji", wrapper.DeletePostsPostIdReactionsEmoji)
+	router.PUT(baseURL+"/posts/:postId/reactions/:emoji", wrapper.PutPostsPostIdReactionsEmoji)
+	router.POST(baseURL+"/posts/:postId/reports", wrapper.PostPostsPostIdReports)
+	router.DELETE(baseURL+"/posts/:postId/repost", wrapper.DeletePostsPostIdRepost)
+	router.PUT(baseURL+"/posts/:postId/repost", wrapper.PutPostsPostIdRepost)
+	router.POST(baseURL+"/posts/:postId/restore", wrapper.PostPostsPostIdRestore)
+	router.GET(baseURL+"/posts/:postId/revisions", wrapper.GetPostsPostIdRevisions)
+	router.GET(baseURL+"/posts/:postId/revisions/:revision", wrapper.GetPostsPostIdRevisionsRevision)
+	router.POST(baseURL+"/posts/:postId/revisions/:revision/restore", wrapper.PostPostsPostIdRevisionsRevisionRestore)
+	router.GET(baseURL+"/posts/:postId/stats", wrapper.GetPostsPostIdStats)
+	router.GET(baseURL+"/tags/popular", wrapper.GetTagsPopular)
+	router.GET(baseURL+"/tags/:tag/posts", wrapper.GetTagsTagPosts)
 	router.GET(baseURL+"/users/me", wrapper.GetUsersMe)
+	router.GET(baseURL+"/users/me/bookmarks", wrapper.GetUsersMeBookmarks)
+	router.GET(baseURL+"/users/me/trash", wrapper.GetUsersMeTrash)
+	router.GET(baseURL+"/users/:userId", wrapper.GetUsersUserId)
+	router.DELETE(baseURL+"/users/:userId/block", wrapper.DeleteUsersUserIdBlock)
+	router.PUT(baseURL+"/users/:userId/block", wrapper.PutUsersUserIdBlock)
+	router.DELETE(baseURL+"/users/:userId/follow", wrapper.DeleteUsersUserIdFollow)
+	router.PUT(baseURL+"/users/:userId/follow", wrapper.PutUsersUserIdFollow)
+	router.GET(baseURL+"/users/:userId/followers", wrapper.GetUsersUserIdFollowers)
+	router.GET(baseURL+"/users/:userId/following", wrapper.GetUsersUserIdFollowing)
+	router.DELETE(baseURL+"/users/:userId/mute", wrapper.DeleteUsersUserIdMute)
+	router.PUT(baseURL+"/users/:userId/mute", wrapper.PutUsersUserIdMute)
+	router.GET(baseURL+"/users/:userId/posts", wrapper.GetUsersUserIdPosts)
 
 }
@@ -1,8 +1,11 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"strconv"
+	"strings"
 )
 
 type AppConfig struct {
@@ -26,14 +29,67 @@ type JwtConfig struct {
 	SecretKey                string
 }
 
+type LinkPreviewsConfig struct {
+	// Pages are fetched with a timeout of FetchTimeoutSeconds and read up
+	// to MaxBytes, previews are fetched again after RefreshHours.
+	FetchTimeoutSeconds int
+	MaxBytes            int
+	RefreshHours        int
+	// Workers is the number of pages fetched concurrently.
+	Workers int
+}
+
+type MediaConfig struct {
+	// AllowedContentTypes are the content types uploads may have, sniffed
+	// from their first bytes.
+	AllowedContentTypes []string
+	MaxUploadBytes      int
+	// Uploads that are not attached to a post within OrphanRetentionHours
+	// are deleted.
+	OrphanRetentionHours int
+	// ProcessingWorkers is the number of images processed concurrently.
+	ProcessingWorkers int
+	// Storage selects where uploads are stored, "local" for LocalPath or
+	// "s3" for the S3 compatible bucket.
+	Storage     string
+	LocalPath   string
+	S3AccessKey string
+	S3Bucket    string
+	S3Endpoint  string
+	S3Region    string
+	S3SecretKey string
+	// Media are downloaded through URLs below URLBase signed with URLSecret,
+	// which stay valid for at least URLExpirationMinutes. URLSecret is
+	// required and must be kept private, anyone who knows it can sign URLs.
+	URLBase              string
+	URLExpirationMinutes int
+	URLSecret            string
+}
+
+type PostsConfig struct {
+	// ContentFilterRulesPath points to a JSON file with the rules of the
+	// content filter, posts are not filtered when it is empty.
+	ContentFilterRulesPath string
+	// MaxPinnedPosts is the number of posts a user can pin to their profile.
+	MaxPinnedPosts     int
+	TrashRetentionDays int
+	// Views are buffered in memory and written in batches of up to
+	// ViewsBatchSize views at least every ViewsFlushIntervalSeconds.
+	ViewsBatchSize            int
+	ViewsFlushIntervalSeconds int
+}
+
 type Config struct {
-	App *AppConfig
-	Db  *DbConfig
-	Jwt *JwtConfig
+	App          *AppConfig
+	Db           *DbConfig
+	Jwt          *JwtConfig
+	LinkPreviews *LinkPreviewsConfig
+	Media        *MediaConfig
+	Posts        *PostsConfig
 }
 
 func LoadConfig() (*Config, error) {
-	return &Config{
+	config := &Config{
 		App: &AppConfig{
 			Env:  os.Getenv("APP_ENV"),
 			Port: getIntEnv("PORT", 8080),
@@ -58,7 +114,102 @@ func LoadConfig() (*Config, error) {
 			),
 			SecretKey: os.Getenv("JWT_SECRET_KEY"),
 		},
-	}, nil
+		LinkPreviews: &LinkPreviewsConfig{
+			FetchTimeoutSeconds: getIntEnv(
+				"LINK_PREVIEWS_FETCH_TIMEOUT_SECONDS",
+				5,
+			),
+			MaxBytes:     getIntEnv("LINK_PREVIEWS_MAX_BYTES", 1<<20),
+			RefreshHours: getIntEnv("LINK_PREVIEWS_REFRESH_HOURS", 24),
+			Workers:      getIntEnv("LINK_PREVIEWS_WORKERS", 2),
+		},
+		Media: &MediaConfig{
+			AllowedContentTypes: getListEnv(
+				"MEDIA_ALLOWED_CONTENT_TYPES",
+				[]string{"image/gif", "image/jpeg", "image/png", "image/webp"},
+			),
+			MaxUploadBytes: getIntEnv("MEDIA_MAX_UPLOAD_BYTES", 10<<20),
+			OrphanRetentionHours: getIntEnv(
+				"MEDIA_ORPHAN_RETENTION_HOURS",
+				24,
+			),
+			ProcessingWorkers: getIntEnv("MEDIA_PROCESSING_WORKERS", 2),
+			Storage:           getEnv("MEDIA_STORAGE", "local"),
+			LocalPath:         getEnv("MEDIA_LOCAL_PATH", "data/media"),
+			S3AccessKey:       os.Getenv("MEDIA_S3_ACCESS_KEY"),
+			S3Bucket:          os.Getenv("MEDIA_S3_BUCKET"),
+			S3Endpoint:        os.Getenv("MEDIA_S3_ENDPOINT"),
+			S3Region:          getEnv("MEDIA_S3_REGION", "us-east-1"),
+			S3SecretKey:       os.Getenv("MEDIA_S3_SECRET_KEY"),
+			URLBase:           getEnv("MEDIA_URL_BASE", "/api/v1/media"),
+			URLExpirationMinutes: getIntEnv(
+				"MEDIA_URL_EXPIRATION_MINUTES",
+				60,
+			),
+			URLSecret: os.Getenv("MEDIA_URL_SECRET"),
+		},
+		Posts: &PostsConfig{
+			ContentFilterRulesPath: os.Getenv("POSTS_CONTENT_FILTER_RULES"),
+			MaxPinnedPosts:         getIntEnv("POSTS_MAX_PINNED", 3),
+			TrashRetentionDays:     getIntEnv("POSTS_TRASH_RETENTION_DAYS", 30),
+			ViewsBatchSize:         getIntEnv("POSTS_VIEWS_BATCH_SIZE", 1000),
+			ViewsFlushIntervalSeconds: getIntEnv(
+				"POSTS_VIEWS_FLUSH_INTERVAL_SECONDS",
+				10,
+			),
+		},
+	}
+
+	if err := config.Posts.validate(); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+func (c *PostsConfig) validate() error {
+	return errors.Join(
+		// Posts must stay in the trash for some time to be restorable.
+		requirePositive("POSTS_TRASH_RETENTION_DAYS", c.TrashRetentionDays),
+		requirePositive("POSTS_VIEWS_BATCH_SIZE", c.ViewsBatchSize),
+		requirePositive(
+			"POSTS_VIEWS_FLUSH_INTERVAL_SECONDS",
+			c.ViewsFlushIntervalSeconds,
+		),
+	)
+}
+
+func getEnv(key string, defaultValue string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+// getListEnv reads a comma separated list.
+func getListEnv(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// requirePositive fails unless the value of the environment variable key is
+// at least 1.
+func requirePositive(key string, value int) error {
+	if value < 1 {
+		return fmt.Errorf("%s must be at least 1, got %d", key, value)
+	}
+	return nil
 }
 
 func getIntEnv(key string, defaultValue int) int {
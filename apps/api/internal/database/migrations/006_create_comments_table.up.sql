@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS comments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    parent_id UUID REFERENCES comments(id) ON DELETE CASCADE,
+    author_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    content TEXT NOT NULL,
+    replies_count INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS comments_newest_idx
+    ON comments (post_id, parent_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS comments_top_idx
+    ON comments (post_id, parent_id, replies_count DESC, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS comments_parent_id_idx ON comments (parent_id);
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS comments_count INT NOT NULL DEFAULT 0;
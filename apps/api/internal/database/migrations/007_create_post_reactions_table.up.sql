@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS post_reactions (
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    emoji TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (post_id, user_id, emoji)
+);
+
+CREATE INDEX IF NOT EXISTS post_reactions_post_id_created_at_idx
+    ON post_reactions (post_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS post_reactions_user_id_idx
+    ON post_reactions (user_id, post_id);
+
+ALTER TABLE posts
+    ADD COLUMN IF NOT EXISTS reaction_counts JSONB NOT NULL DEFAULT '{}';
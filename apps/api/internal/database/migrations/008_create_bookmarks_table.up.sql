@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS bookmarks (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, post_id)
+);
+
+CREATE INDEX IF NOT EXISTS bookmarks_user_id_created_at_idx
+    ON bookmarks (user_id, created_at DESC, post_id DESC);
+CREATE INDEX IF NOT EXISTS bookmarks_post_id_idx ON bookmarks (post_id);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS posts_author_id_created_at_idx;
@@ -0,0 +1,2 @@
+-- Reports without a reporter are raised by the content filter.
+ALTER TABLE reports ALTER COLUMN reporter_id DROP NOT NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE posts
+    ADD COLUMN IF NOT EXISTS visibility TEXT NOT NULL DEFAULT 'public'
+        CHECK (visibility IN ('public', 'followers', 'private', 'unlisted'));
@@ -0,0 +1,8 @@
+-- A viewer is counted once per post and day.
+CREATE TABLE IF NOT EXISTS post_views (
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    viewer_key TEXT NOT NULL,
+    day DATE NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (post_id, day, viewer_key)
+);
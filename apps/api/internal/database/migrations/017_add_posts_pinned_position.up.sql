@@ -0,0 +1,5 @@
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS pinned_position INTEGER;
+
+CREATE INDEX IF NOT EXISTS posts_author_id_pinned_position_idx
+    ON posts (author_id, pinned_position)
+    WHERE pinned_position IS NOT NULL;
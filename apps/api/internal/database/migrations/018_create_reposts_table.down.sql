@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS posts_quoted_post_id_idx;
+
+ALTER TABLE posts
+    DROP COLUMN IF EXISTS quoted_post_id,
+    DROP COLUMN IF EXISTS reposts_count;
+
+DROP TABLE IF EXISTS reposts;
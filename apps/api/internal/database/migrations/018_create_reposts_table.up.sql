@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS reposts (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, post_id)
+);
+
+CREATE INDEX IF NOT EXISTS reposts_user_id_created_at_idx
+    ON reposts (user_id, created_at DESC, post_id DESC);
+CREATE INDEX IF NOT EXISTS reposts_post_id_created_at_idx
+    ON reposts (post_id, created_at DESC);
+
+ALTER TABLE posts
+    ADD COLUMN IF NOT EXISTS reposts_count INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS quoted_post_id UUID
+        REFERENCES posts(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS posts_quoted_post_id_idx
+    ON posts (quoted_post_id)
+    WHERE quoted_post_id IS NOT NULL;
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS polls (
+    post_id UUID PRIMARY KEY REFERENCES posts(id) ON DELETE CASCADE,
+    multiple_choice BOOLEAN NOT NULL DEFAULT FALSE,
+    closes_at TIMESTAMPTZ NOT NULL,
+    voters_count INT NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS poll_options (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    post_id UUID NOT NULL REFERENCES polls(post_id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    text TEXT NOT NULL,
+    votes_count INT NOT NULL DEFAULT 0,
+    UNIQUE (post_id, position)
+);
+
+CREATE TABLE IF NOT EXISTS poll_votes (
+    post_id UUID NOT NULL REFERENCES polls(post_id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    option_id UUID NOT NULL REFERENCES poll_options(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (post_id, user_id, option_id)
+);
+
+CREATE INDEX IF NOT EXISTS poll_votes_option_id_idx ON poll_votes (option_id);
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS media (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    post_id UUID REFERENCES posts(id) ON DELETE SET NULL,
+    position INT,
+    content_type TEXT NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    storage_key TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS media_post_id_position_idx
+    ON media (post_id, position)
+    WHERE post_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS media_orphaned_created_at_idx
+    ON media (created_at)
+    WHERE post_id IS NULL;
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS media_variants;
+
+ALTER TABLE media
+    DROP COLUMN IF EXISTS blurhash,
+    DROP COLUMN IF EXISTS height,
+    DROP COLUMN IF EXISTS width,
+    DROP COLUMN IF EXISTS processing_started_at,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,7 @@
+ALTER TABLE post_revisions
+    DROP COLUMN IF EXISTS content_html,
+    DROP COLUMN IF EXISTS content_format;
+
+ALTER TABLE posts
+    DROP COLUMN IF EXISTS content_html,
+    DROP COLUMN IF EXISTS content_format;
@@ -0,0 +1,27 @@
+ALTER TABLE posts
+    ADD COLUMN IF NOT EXISTS content_format TEXT NOT NULL DEFAULT 'plain'
+        CHECK (content_format IN ('plain', 'markdown')),
+    ADD COLUMN IF NOT EXISTS content_html TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE post_revisions
+    ADD COLUMN IF NOT EXISTS content_format TEXT NOT NULL DEFAULT 'plain'
+        CHECK (content_format IN ('plain', 'markdown')),
+    ADD COLUMN IF NOT EXISTS content_html TEXT NOT NULL DEFAULT '';
+
+-- Existing content is plain text, rendered the way the API renders it:
+-- escaped, with line breaks kept, in a single paragraph.
+UPDATE posts SET content_html = '<p>' || replace(
+    replace(replace(replace(replace(replace(
+        content,
+        '&', '&amp;'), '''', '&#39;'), '<', '&lt;'), '>', '&gt;'),
+        '"', '&#34;'),
+    E'\n', E'<br>\n'
+) || '</p>';
+
+UPDATE post_revisions SET content_html = '<p>' || replace(
+    replace(replace(replace(replace(replace(
+        content,
+        '&', '&amp;'), '''', '&#39;'), '<', '&lt;'), '>', '&gt;'),
+        '"', '&#34;'),
+    E'\n', E'<br>\n'
+) || '</p>';
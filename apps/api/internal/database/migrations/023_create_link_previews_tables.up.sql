@@ -0,0 +1,27 @@
+-- link_previews caches the metadata fetched from the pages posts link to,
+-- shared by all posts linking to the same URL.
+CREATE TABLE IF NOT EXISTS link_previews (
+    url TEXT PRIMARY KEY,
+    status TEXT NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'ready', 'failed')),
+    title TEXT,
+    description TEXT,
+    image_url TEXT,
+    site_name TEXT,
+    fetch_started_at TIMESTAMPTZ,
+    fetched_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS link_previews_unfetched_created_at_idx
+    ON link_previews (created_at)
+    WHERE fetched_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS post_links (
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    url TEXT NOT NULL REFERENCES link_previews(url) ON DELETE CASCADE,
+    PRIMARY KEY (post_id, position)
+);
+
+CREATE INDEX IF NOT EXISTS post_links_url_idx ON post_links (url);
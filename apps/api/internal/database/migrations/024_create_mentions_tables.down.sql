@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS notifications;
+
+DROP TABLE IF EXISTS mentions;
+
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS users_handle_key,
+    DROP CONSTRAINT IF EXISTS users_handle_format,
+    DROP COLUMN IF EXISTS handle;
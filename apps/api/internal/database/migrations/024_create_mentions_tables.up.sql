@@ -0,0 +1,85 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS handle TEXT;
+
+-- Existing users get a handle derived from their email address, with part
+-- of their id appended when another user derives the same handle.
+WITH derived AS (
+    SELECT
+        id,
+        created_at,
+        LEFT(
+            COALESCE(
+                NULLIF(
+                    BTRIM(
+                        REGEXP_REPLACE(
+                            LOWER(SPLIT_PART(email, '@', 1)),
+                            '[^a-z0-9_]',
+                            '_',
+                            'g'
+                        ),
+                        '_'
+                    ),
+                    ''
+                ),
+                'user'
+            ),
+            30
+        ) AS handle
+    FROM users
+    WHERE handle IS NULL
+), numbered AS (
+    SELECT
+        id,
+        handle,
+        ROW_NUMBER() OVER (PARTITION BY handle ORDER BY created_at, id) AS n
+    FROM derived
+)
+UPDATE users
+SET handle = CASE
+    WHEN numbered.n = 1 THEN numbered.handle
+    ELSE LEFT(numbered.handle, 23) || '_'
+        || LEFT(REPLACE(users.id::text, '-', ''), 6)
+END
+FROM numbered
+WHERE users.id = numbered.id;
+
+ALTER TABLE users
+    ALTER COLUMN handle SET NOT NULL,
+    ADD CONSTRAINT users_handle_format CHECK (handle ~ '^[a-z0-9_]{1,30}$'),
+    ADD CONSTRAINT users_handle_key UNIQUE (handle);
+
+-- mentions are the @handle mentions of users in posts and comments, post_id
+-- is the commented post for mentions in comments. Offsets and lengths count
+-- UTF-16 code units of the content.
+CREATE TABLE IF NOT EXISTS mentions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    comment_id UUID REFERENCES comments(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    start_offset INTEGER NOT NULL,
+    length INTEGER NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS mentions_post_id_idx
+    ON mentions (post_id, start_offset)
+    WHERE comment_id IS NULL;
+
+CREATE INDEX IF NOT EXISTS mentions_comment_id_idx
+    ON mentions (comment_id, start_offset)
+    WHERE comment_id IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS mentions_user_id_idx ON mentions (user_id);
+
+CREATE TABLE IF NOT EXISTS notifications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type TEXT NOT NULL CHECK (type IN ('mention')),
+    actor_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    post_id UUID REFERENCES posts(id) ON DELETE CASCADE,
+    comment_id UUID REFERENCES comments(id) ON DELETE CASCADE,
+    read_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS notifications_user_id_created_at_idx
+    ON notifications (user_id, created_at DESC, id DESC);
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS notifications_user_id_unread_idx;
+
+DROP INDEX IF EXISTS notifications_user_id_group_idx;
+
+DELETE FROM notifications WHERE type <> 'mention';
+
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS payload,
+    DROP COLUMN IF EXISTS group_key,
+    DROP CONSTRAINT IF EXISTS notifications_type_check,
+    ADD CONSTRAINT notifications_type_check CHECK (type IN ('mention'));
@@ -0,0 +1,21 @@
+-- Notifications of the same type with the same group_key, e.g. the post a
+-- reaction is on, are shown as one group. payload holds the details that
+-- depend on the type, e.g. the emoji of a reaction.
+ALTER TABLE notifications
+    DROP CONSTRAINT IF EXISTS notifications_type_check,
+    ADD CONSTRAINT notifications_type_check CHECK (
+        type IN ('follow', 'reaction', 'comment', 'mention', 'moderation')
+    ),
+    ADD COLUMN IF NOT EXISTS group_key TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS payload JSONB NOT NULL DEFAULT '{}';
+
+UPDATE notifications
+SET group_key = COALESCE(comment_id, post_id)::text
+WHERE type = 'mention';
+
+CREATE INDEX IF NOT EXISTS notifications_user_id_group_idx
+    ON notifications (user_id, type, group_key, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS notifications_user_id_unread_idx
+    ON notifications (user_id)
+    WHERE read_at IS NULL;
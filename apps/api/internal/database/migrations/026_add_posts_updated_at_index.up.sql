@@ -0,0 +1,3 @@
+-- The Last-Modified validator of post lists is the latest updated_at of all
+-- posts.
+CREATE INDEX IF NOT EXISTS posts_updated_at_idx ON posts (updated_at);
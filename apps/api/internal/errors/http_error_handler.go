@@ -18,10 +18,14 @@ func HTTPErrorHandler(err error, c echo.Context) {
 
 	if errors.As(err, &valErr) {
 		fmt.Println("Validation error:", valErr)
+		fieldErrors := &valErr.Fields
+		if valErr.Issues != nil {
+			fieldErrors = validationDetails(*valErr.Issues)
+		}
 		c.JSON(
 			http.StatusBadRequest,
 			api.GeneralError{
-				FieldErrors: validationDetails(*valErr.Issues),
+				FieldErrors: fieldErrors,
 				Message:     valErr.Error(),
 			},
 		)
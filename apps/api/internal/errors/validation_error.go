@@ -5,8 +5,11 @@ import (
 )
 
 type ValidationError struct {
-	Issues  *z.ZogIssueMap `json:"-"`
-	Message string         `json:"message"`
+	// Fields holds one message per field when the error does not come from
+	// a schema.
+	Fields  map[string]string `json:"-"`
+	Issues  *z.ZogIssueMap    `json:"-"`
+	Message string            `json:"message"`
 }
 
 func (e *ValidationError) Error() string {
@@ -19,3 +22,10 @@ func NewValidationError(issues *z.ZogIssueMap) *ValidationError {
 		Message: "Validation failed",
 	}
 }
+
+func NewFieldsValidationError(fields map[string]string) *ValidationError {
+	return &ValidationError{
+		Fields:  fields,
+		Message: "Validation failed",
+	}
+}
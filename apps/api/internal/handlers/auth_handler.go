@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	stderrors "errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -69,6 +70,9 @@ func (h *AuthHandler) PostAuthLogin(c echo.Context) error {
 			"Invalid email or password",
 		)
 	}
+	if user.SuspendedAt != nil {
+		return echo.NewHTTPError(http.StatusForbidden, "Account suspended")
+	}
 
 	authToken, err := h.jwtService.GenerateAuthToken(user.ID)
 	if err != nil {
@@ -83,6 +87,16 @@ func (h *AuthHandler) PostAuthLogin(c echo.Context) error {
 
 var registerRequestSchema = z.Struct(z.Schema{
 	"email": utils.EmailSchema,
+	"handle": z.Ptr(
+		z.String().
+			Match(
+				utils.HandleRegexp,
+				z.Message(
+					"Should be 1 to 30 letters, digits or underscores",
+				),
+			).
+			Optional(),
+	),
 	"password": z.String().
 		Min(6, z.Message("Must be at least 6 characters")).
 		Required(z.Message("Password is required")),
@@ -94,6 +108,14 @@ func (h *AuthHandler) PostAuthRegister(c echo.Context) error {
 		return err
 	}
 
+	var handle string
+	if req.Handle != nil {
+		handle = strings.ToLower(strings.TrimPrefix(
+			strings.TrimSpace(*req.Handle),
+			"@",
+		))
+		req.Handle = &handle
+	}
 	if errs := registerRequestSchema.Validate(&req); errs != nil {
 		return errors.NewValidationError(&errs)
 	}
@@ -117,6 +139,7 @@ func (h *AuthHandler) PostAuthRegister(c echo.Context) error {
 		c.Request().Context(),
 		models.UserCreate{
 			Email:        email,
+			Handle:       handle,
 			PasswordHash: string(hashedPassword),
 		},
 	)
@@ -124,6 +147,9 @@ func (h *AuthHandler) PostAuthRegister(c echo.Context) error {
 	fmt.Println("User created:", user)
 	fmt.Println("User created err:", err)
 
+	if stderrors.Is(err, repositories.ErrHandleTaken) {
+		return echo.NewHTTPError(http.StatusConflict, "Handle already taken")
+	}
 	if err != nil || user == nil {
 		return echo.NewHTTPError(
 			http.StatusInternalServerError,
@@ -179,6 +205,9 @@ func (h *AuthHandler) PostAuthRefresh(c echo.Context) error {
 	if user == nil {
 		return echo.NewHTTPError(http.StatusUnauthorized, "User not found")
 	}
+	if user.SuspendedAt != nil {
+		return echo.NewHTTPError(http.StatusForbidden, "Account suspended")
+	}
 
 	newAccessToken, err := h.jwtService.GenerateAuthToken(user.ID)
 	if err != nil {
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"apps/api/internal/repositories"
+)
+
+type BlockHandler struct {
+	blockRepo *repositories.BlockRepo
+	userRepo  *repositories.UserRepo
+}
+
+func NewBlockHandler(
+	blockRepo *repositories.BlockRepo,
+	userRepo *repositories.UserRepo,
+) *BlockHandler {
+	return &BlockHandler{
+		blockRepo,
+		userRepo,
+	}
+}
+
+func (h *BlockHandler) DeleteUsersUserIdBlock(
+	c echo.Context,
+	userId string,
+) error {
+	blockerId := c.Get("userId").(string)
+
+	if _, err := h.userRepo.GetUserById(
+		c.Request().Context(),
+		userId,
+	); err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "User not found")
+	}
+
+	if err := h.blockRepo.Unblock(
+		c.Request().Context(),
+		blockerId,
+		userId,
+	); err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to unblock user",
+		)
+	}
+
+	return c.JSON(http.StatusNoContent, nil)
+}
+
+func (h *BlockHandler) PutUsersUserIdBlock(
+	c echo.Context,
+	userId string,
+) error {
+	blockerId := c.Get("userId").(string)
+	if blockerId == userId {
+		return echo.NewHTTPError(
+			http.StatusBadRequest,
+			"You cannot block yourself",
+		)
+	}
+
+	if _, err := h.userRepo.GetUserById(
+		c.Request().Context(),
+		userId,
+	); err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "User not found")
+	}
+
+	if err := h.blockRepo.Block(
+		c.Request().Context(),
+		blockerId,
+		userId,
+	); err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to block user",
+		)
+	}
+
+	return c.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"apps/api/internal/api"
+	"apps/api/internal/errors"
+	"apps/api/internal/models"
+	"apps/api/internal/repositories"
+	"apps/api/internal/schemas"
+	"apps/api/internal/services"
+	"apps/api/internal/utils"
+)
+
+type BookmarkHandler struct {
+	bookmarkRepo   *repositories.BookmarkRepo
+	mediaURLSigner *services.MediaURLSigner
+	postRepo       *repositories.PostRepo
+}
+
+func NewBookmarkHandler(
+	bookmarkRepo *repositories.BookmarkRepo,
+	mediaURLSigner *services.MediaURLSigner,
+	postRepo *repositories.PostRepo,
+) *BookmarkHandler {
+	return &BookmarkHandler{
+		bookmarkRepo,
+		mediaURLSigner,
+		postRepo,
+	}
+}
+
+func (h *BookmarkHandler) DeletePostsPostIdBookmark(
+	c echo.Context,
+	postId string,
+) error {
+	userId := c.Get("userId").(string)
+
+	post, err := h.postRepo.GetPostById(
+		c.Request().Context(),
+		userId,
+		postId,
+	)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Post not found")
+	}
+
+	if err := h.bookmarkRepo.RemoveBookmark(
+		c.Request().Context(),
+		userId,
+		postId,
+	); err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to remove bookmark",
+		)
+	}
+
+	post.BookmarkedAt = nil
+	return c.JSON(http.StatusOK, mapModelPostToApi(post, h.mediaURLSigner))
+}
+
+func (h *BookmarkHandler) GetUsersMeBookmarks(
+	c echo.Context,
+	params api.GetUsersMeBookmarksParams,
+) error {
+	if errs := schemas.GetUsersMeBookmarksParamsSchema.Validate(
+		&params,
+	); errs != nil {
+		return errors.NewValidationError(&errs)
+	}
+
+	limit := 20
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	var cursor *models.BookmarkCursor
+	if params.Cursor != nil {
+		cursor = &models.BookmarkCursor{}
+		if err := utils.DecodeCursor(*params.Cursor, cursor); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid cursor")
+		}
+	}
+
+	posts, err := h.postRepo.GetBookmarkedPosts(
+		c.Request().Context(),
+		c.Get("userId").(string),
+		cursor,
+		limit,
+	)
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to retrieve bookmarks",
+		)
+	}
+	if posts == nil {
+		posts = []*models.Post{}
+	}
+
+	page := api.CursorPaginatedPosts{
+		Items: mapModelPostsToApi(posts, h.mediaURLSigner),
+	}
+	if len(posts) == limit {
+		last := posts[len(posts)-1]
+		nextCursor, err := utils.EncodeCursor(models.BookmarkCursor{
+			CreatedAt: *last.BookmarkedAt,
+			PostId:    last.ID,
+		})
+		if err != nil {
+			return echo.NewHTTPError(
+				http.StatusInternalServerError,
+				"Failed to encode cursor",
+			)
+		}
+		page.NextCursor = &nextCursor
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
+
+func (h *BookmarkHandler) PutPostsPostIdBookmark(
+	c echo.Context,
+	postId string,
+) error {
+	userId := c.Get("userId").(string)
+
+	if _, err := h.postRepo.GetPostById(
+		c.Request().Context(),
+		userId,
+		postId,
+	); err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Post not found")
+	}
+
+	if err := h.bookmarkRepo.AddBookmark(
+		c.Request().Context(),
+		userId,
+		postId,
+	); err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to add bookmark",
+		)
+	}
+
+	post, err := h.postRepo.GetPostById(
+		c.Request().Context(),
+		userId,
+		postId,
+	)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Post not found")
+	}
+
+	return c.JSON(http.StatusOK, mapModelPostToApi(post, h.mediaURLSigner))
+}
@@ -0,0 +1,296 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"apps/api/internal/api"
+	"apps/api/internal/errors"
+	"apps/api/internal/models"
+	"apps/api/internal/repositories"
+	"apps/api/internal/schemas"
+	"apps/api/internal/utils"
+)
+
+type CommentHandler struct {
+	commentRepo *repositories.CommentRepo
+	postRepo    *repositories.PostRepo
+}
+
+func NewCommentHandler(
+	commentRepo *repositories.CommentRepo,
+	postRepo *repositories.PostRepo,
+) *CommentHandler {
+	return &CommentHandler{
+		commentRepo,
+		postRepo,
+	}
+}
+
+func (h *CommentHandler) DeletePostsPostIdCommentsCommentId(
+	c echo.Context,
+	postId string,
+	commentId string,
+) error {
+	comment, err := h.getOwnComment(c, postId, commentId)
+	if err != nil {
+		return err
+	}
+
+	if err := h.commentRepo.DeleteComment(
+		c.Request().Context(),
+		comment,
+	); err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to delete comment",
+		)
+	}
+
+	return c.JSON(http.StatusNoContent, nil)
+}
+
+func (h *CommentHandler) GetPostsPostIdComments(
+	c echo.Context,
+	postId string,
+	params api.GetPostsPostIdCommentsParams,
+) error {
+	if errs := schemas.GetPostsPostIdCommentsParamsSchema.Validate(
+		&params,
+	); errs != nil {
+		return errors.NewValidationError(&errs)
+	}
+
+	if _, err := h.postRepo.GetPostById(
+		c.Request().Context(),
+		c.Get("userId").(string),
+		postId,
+	); err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Post not found")
+	}
+
+	listParams := models.CommentListParams{
+		Limit:    20,
+		ParentId: params.ParentId,
+		PostId:   postId,
+		Sort:     models.CommentSortNewest,
+		ViewerId: c.Get("userId").(string),
+	}
+	if params.Limit != nil {
+		listParams.Limit = *params.Limit
+	}
+	if params.Sort != nil {
+		listParams.Sort = models.CommentSort(*params.Sort)
+	}
+	if params.Cursor != nil {
+		var cursor models.CommentCursor
+		if err := utils.DecodeCursor(*params.Cursor, &cursor); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid cursor")
+		}
+		listParams.Cursor = &cursor
+	}
+
+	comments, err := h.commentRepo.GetComments(
+		c.Request().Context(),
+		listParams,
+	)
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to retrieve comments",
+		)
+	}
+	if comments == nil {
+		comments = []*models.Comment{}
+	}
+
+	page := api.PaginatedComments{
+		Items: utils.MapSlice(comments, mapModelCommentToApi),
+	}
+	if len(comments) == listParams.Limit {
+		last := comments[len(comments)-1]
+		nextCursor, err := utils.EncodeCursor(models.CommentCursor{
+			CreatedAt:    last.CreatedAt,
+			Id:           last.ID,
+			RepliesCount: last.RepliesCount,
+		})
+		if err != nil {
+			return echo.NewHTTPError(
+				http.StatusInternalServerError,
+				"Failed to encode cursor",
+			)
+		}
+		page.NextCursor = &nextCursor
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
+
+func (h *CommentHandler) GetPostsPostIdCommentsCommentId(
+	c echo.Context,
+	postId string,
+	commentId string,
+) error {
+	if _, err := h.postRepo.GetPostById(
+		c.Request().Context(),
+		c.Get("userId").(string),
+		postId,
+	); err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Post not found")
+	}
+
+	comment, err := h.commentRepo.GetCommentById(
+		c.Request().Context(),
+		c.Get("userId").(string),
+		postId,
+		commentId,
+	)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Comment not found")
+	}
+
+	return c.JSON(http.StatusOK, mapModelCommentToApi(comment))
+}
+
+func (h *CommentHandler) PatchPostsPostIdCommentsCommentId(
+	c echo.Context,
+	postId string,
+	commentId string,
+) error {
+	var req api.UpdateCommentRequest
+	if err := utils.BindRequest(c, &req); err != nil {
+		return err
+	}
+
+	if errs := schemas.UpdateCommentRequestSchema.Validate(&req); errs != nil {
+		return errors.NewValidationError(&errs)
+	}
+
+	if _, err := h.getOwnComment(c, postId, commentId); err != nil {
+		return err
+	}
+
+	comment, err := h.commentRepo.UpdateComment(
+		c.Request().Context(),
+		commentId,
+		req.Content,
+	)
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to update comment",
+		)
+	}
+
+	return c.JSON(http.StatusOK, mapModelCommentToApi(comment))
+}
+
+func (h *CommentHandler) PostPostsPostIdComments(
+	c echo.Context,
+	postId string,
+) error {
+	var req api.CreateCommentRequest
+	if err := utils.BindRequest(c, &req); err != nil {
+		return err
+	}
+
+	if errs := schemas.CreateCommentRequestSchema.Validate(&req); errs != nil {
+		return errors.NewValidationError(&errs)
+	}
+
+	if _, err := h.postRepo.GetPostById(
+		c.Request().Context(),
+		c.Get("userId").(string),
+		postId,
+	); err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Post not found")
+	}
+
+	if req.ParentId != nil {
+		// Users can not reply to comments of users they blocked or were
+		// blocked by.
+		if _, err := h.commentRepo.GetCommentById(
+			c.Request().Context(),
+			c.Get("userId").(string),
+			postId,
+			*req.ParentId,
+		); err != nil {
+			return echo.NewHTTPError(
+				http.StatusNotFound,
+				"Parent comment not found",
+			)
+		}
+	}
+
+	comment, err := h.commentRepo.CreateComment(
+		c.Request().Context(),
+		models.CommentCreate{
+			AuthorId: c.Get("userId").(string),
+			Content:  req.Content,
+			ParentId: req.ParentId,
+			PostId:   postId,
+		},
+	)
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to create comment",
+		)
+	}
+
+	return c.JSON(http.StatusCreated, mapModelCommentToApi(comment))
+}
+
+// getOwnComment loads the comment of a visible post and makes sure it was
+// written by the current user.
+func (h *CommentHandler) getOwnComment(
+	c echo.Context,
+	postId string,
+	commentId string,
+) (*models.Comment, error) {
+	if _, err := h.postRepo.GetPostById(
+		c.Request().Context(),
+		c.Get("userId").(string),
+		postId,
+	); err != nil {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Post not found")
+	}
+
+	comment, err := h.commentRepo.GetCommentById(
+		c.Request().Context(),
+		c.Get("userId").(string),
+		postId,
+		commentId,
+	)
+	if err != nil {
+		return nil, echo.NewHTTPError(
+			http.StatusNotFound,
+			"Comment not found",
+		)
+	}
+	userId := c.Get("userId").(string)
+	if comment.AuthorId == nil || *comment.AuthorId != userId {
+		return nil, echo.NewHTTPError(
+			http.StatusForbidden,
+			"You do not have permission to change this comment",
+		)
+	}
+
+	return comment, nil
+}
+
+func mapModelCommentToApi(comment *models.Comment) api.Comment {
+	mentions := utils.MapSlice(comment.Mentions, mapModelMentionToApi)
+	return api.Comment{
+		AuthorId:     comment.AuthorId,
+		Content:      comment.Content,
+		CreatedAt:    comment.CreatedAt,
+		Id:           comment.ID,
+		Mentions:     mentions,
+		ParentId:     comment.ParentId,
+		PostId:       comment.PostId,
+		RepliesCount: comment.RepliesCount,
+		UpdatedAt:    comment.UpdatedAt,
+	}
+}
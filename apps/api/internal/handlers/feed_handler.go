@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"apps/api/internal/api"
+	"apps/api/internal/errors"
+	"apps/api/internal/models"
+	"apps/api/internal/schemas"
+	"apps/api/internal/services"
+	"apps/api/internal/utils"
+)
+
+type FeedHandler struct {
+	feedService    *services.FeedService
+	mediaURLSigner *services.MediaURLSigner
+}
+
+func NewFeedHandler(
+	feedService *services.FeedService,
+	mediaURLSigner *services.MediaURLSigner,
+) *FeedHandler {
+	return &FeedHandler{
+		feedService:    feedService,
+		mediaURLSigner: mediaURLSigner,
+	}
+}
+
+func (h *FeedHandler) GetFeed(
+	c echo.Context,
+	params api.GetFeedParams,
+) error {
+	if errs := schemas.GetFeedParamsSchema.Validate(&params); errs != nil {
+		return errors.NewValidationError(&errs)
+	}
+
+	limit := 20
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	var cursor *models.PostCursor
+	if params.Cursor != nil {
+		cursor = &models.PostCursor{}
+		if err := utils.DecodeCursor(*params.Cursor, cursor); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid cursor")
+		}
+	}
+
+	posts, err := h.feedService.GetFeed(
+		c.Request().Context(),
+		c.Get("userId").(string),
+		cursor,
+		limit,
+	)
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to retrieve feed",
+		)
+	}
+
+	page, err := postsPage(posts, limit, h.mediaURLSigner)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
@@ -0,0 +1,258 @@
+package handlers
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"apps/api/internal/api"
+	"apps/api/internal/errors"
+	"apps/api/internal/models"
+	"apps/api/internal/repositories"
+	"apps/api/internal/schemas"
+	"apps/api/internal/utils"
+)
+
+type FollowHandler struct {
+	followRepo *repositories.FollowRepo
+	userRepo   *repositories.UserRepo
+}
+
+func NewFollowHandler(
+	followRepo *repositories.FollowRepo,
+	userRepo *repositories.UserRepo,
+) *FollowHandler {
+	return &FollowHandler{
+		followRepo,
+		userRepo,
+	}
+}
+
+func (h *FollowHandler) DeleteUsersUserIdFollow(
+	c echo.Context,
+	userId string,
+) error {
+	followerId := c.Get("userId").(string)
+
+	if _, err := h.userRepo.GetUserById(
+		c.Request().Context(),
+		userId,
+	); err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "User not found")
+	}
+
+	if err := h.followRepo.Unfollow(
+		c.Request().Context(),
+		followerId,
+		userId,
+	); err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to unfollow user",
+		)
+	}
+
+	return h.followedUser(c, userId, false)
+}
+
+func (h *FollowHandler) GetUsersUserIdFollowers(
+	c echo.Context,
+	userId string,
+	params api.GetUsersUserIdFollowersParams,
+) error {
+	if errs := schemas.GetUsersUserIdFollowersParamsSchema.Validate(
+		&params,
+	); errs != nil {
+		return errors.NewValidationError(&errs)
+	}
+
+	return h.follows(
+		c,
+		userId,
+		params.Cursor,
+		params.Limit,
+		h.followRepo.GetFollowers,
+		func(follow *models.Follow) string { return follow.FollowerId },
+	)
+}
+
+func (h *FollowHandler) GetUsersUserIdFollowing(
+	c echo.Context,
+	userId string,
+	params api.GetUsersUserIdFollowingParams,
+) error {
+	if errs := schemas.GetUsersUserIdFollowingParamsSchema.Validate(
+		&params,
+	); errs != nil {
+		return errors.NewValidationError(&errs)
+	}
+
+	return h.follows(
+		c,
+		userId,
+		params.Cursor,
+		params.Limit,
+		h.followRepo.GetFollowing,
+		func(follow *models.Follow) string { return follow.FolloweeId },
+	)
+}
+
+func (h *FollowHandler) PutUsersUserIdFollow(
+	c echo.Context,
+	userId string,
+) error {
+	followerId := c.Get("userId").(string)
+	if followerId == userId {
+		return echo.NewHTTPError(
+			http.StatusBadRequest,
+			"You cannot follow yourself",
+		)
+	}
+
+	if _, err := h.userRepo.GetUserById(
+		c.Request().Context(),
+		userId,
+	); err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "User not found")
+	}
+
+	err := h.followRepo.Follow(c.Request().Context(), followerId, userId)
+	if stderrors.Is(err, repositories.ErrUserBlocked) {
+		return echo.NewHTTPError(
+			http.StatusForbidden,
+			"You cannot follow this user",
+		)
+	}
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to follow user",
+		)
+	}
+
+	return h.followedUser(c, userId, true)
+}
+
+// followedUser responds with the profile of the user after the current user
+// followed or unfollowed them.
+func (h *FollowHandler) followedUser(
+	c echo.Context,
+	userId string,
+	isFollowing bool,
+) error {
+	user, err := h.userRepo.GetUserById(c.Request().Context(), userId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "User not found")
+	}
+
+	return c.JSON(http.StatusOK, mapModelUserToProfileApi(user, isFollowing))
+}
+
+// follows responds with a page of the users on the other side of the follows
+// of userId returned by getFollows.
+func (h *FollowHandler) follows(
+	c echo.Context,
+	userId string,
+	cursorParam *string,
+	limitParam *int,
+	getFollows func(
+		ctx context.Context,
+		viewerId string,
+		userId string,
+		cursor *models.FollowCursor,
+		limit int,
+	) ([]*models.Follow, error),
+	otherUserId func(follow *models.Follow) string,
+) error {
+	ctx := c.Request().Context()
+
+	if _, err := h.userRepo.GetUserById(ctx, userId); err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "User not found")
+	}
+
+	limit := 20
+	if limitParam != nil {
+		limit = *limitParam
+	}
+	var cursor *models.FollowCursor
+	if cursorParam != nil {
+		cursor = &models.FollowCursor{}
+		if err := utils.DecodeCursor(*cursorParam, cursor); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid cursor")
+		}
+	}
+
+	viewerId := c.Get("userId").(string)
+	follows, err := getFollows(ctx, viewerId, userId, cursor, limit)
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to retrieve follows",
+		)
+	}
+
+	userIds := utils.MapSlice(follows, otherUserId)
+	users, err := h.userRepo.GetUsersByIds(ctx, userIds)
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to retrieve users",
+		)
+	}
+	followed, err := h.followRepo.GetFollowedIds(ctx, viewerId, userIds)
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to retrieve follows",
+		)
+	}
+
+	usersById := make(map[string]*models.User, len(users))
+	for _, user := range users {
+		usersById[user.ID] = user
+	}
+	page := api.PaginatedUserProfiles{Items: []api.UserProfile{}}
+	for _, id := range userIds {
+		if user, ok := usersById[id]; ok {
+			page.Items = append(
+				page.Items,
+				mapModelUserToProfileApi(user, followed[id]),
+			)
+		}
+	}
+	if len(follows) == limit {
+		last := follows[len(follows)-1]
+		nextCursor, err := utils.EncodeCursor(models.FollowCursor{
+			CreatedAt: last.CreatedAt,
+			UserId:    otherUserId(last),
+		})
+		if err != nil {
+			return echo.NewHTTPError(
+				http.StatusInternalServerError,
+				"Failed to encode cursor",
+			)
+		}
+		page.NextCursor = &nextCursor
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
+
+func mapModelUserToProfileApi(
+	user *models.User,
+	isFollowing bool,
+) api.UserProfile {
+	if user == nil {
+		return api.UserProfile{}
+	}
+	return api.UserProfile{
+		CreatedAt:      user.CreatedAt,
+		FollowersCount: user.FollowersCount,
+		FollowingCount: user.FollowingCount,
+		Handle:         user.Handle,
+		Id:             user.ID,
+		IsFollowing:    isFollowing,
+	}
+}
@@ -0,0 +1,245 @@
+package handlers
+
+import (
+	stderrors "errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"apps/api/internal/api"
+	"apps/api/internal/config"
+	"apps/api/internal/errors"
+	"apps/api/internal/models"
+	"apps/api/internal/services"
+	"apps/api/internal/utils"
+)
+
+// mediaFormOverheadBytes is how much larger than the file an upload request
+// may be, for the multipart boundaries and headers.
+const mediaFormOverheadBytes = 64 << 10
+
+var errMediaTooLarge = stderrors.New("Media too large")
+
+type MediaHandler struct {
+	config         *config.MediaConfig
+	mediaService   *services.MediaService
+	mediaURLSigner *services.MediaURLSigner
+}
+
+func NewMediaHandler(
+	config *config.MediaConfig,
+	mediaService *services.MediaService,
+	mediaURLSigner *services.MediaURLSigner,
+) *MediaHandler {
+	return &MediaHandler{
+		config,
+		mediaService,
+		mediaURLSigner,
+	}
+}
+
+// GetMediaMediaId serves the file of a media through its signed URL, which
+// is the only authorization required, so that it can be embedded in pages.
+func (h *MediaHandler) GetMediaMediaId(
+	c echo.Context,
+	mediaId string,
+	params api.GetMediaMediaIdParams,
+) error {
+	var variant string
+	if params.Variant != nil {
+		variant = string(*params.Variant)
+	}
+
+	err := h.mediaURLSigner.Verify(
+		mediaId,
+		variant,
+		params.Expires,
+		params.Signature,
+	)
+	if stderrors.Is(err, services.ErrMediaURLExpired) {
+		return echo.NewHTTPError(http.StatusForbidden, "Media URL expired")
+	}
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusForbidden,
+			"Invalid media URL signature",
+		)
+	}
+
+	content, err := h.mediaService.OpenMedia(
+		c.Request().Context(),
+		mediaId,
+		variant,
+	)
+	if stderrors.Is(err, services.ErrMediaNotReady) {
+		return echo.NewHTTPError(
+			http.StatusConflict,
+			"Media is not processed yet",
+		)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Media not found")
+	}
+	defer content.Close()
+
+	// The file behind a URL never changes, so it can be cached until the URL
+	// expires.
+	maxAge := max(0, params.Expires-time.Now().Unix())
+	header := c.Response().Header()
+	header.Set("Cache-Control", fmt.Sprintf("private, max-age=%d", maxAge))
+	header.Set("Content-Type", content.ContentType)
+	header.Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(
+		c.Response(),
+		c.Request(),
+		"",
+		content.CreatedAt,
+		content,
+	)
+
+	return nil
+}
+
+func (h *MediaHandler) PostMedia(c echo.Context) error {
+	maxBytes := int64(h.config.MaxUploadBytes)
+	req := c.Request()
+	req.Body = http.MaxBytesReader(
+		c.Response(),
+		req.Body,
+		maxBytes+mediaFormOverheadBytes,
+	)
+
+	reader, err := req.MultipartReader()
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusBadRequest,
+			"Expected a multipart/form-data request",
+		)
+	}
+
+	data, err := readMediaFile(reader, maxBytes)
+	var maxBytesErr *http.MaxBytesError
+	if stderrors.Is(err, errMediaTooLarge) || stderrors.As(err, &maxBytesErr) {
+		return echo.NewHTTPError(
+			http.StatusRequestEntityTooLarge,
+			fmt.Sprintf("File should be at most %d bytes", maxBytes),
+		)
+	}
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusBadRequest,
+			"Invalid request payload",
+		)
+	}
+	if len(data) == 0 {
+		return errors.NewFieldsValidationError(map[string]string{
+			"file": "File is required",
+		})
+	}
+
+	media, err := h.mediaService.Upload(
+		req.Context(),
+		c.Get("userId").(string),
+		data,
+	)
+	if stderrors.Is(err, services.ErrUnsupportedMediaType) {
+		return echo.NewHTTPError(
+			http.StatusUnsupportedMediaType,
+			"File should be one of: "+
+				strings.Join(h.config.AllowedContentTypes, ", "),
+		)
+	}
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to upload media",
+		)
+	}
+
+	return c.JSON(
+		http.StatusCreated,
+		mapModelMediaToApi(media, h.mediaURLSigner),
+	)
+}
+
+// readMediaFile reads the file part of the form into memory. It returns no
+// data when the form has no file, and errMediaTooLarge when the file is
+// larger than maxBytes.
+func readMediaFile(reader *multipart.Reader, maxBytes int64) ([]byte, error) {
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if part.FormName() != "file" {
+			continue
+		}
+
+		data, err := io.ReadAll(io.LimitReader(part, maxBytes+1))
+		if err != nil {
+			return nil, err
+		}
+		if int64(len(data)) > maxBytes {
+			return nil, errMediaTooLarge
+		}
+		return data, nil
+	}
+}
+
+// mapModelMediaToApi maps the media with signed URLs, which are only set once
+// the media is ready.
+func mapModelMediaToApi(
+	media *models.Media,
+	mediaURLSigner *services.MediaURLSigner,
+) api.Media {
+	if media == nil {
+		return api.Media{}
+	}
+
+	var url *string
+	if media.Status == models.MediaStatusReady {
+		signed := mediaURLSigner.URL(media.ID, "")
+		url = &signed
+	}
+	variants := utils.MapSlice(
+		media.Variants,
+		func(variant *models.MediaVariant) api.MediaVariant {
+			return mapModelMediaVariantToApi(variant, mediaURLSigner)
+		},
+	)
+
+	return api.Media{
+		Blurhash:    media.Blurhash,
+		ContentType: media.ContentType,
+		CreatedAt:   media.CreatedAt,
+		Height:      media.Height,
+		Id:          media.ID,
+		SizeBytes:   media.SizeBytes,
+		Status:      api.MediaStatus(media.Status),
+		Url:         url,
+		Variants:    variants,
+		Width:       media.Width,
+	}
+}
+
+func mapModelMediaVariantToApi(
+	variant *models.MediaVariant,
+	mediaURLSigner *services.MediaURLSigner,
+) api.MediaVariant {
+	return api.MediaVariant{
+		ContentType: variant.ContentType,
+		Height:      variant.Height,
+		Name:        api.MediaVariantName(variant.Name),
+		SizeBytes:   variant.SizeBytes,
+		Url:         mediaURLSigner.URL(variant.MediaId, variant.Name),
+		Width:       variant.Width,
+	}
+}
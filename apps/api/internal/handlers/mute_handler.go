@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"apps/api/internal/repositories"
+)
+
+type MuteHandler struct {
+	muteRepo *repositories.MuteRepo
+	userRepo *repositories.UserRepo
+}
+
+func NewMuteHandler(
+	muteRepo *repositories.MuteRepo,
+	userRepo *repositories.UserRepo,
+) *MuteHandler {
+	return &MuteHandler{
+		muteRepo,
+		userRepo,
+	}
+}
+
+func (h *MuteHandler) DeleteUsersUserIdMute(
+	c echo.Context,
+	userId string,
+) error {
+	muterId := c.Get("userId").(string)
+
+	if _, err := h.userRepo.GetUserById(
+		c.Request().Context(),
+		userId,
+	); err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "User not found")
+	}
+
+	if err := h.muteRepo.Unmute(
+		c.Request().Context(),
+		muterId,
+		userId,
+	); err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to unmute user",
+		)
+	}
+
+	return c.JSON(http.StatusNoContent, nil)
+}
+
+func (h *MuteHandler) PutUsersUserIdMute(
+	c echo.Context,
+	userId string,
+) error {
+	muterId := c.Get("userId").(string)
+	if muterId == userId {
+		return echo.NewHTTPError(
+			http.StatusBadRequest,
+			"You cannot mute yourself",
+		)
+	}
+
+	if _, err := h.userRepo.GetUserById(
+		c.Request().Context(),
+		userId,
+	); err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "User not found")
+	}
+
+	if err := h.muteRepo.Mute(
+		c.Request().Context(),
+		muterId,
+		userId,
+	); err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to mute user",
+		)
+	}
+
+	return c.JSON(http.StatusNoContent, nil)
+}
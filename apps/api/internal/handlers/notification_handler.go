@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	stderrors "errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"apps/api/internal/api"
+	"apps/api/internal/errors"
+	"apps/api/internal/models"
+	"apps/api/internal/repositories"
+	"apps/api/internal/schemas"
+	"apps/api/internal/utils"
+)
+
+type NotificationHandler struct {
+	notificationRepo *repositories.NotificationRepo
+}
+
+func NewNotificationHandler(
+	notificationRepo *repositories.NotificationRepo,
+) *NotificationHandler {
+	return &NotificationHandler{notificationRepo}
+}
+
+func (h *NotificationHandler) GetNotifications(
+	c echo.Context,
+	params api.GetNotificationsParams,
+) error {
+	if errs := schemas.GetNotificationsParamsSchema.Validate(
+		&params,
+	); errs != nil {
+		return errors.NewValidationError(&errs)
+	}
+
+	limit := 20
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	var cursor *models.NotificationCursor
+	if params.Cursor != nil {
+		cursor = &models.NotificationCursor{}
+		if err := utils.DecodeCursor(*params.Cursor, cursor); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid cursor")
+		}
+	}
+
+	notifications, err := h.notificationRepo.GetNotifications(
+		c.Request().Context(),
+		models.NotificationListParams{
+			Cursor:     cursor,
+			Limit:      limit,
+			UnreadOnly: params.Unread != nil && *params.Unread,
+			UserId:     c.Get("userId").(string),
+		},
+	)
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to retrieve notifications",
+		)
+	}
+	if notifications == nil {
+		notifications = []*models.Notification{}
+	}
+
+	page := api.CursorPaginatedNotifications{
+		Items: utils.MapSlice(notifications, mapModelNotificationToApi),
+	}
+	if len(notifications) == limit {
+		last := notifications[len(notifications)-1]
+		nextCursor, err := utils.EncodeCursor(models.NotificationCursor{
+			CreatedAt: last.CreatedAt,
+			Id:        last.ID,
+		})
+		if err != nil {
+			return echo.NewHTTPError(
+				http.StatusInternalServerError,
+				"Failed to encode cursor",
+			)
+		}
+		page.NextCursor = &nextCursor
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
+
+func (h *NotificationHandler) GetNotificationsUnreadCount(
+	c echo.Context,
+) error {
+	count, err := h.notificationRepo.CountUnreadNotifications(
+		c.Request().Context(),
+		c.Get("userId").(string),
+	)
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to count notifications",
+		)
+	}
+
+	return c.JSON(http.StatusOK, api.NotificationsUnreadCount{Count: count})
+}
+
+func (h *NotificationHandler) PostNotificationsRead(c echo.Context) error {
+	err := h.notificationRepo.MarkAllNotificationsRead(
+		c.Request().Context(),
+		c.Get("userId").(string),
+	)
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to mark notifications read",
+		)
+	}
+
+	return c.JSON(http.StatusNoContent, nil)
+}
+
+func (h *NotificationHandler) PostNotificationsNotificationIdRead(
+	c echo.Context,
+	notificationId string,
+) error {
+	err := h.notificationRepo.MarkNotificationRead(
+		c.Request().Context(),
+		c.Get("userId").(string),
+		notificationId,
+	)
+	if stderrors.Is(err, repositories.ErrNotificationNotFound) {
+		return echo.NewHTTPError(
+			http.StatusNotFound,
+			"Notification not found",
+		)
+	}
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to mark notification read",
+		)
+	}
+
+	return c.JSON(http.StatusNoContent, nil)
+}
+
+func mapModelNotificationToApi(
+	notification *models.Notification,
+) api.Notification {
+	if notification == nil {
+		return api.Notification{}
+	}
+
+	var action *api.ReportAction
+	if notification.Payload.Action != nil {
+		reportAction := api.ReportAction(*notification.Payload.Action)
+		action = &reportAction
+	}
+	payload := api.NotificationPayload{
+		Action:   action,
+		Emoji:    notification.Payload.Emoji,
+		ReportId: notification.Payload.ReportId,
+	}
+
+	return api.Notification{
+		ActorIds:    notification.ActorIds,
+		ActorsCount: notification.ActorsCount,
+		CommentId:   notification.CommentId,
+		CreatedAt:   notification.CreatedAt,
+		Id:          notification.ID,
+		IsRead:      notification.IsRead,
+		Payload:     payload,
+		PostId:      notification.PostId,
+		Type:        api.NotificationType(notification.Type),
+	}
+}
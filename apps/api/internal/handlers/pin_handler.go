@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	stderrors "errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"apps/api/internal/api"
+	"apps/api/internal/config"
+	"apps/api/internal/errors"
+	"apps/api/internal/repositories"
+	"apps/api/internal/schemas"
+)
+
+type PinHandler struct {
+	config   *config.PostsConfig
+	postRepo *repositories.PostRepo
+}
+
+func NewPinHandler(
+	config *config.PostsConfig,
+	postRepo *repositories.PostRepo,
+) *PinHandler {
+	return &PinHandler{
+		config,
+		postRepo,
+	}
+}
+
+func (h *PinHandler) DeletePostsPostIdPin(
+	c echo.Context,
+	postId string,
+) error {
+	userId := c.Get("userId").(string)
+
+	if err := h.requireAuthor(c, userId, postId); err != nil {
+		return err
+	}
+
+	if err := h.postRepo.UnpinPost(
+		c.Request().Context(),
+		userId,
+		postId,
+	); err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to unpin post",
+		)
+	}
+
+	return c.JSON(http.StatusNoContent, nil)
+}
+
+func (h *PinHandler) PutPostsPostIdPin(
+	c echo.Context,
+	postId string,
+	params api.PutPostsPostIdPinParams,
+) error {
+	if errs := schemas.PutPostsPostIdPinParamsSchema.Validate(
+		&params,
+	); errs != nil {
+		return errors.NewValidationError(&errs)
+	}
+
+	userId := c.Get("userId").(string)
+
+	if err := h.requireAuthor(c, userId, postId); err != nil {
+		return err
+	}
+
+	err := h.postRepo.PinPost(
+		c.Request().Context(),
+		userId,
+		postId,
+		params.Position,
+		h.config.MaxPinnedPosts,
+	)
+	if stderrors.Is(err, repositories.ErrPinLimitReached) {
+		return echo.NewHTTPError(
+			http.StatusConflict,
+			fmt.Sprintf(
+				"You can pin at most %d posts",
+				h.config.MaxPinnedPosts,
+			),
+		)
+	}
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to pin post",
+		)
+	}
+
+	return c.JSON(http.StatusNoContent, nil)
+}
+
+// requireAuthor fails unless the post exists and was written by userId.
+func (h *PinHandler) requireAuthor(
+	c echo.Context,
+	userId string,
+	postId string,
+) error {
+	post, err := h.postRepo.GetPostById(c.Request().Context(), userId, postId)
+	if err != nil || post == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Post not found")
+	}
+	if post.AuthorId != userId {
+		return echo.NewHTTPError(
+			http.StatusForbidden,
+			"You can only pin your own posts",
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	stderrors "errors"
+	"net/http"
+	"slices"
+
+	"github.com/labstack/echo/v4"
+
+	"apps/api/internal/api"
+	"apps/api/internal/errors"
+	"apps/api/internal/models"
+	"apps/api/internal/repositories"
+	"apps/api/internal/schemas"
+	"apps/api/internal/utils"
+)
+
+type PollHandler struct {
+	pollRepo *repositories.PollRepo
+	postRepo *repositories.PostRepo
+}
+
+func NewPollHandler(
+	pollRepo *repositories.PollRepo,
+	postRepo *repositories.PostRepo,
+) *PollHandler {
+	return &PollHandler{
+		pollRepo,
+		postRepo,
+	}
+}
+
+func (h *PollHandler) PostPostsPostIdPollVotes(
+	c echo.Context,
+	postId string,
+) error {
+	var req api.CreatePollVoteRequest
+	if err := utils.BindRequest(c, &req); err != nil {
+		return err
+	}
+
+	if errs := schemas.CreatePollVoteRequestSchema.Validate(
+		&req,
+	); errs != nil {
+		return errors.NewValidationError(&errs)
+	}
+
+	userId := c.Get("userId").(string)
+
+	post, err := h.postRepo.GetPostById(c.Request().Context(), userId, postId)
+	if err != nil || post == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Post not found")
+	}
+	if post.Poll == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Post has no poll")
+	}
+	for _, optionId := range req.OptionIds {
+		if !slices.ContainsFunc(
+			post.Poll.Options,
+			func(option *models.PollOption) bool {
+				return option.ID == optionId
+			},
+		) {
+			return invalidPollOptionsError()
+		}
+	}
+
+	err = h.pollRepo.Vote(
+		c.Request().Context(),
+		postId,
+		userId,
+		req.OptionIds,
+	)
+	if stderrors.Is(err, repositories.ErrPollClosed) {
+		return echo.NewHTTPError(http.StatusConflict, "Poll is closed")
+	}
+	if stderrors.Is(err, repositories.ErrPollAlreadyVoted) {
+		return echo.NewHTTPError(
+			http.StatusConflict,
+			"You already voted in this poll",
+		)
+	}
+	if stderrors.Is(err, repositories.ErrPollInvalidOptions) {
+		return invalidPollOptionsError()
+	}
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to vote",
+		)
+	}
+
+	poll, err := h.pollRepo.GetPoll(c.Request().Context(), userId, postId)
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to retrieve poll",
+		)
+	}
+
+	return c.JSON(http.StatusOK, mapModelPollToApi(poll))
+}
+
+func invalidPollOptionsError() error {
+	return errors.NewFieldsValidationError(map[string]string{
+		"optionIds": "Should be options of the poll, " +
+			"only one unless the poll is multiple choice",
+	})
+}
+
+// mapModelPollToApi leaves out the vote counts until the user reading the
+// poll voted or the poll closed.
+func mapModelPollToApi(poll *models.Poll) api.Poll {
+	if poll == nil {
+		return api.Poll{}
+	}
+
+	resultsVisible := poll.ResultsVisible()
+	options := make([]api.PollOption, 0, len(poll.Options))
+	for _, option := range poll.Options {
+		apiOption := api.PollOption{Id: option.ID, Text: option.Text}
+		if resultsVisible {
+			apiOption.VotesCount = &option.VotesCount
+		}
+		options = append(options, apiOption)
+	}
+	var votersCount *int
+	if resultsVisible {
+		votersCount = &poll.VotersCount
+	}
+	myVotes := poll.MyVotes
+	if myVotes == nil {
+		myVotes = []string{}
+	}
+
+	return api.Poll{
+		ClosesAt:       poll.ClosesAt,
+		IsClosed:       poll.Closed(),
+		MultipleChoice: poll.MultipleChoice,
+		MyVotes:        myVotes,
+		Options:        options,
+		VotersCount:    votersCount,
+	}
+}
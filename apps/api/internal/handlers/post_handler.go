@@ -1,8 +1,14 @@
 package handlers
 
 import (
+	stderrors "errors"
 	"fmt"
+	"maps"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 
@@ -11,20 +17,42 @@ import (
 	"apps/api/internal/models"
 	"apps/api/internal/repositories"
 	"apps/api/internal/schemas"
+	"apps/api/internal/services"
 	"apps/api/internal/utils"
 )
 
 type PostHandler struct {
-	postRepo *repositories.PostRepo
-	userRepo *repositories.UserRepo
+	contentFilter      *services.ContentFilter
+	contentRenderer    *services.ContentRenderer
+	linkPreviewService *services.LinkPreviewService
+	mediaRepo          *repositories.MediaRepo
+	mediaURLSigner     *services.MediaURLSigner
+	postRepo           *repositories.PostRepo
+	postViewRecorder   *services.PostViewRecorder
+	reportRepo         *repositories.ReportRepo
+	userRepo           *repositories.UserRepo
 }
 
 func NewPostHandler(
+	contentFilter *services.ContentFilter,
+	contentRenderer *services.ContentRenderer,
+	linkPreviewService *services.LinkPreviewService,
+	mediaRepo *repositories.MediaRepo,
+	mediaURLSigner *services.MediaURLSigner,
 	postRepo *repositories.PostRepo,
+	postViewRecorder *services.PostViewRecorder,
+	reportRepo *repositories.ReportRepo,
 	userRepo *repositories.UserRepo,
 ) *PostHandler {
 	return &PostHandler{
+		contentFilter,
+		contentRenderer,
+		linkPreviewService,
+		mediaRepo,
+		mediaURLSigner,
 		postRepo,
+		postViewRecorder,
+		reportRepo,
 		userRepo,
 	}
 }
@@ -32,11 +60,13 @@ func NewPostHandler(
 func (h *PostHandler) DeletePostsPostId(
 	c echo.Context,
 	postId string,
+	params api.DeletePostsPostIdParams,
 ) error {
 	userId := c.Get("userId").(string)
 
 	post, err := h.postRepo.GetPostById(
 		c.Request().Context(),
+		userId,
 		postId,
 	)
 	if err != nil {
@@ -52,10 +82,20 @@ func (h *PostHandler) DeletePostsPostId(
 		)
 	}
 
-	if err := h.postRepo.DeletePost(
+	expectedVersion, ok := expectedPostVersion(post, params.IfMatch)
+	if !ok {
+		return h.postPreconditionFailed(c, post)
+	}
+
+	err = h.postRepo.DeletePost(
 		c.Request().Context(),
 		postId,
-	); err != nil {
+		expectedVersion,
+	)
+	if stderrors.Is(err, repositories.ErrPostVersionMismatch) {
+		return h.currentPostPreconditionFailed(c, postId)
+	}
+	if err != nil {
 		return echo.NewHTTPError(
 			http.StatusInternalServerError,
 			"Failed to delete post",
@@ -73,18 +113,25 @@ func (h *PostHandler) GetPosts(
 		return errors.NewValidationError(&errs)
 	}
 
-	limit := 20
-	if params.Limit != nil && *params.Limit > 0 {
-		limit = *params.Limit
-	}
-	offset := 0
-	if params.Offset != nil && *params.Offset >= 0 {
-		offset = *params.Offset
-	}
+	limit, offset := paginationParams(params.Limit, params.Offset)
 	fmt.Print("Fetching posts with params: ", limit, offset)
 
+	// The modification time is read before the posts, so that changes in
+	// between make it older than the response rather than newer.
+	modifiedAt, err := h.postRepo.GetPostsModifiedAt(
+		c.Request().Context(),
+		c.Get("userId").(string),
+	)
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to retrieve posts",
+		)
+	}
+
 	posts, total, err := h.postRepo.GetPosts(
 		c.Request().Context(),
+		c.Get("userId").(string),
 		limit,
 		offset,
 	)
@@ -99,10 +146,22 @@ func (h *PostHandler) GetPosts(
 		posts = []*models.Post{}
 	}
 
+	etag, lastModified := postsValidators(
+		posts,
+		limit,
+		offset,
+		total,
+		modifiedAt,
+		h.mediaURLSigner,
+	)
+	if utils.CheckNotModified(c, etag, lastModified) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
 	return c.JSON(
 		http.StatusOK,
 		api.PaginatedPosts{
-			Items:  utils.MapSlice(posts, mapModelPostToApi),
+			Items:  mapModelPostsToApi(posts, h.mediaURLSigner),
 			Limit:  &limit,
 			Offset: &offset,
 			Total:  total,
@@ -114,8 +173,24 @@ func (h *PostHandler) GetPostsPostId(
 	c echo.Context,
 	postId string,
 ) error {
+	userId := c.Get("userId").(string)
+
+	// The modification time is read before the post, see GetPosts.
+	modifiedAt, err := h.postRepo.GetPostModifiedAt(
+		c.Request().Context(),
+		userId,
+		postId,
+	)
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to retrieve post",
+		)
+	}
+
 	post, err := h.postRepo.GetPostById(
 		c.Request().Context(),
+		userId,
 		postId,
 	)
 	if err != nil || post == nil {
@@ -125,15 +200,25 @@ func (h *PostHandler) GetPostsPostId(
 		)
 	}
 
+	if viewerKey := postViewerKey(c); viewerKey != "" &&
+		post.AuthorId != userId {
+		h.postViewRecorder.Record(post.ID, viewerKey)
+	}
+
+	if checkPostNotModified(c, post, modifiedAt, h.mediaURLSigner) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
 	return c.JSON(
 		http.StatusOK,
-		mapModelPostToApi(post),
+		mapModelPostToApi(post, h.mediaURLSigner),
 	)
 }
 
 func (h *PostHandler) PatchPostsPostId(
 	c echo.Context,
 	postId string,
+	params api.PatchPostsPostIdParams,
 ) error {
 	var req api.UpdatePostRequest
 	if err := utils.BindRequest(c, &req); err != nil {
@@ -144,15 +229,79 @@ func (h *PostHandler) PatchPostsPostId(
 		return errors.NewValidationError(&errs)
 	}
 
-	post, err := h.postRepo.UpdatePost(
+	userId := c.Get("userId").(string)
+
+	post, err := h.postRepo.GetPostById(
 		c.Request().Context(),
+		userId,
 		postId,
+	)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Post not found")
+	}
+	if post.AuthorId != userId {
+		return echo.NewHTTPError(
+			http.StatusForbidden,
+			"You do not have permission to update this post",
+		)
+	}
+
+	expectedVersion, ok := expectedPostVersion(post, params.IfMatch)
+	if !ok {
+		return h.postPreconditionFailed(c, post)
+	}
+
+	filtered, err := h.checkContent(c, services.ContentFilterInput{
+		AuthorId: userId,
+		Content:  req.Content,
+		PostId:   postId,
+		Title:    req.Title,
+	})
+	if err != nil {
+		return err
+	}
+
+	var contentHtml *string
+	if req.Content != nil || req.ContentFormat != nil {
+		content := post.Content
+		if req.Content != nil {
+			content = *req.Content
+		} else if expectedVersion == nil {
+			// The stored content is rendered in the new format, so it must
+			// not change meanwhile.
+			expectedVersion = &post.Version
+		}
+		contentFormat := post.ContentFormat
+		if req.ContentFormat != nil {
+			contentFormat = models.ContentFormat(*req.ContentFormat)
+		}
+		html, err := h.renderContent(content, contentFormat)
+		if err != nil {
+			return err
+		}
+		contentHtml = &html
+	}
+
+	post, err = h.postRepo.UpdatePost(
+		c.Request().Context(),
+		postId,
+		userId,
 		models.PostUpdate{
-			Content: req.Content,
-			Title:   req.Title,
+			Content:       req.Content,
+			ContentFormat: (*models.ContentFormat)(req.ContentFormat),
+			ContentHtml:   contentHtml,
+			Tags:          req.Tags,
+			Title:         req.Title,
+			Visibility:    (*models.PostVisibility)(req.Visibility),
 		},
+		expectedVersion,
 	)
-
+	if stderrors.Is(err, repositories.ErrPostNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Post not found")
+	}
+	if stderrors.Is(err, repositories.ErrPostVersionMismatch) {
+		return h.currentPostPreconditionFailed(c, postId)
+	}
 	if err != nil || post == nil {
 		return echo.NewHTTPError(
 			http.StatusInternalServerError,
@@ -160,7 +309,165 @@ func (h *PostHandler) PatchPostsPostId(
 		)
 	}
 
-	return c.JSON(http.StatusOK, mapModelPostToApi(post))
+	h.flagPost(c, post, filtered)
+	if req.Content != nil {
+		h.linkPreviewService.Enqueue(utils.ExtractLinks(post.Content)...)
+	}
+
+	c.Response().Header().Set("ETag", postETag(post, h.mediaURLSigner))
+	return c.JSON(http.StatusOK, mapModelPostToApi(post, h.mediaURLSigner))
+}
+
+func (h *PostHandler) GetUsersMeTrash(
+	c echo.Context,
+	params api.GetUsersMeTrashParams,
+) error {
+	if errs := schemas.GetUsersMeTrashParamsSchema.Validate(
+		&params,
+	); errs != nil {
+		return errors.NewValidationError(&errs)
+	}
+
+	limit, offset := paginationParams(params.Limit, params.Offset)
+
+	posts, total, err := h.postRepo.GetTrashedPosts(
+		c.Request().Context(),
+		c.Get("userId").(string),
+		limit,
+		offset,
+	)
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to retrieve trashed posts",
+		)
+	}
+	if posts == nil {
+		posts = []*models.Post{}
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		api.PaginatedPosts{
+			Items:  mapModelPostsToApi(posts, h.mediaURLSigner),
+			Limit:  &limit,
+			Offset: &offset,
+			Total:  total,
+		},
+	)
+}
+
+func (h *PostHandler) GetUsersUserIdPosts(
+	c echo.Context,
+	userId string,
+	params api.GetUsersUserIdPostsParams,
+) error {
+	if errs := schemas.GetUsersUserIdPostsParamsSchema.Validate(
+		&params,
+	); errs != nil {
+		return errors.NewValidationError(&errs)
+	}
+
+	limit := 20
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	var cursor *models.UserPostCursor
+	if params.Cursor != nil {
+		cursor = &models.UserPostCursor{}
+		if err := utils.DecodeCursor(*params.Cursor, cursor); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid cursor")
+		}
+	}
+
+	if _, err := h.userRepo.GetUserById(
+		c.Request().Context(),
+		userId,
+	); err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "User not found")
+	}
+
+	posts, err := h.postRepo.GetUserPosts(
+		c.Request().Context(),
+		c.Get("userId").(string),
+		userId,
+		cursor,
+		limit,
+	)
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to retrieve posts",
+		)
+	}
+	if posts == nil {
+		posts = []*models.Post{}
+	}
+
+	page := api.CursorPaginatedPosts{
+		Items: mapModelPostsToApi(posts, h.mediaURLSigner),
+	}
+	if len(posts) == limit {
+		last := posts[len(posts)-1]
+		nextCursor, err := utils.EncodeCursor(models.UserPostCursor{
+			PinnedPosition: last.PinnedPosition,
+			CreatedAt:      last.CreatedAt,
+			Id:             last.ID,
+		})
+		if err != nil {
+			return echo.NewHTTPError(
+				http.StatusInternalServerError,
+				"Failed to encode cursor",
+			)
+		}
+		page.NextCursor = &nextCursor
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
+
+func (h *PostHandler) PostPostsPostIdRestore(
+	c echo.Context,
+	postId string,
+) error {
+	userId := c.Get("userId").(string)
+
+	post, err := h.postRepo.GetTrashedPostById(
+		c.Request().Context(),
+		postId,
+	)
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusNotFound,
+			"Post not found in trash",
+		)
+	}
+	if post.AuthorId != userId {
+		return echo.NewHTTPError(
+			http.StatusForbidden,
+			"You do not have permission to restore this post",
+		)
+	}
+
+	post, err = h.postRepo.RestorePost(
+		c.Request().Context(),
+		userId,
+		postId,
+	)
+	if stderrors.Is(err, repositories.ErrPostNotFound) {
+		return echo.NewHTTPError(
+			http.StatusNotFound,
+			"Post not found in trash",
+		)
+	}
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to restore post",
+		)
+	}
+
+	return c.JSON(http.StatusOK, mapModelPostToApi(post, h.mediaURLSigner))
 }
 
 func (h *PostHandler) PostPosts(c echo.Context) error {
@@ -173,32 +480,583 @@ func (h *PostHandler) PostPosts(c echo.Context) error {
 		return errors.NewValidationError(&errs)
 	}
 
+	var tags []string
+	if req.Tags != nil {
+		tags = *req.Tags
+	}
+
+	var mediaIds []string
+	if req.MediaIds != nil {
+		mediaIds = *req.MediaIds
+	}
+
+	visibility := models.PostVisibilityPublic
+	if req.Visibility != nil {
+		visibility = models.PostVisibility(*req.Visibility)
+	}
+
+	contentFormat := models.ContentFormatPlain
+	if req.ContentFormat != nil {
+		contentFormat = models.ContentFormat(*req.ContentFormat)
+	}
+
+	var poll *models.PollCreate
+	if req.Poll != nil {
+		poll = &models.PollCreate{
+			ClosesAt: req.Poll.ClosesAt,
+			Options:  req.Poll.Options,
+		}
+		if req.Poll.MultipleChoice != nil {
+			poll.MultipleChoice = *req.Poll.MultipleChoice
+		}
+	}
+
+	userId := c.Get("userId").(string)
+
+	if req.QuotedPostId != nil {
+		if err := h.checkQuotedPost(c, userId, *req.QuotedPostId); err != nil {
+			return err
+		}
+	}
+
+	if err := h.checkMedia(c, userId, mediaIds); err != nil {
+		return err
+	}
+
+	filtered, err := h.checkContent(c, services.ContentFilterInput{
+		AuthorId: userId,
+		Content:  &req.Content,
+		Title:    &req.Title,
+	})
+	if err != nil {
+		return err
+	}
+
+	contentHtml, err := h.renderContent(req.Content, contentFormat)
+	if err != nil {
+		return err
+	}
+
 	post, err := h.postRepo.CreatePost(
 		c.Request().Context(),
 		models.PostCreate{
-			AuthorId: c.Get("userId").(string),
-			Title:    req.Title,
-			Content:  req.Content,
+			AuthorId:      userId,
+			Title:         req.Title,
+			Content:       req.Content,
+			ContentFormat: contentFormat,
+			ContentHtml:   contentHtml,
+			MediaIds:      mediaIds,
+			Poll:          poll,
+			QuotedPostId:  req.QuotedPostId,
+			Tags:          tags,
+			Visibility:    visibility,
 		},
 	)
 
+	if stderrors.Is(err, repositories.ErrMediaUnavailable) {
+		return unavailableMediaError()
+	}
 	if err != nil || post == nil {
 		return echo.NewHTTPError(
 			http.StatusInternalServerError,
 			"Failed to create post")
 	}
 
-	return c.JSON(http.StatusCreated, mapModelPostToApi(post))
+	h.flagPost(c, post, filtered)
+	h.linkPreviewService.Enqueue(utils.ExtractLinks(post.Content)...)
+
+	c.Response().Header().Set("ETag", postETag(post, h.mediaURLSigner))
+	return c.JSON(http.StatusCreated, mapModelPostToApi(post, h.mediaURLSigner))
+}
+
+// postViewerKey identifies who views a post, the current user or, for
+// requests without one, the device sent in the X-Device-Id header.
+func postViewerKey(c echo.Context) string {
+	if userId, ok := c.Get("userId").(string); ok && userId != "" {
+		return "user:" + userId
+	}
+	if deviceId := c.Request().Header.Get("X-Device-Id"); deviceId != "" {
+		return "device:" + deviceId
+	}
+	return ""
 }
 
-func mapModelPostToApi(post *models.Post) api.Post {
+// checkQuotedPost fails unless the user can read the post to quote and the
+// post is public.
+func (h *PostHandler) checkQuotedPost(
+	c echo.Context,
+	userId string,
+	postId string,
+) error {
+	post, err := h.postRepo.GetPostById(c.Request().Context(), userId, postId)
+	if err != nil || post == nil {
+		return errors.NewFieldsValidationError(map[string]string{
+			"quotedPostId": "Post not found",
+		})
+	}
+	if post.Visibility != models.PostVisibilityPublic {
+		return errors.NewFieldsValidationError(map[string]string{
+			"quotedPostId": "Only public posts can be quoted",
+		})
+	}
+
+	return nil
+}
+
+// checkMedia fails unless the media were uploaded by the user and are not
+// attached to a post yet.
+func (h *PostHandler) checkMedia(
+	c echo.Context,
+	userId string,
+	mediaIds []string,
+) error {
+	for _, id := range mediaIds {
+		media, err := h.mediaRepo.GetMediaById(c.Request().Context(), id)
+		if err != nil || media.OwnerId == nil || *media.OwnerId != userId {
+			return errors.NewFieldsValidationError(map[string]string{
+				"mediaIds": "Media not found",
+			})
+		}
+		if media.PostId != nil {
+			return unavailableMediaError()
+		}
+	}
+
+	return nil
+}
+
+// renderContent renders the content to the HTML stored with the post, so
+// that it is not rendered again on every read.
+func (h *PostHandler) renderContent(
+	content string,
+	contentFormat models.ContentFormat,
+) (string, error) {
+	html, err := h.contentRenderer.Render(content, contentFormat)
+	if err != nil {
+		return "", echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to render content",
+		)
+	}
+	return html, nil
+}
+
+func unavailableMediaError() error {
+	return errors.NewFieldsValidationError(map[string]string{
+		"mediaIds": "Media should not be attached to a post already",
+	})
+}
+
+// checkContent runs the post through the content filter and fails with the
+// field errors of the rules that rejected it.
+func (h *PostHandler) checkContent(
+	c echo.Context,
+	input services.ContentFilterInput,
+) (*services.ContentFilterResult, error) {
+	result, err := h.contentFilter.Check(c.Request().Context(), input)
+	if err != nil {
+		return nil, echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to check post content",
+		)
+	}
+	if result.Rejected() {
+		return nil, errors.NewFieldsValidationError(result.FieldErrors)
+	}
+
+	return result, nil
+}
+
+// flagPost queues the post for review when the content filter flagged it.
+// The post is already saved, so a failure is only logged.
+func (h *PostHandler) flagPost(
+	c echo.Context,
+	post *models.Post,
+	result *services.ContentFilterResult,
+) {
+	if !result.Flagged() {
+		return
+	}
+
+	details := strings.Join(result.Flags, "\n")
+	if _, err := h.reportRepo.CreateReport(
+		c.Request().Context(),
+		models.ReportCreate{
+			Details: &details,
+			PostId:  post.ID,
+			Reason:  models.ReportReasonOther,
+		},
+	); err != nil {
+		c.Logger().Errorf("Failed to flag post %s: %v", post.ID, err)
+	}
+}
+
+// currentPostPreconditionFailed responds with the latest version of the post
+// after a conditional change lost the race against a concurrent update.
+func (h *PostHandler) currentPostPreconditionFailed(
+	c echo.Context,
+	postId string,
+) error {
+	post, err := h.postRepo.GetPostById(
+		c.Request().Context(),
+		c.Get("userId").(string),
+		postId,
+	)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Post not found")
+	}
+
+	return h.postPreconditionFailed(c, post)
+}
+
+func (h *PostHandler) postPreconditionFailed(
+	c echo.Context,
+	post *models.Post,
+) error {
+	c.Response().Header().Set("ETag", postETag(post, h.mediaURLSigner))
+	return c.JSON(
+		http.StatusPreconditionFailed,
+		mapModelPostToApi(post, h.mediaURLSigner),
+	)
+}
+
+// mapModelPostToApi maps the post, signing the URLs of its media, which are
+// served to whoever the post is served to.
+func mapModelPostToApi(
+	post *models.Post,
+	mediaURLSigner *services.MediaURLSigner,
+) api.Post {
 	if post == nil {
 		return api.Post{}
 	}
+	myReactions := post.MyReactions
+	if myReactions == nil {
+		myReactions = []string{}
+	}
+	var poll *api.Poll
+	if post.Poll != nil {
+		apiPoll := mapModelPollToApi(post.Poll)
+		poll = &apiPoll
+	}
+	var quotedPost *api.Post
+	if post.QuotedPost != nil {
+		quoted := mapModelPostToApi(post.QuotedPost, mediaURLSigner)
+		quotedPost = &quoted
+	}
+	var repostedBy *string
+	var repostedAt *time.Time
+	if post.Repost != nil {
+		repostedBy = &post.Repost.UserId
+		repostedAt = &post.Repost.CreatedAt
+	}
+	reactionCounts := post.ReactionCounts
+	if reactionCounts == nil {
+		reactionCounts = map[string]int{}
+	}
+	tags := post.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	media := utils.MapSlice(post.Media, func(media *models.Media) api.Media {
+		return mapModelMediaToApi(media, mediaURLSigner)
+	})
+	linkPreviews := utils.MapSlice(
+		post.LinkPreviews,
+		mapModelLinkPreviewToApi,
+	)
+	mentions := utils.MapSlice(post.Mentions, mapModelMentionToApi)
 	return api.Post{
-		Id:       post.ID,
-		AuthorId: post.AuthorId,
-		Content:  post.Content,
-		Title:    post.Title,
+		Id:             post.ID,
+		AuthorId:       post.AuthorId,
+		CommentsCount:  post.CommentsCount,
+		Content:        post.Content,
+		ContentFormat:  api.ContentFormat(post.ContentFormat),
+		ContentHtml:    post.ContentHtml,
+		CreatedAt:      &post.CreatedAt,
+		DeletedAt:      post.DeletedAt,
+		EditedAt:       post.EditedAt,
+		IsBookmarked:   post.BookmarkedAt != nil,
+		IsEdited:       post.EditedAt != nil,
+		IsPinned:       post.PinnedPosition != nil,
+		IsReposted:     post.RepostedAt != nil,
+		LinkPreviews:   linkPreviews,
+		Media:          media,
+		Mentions:       mentions,
+		MyReactions:    myReactions,
+		Poll:           poll,
+		QuotedPost:     quotedPost,
+		QuotedPostId:   post.QuotedPostId,
+		ReactionCounts: reactionCounts,
+		RepostedAt:     repostedAt,
+		RepostedBy:     repostedBy,
+		RepostsCount:   post.RepostsCount,
+		Tags:           tags,
+		Title:          post.Title,
+		UpdatedAt:      &post.UpdatedAt,
+		Version:        post.Version,
+		Visibility:     api.PostVisibility(post.Visibility),
+	}
+}
+
+func mapModelLinkPreviewToApi(preview *models.LinkPreview) api.LinkPreview {
+	var title string
+	if preview.Title != nil {
+		title = *preview.Title
+	}
+	return api.LinkPreview{
+		Description: preview.Description,
+		ImageUrl:    preview.ImageUrl,
+		SiteName:    preview.SiteName,
+		Title:       title,
+		Url:         preview.Url,
+	}
+}
+
+func mapModelMentionToApi(mention *models.Mention) api.Mention {
+	return api.Mention{
+		Handle: mention.Handle,
+		Length: mention.Length,
+		Offset: mention.Offset,
+		UserId: mention.UserId,
+	}
+}
+
+func mapModelPostsToApi(
+	posts []*models.Post,
+	mediaURLSigner *services.MediaURLSigner,
+) []api.Post {
+	return utils.MapSlice(posts, func(post *models.Post) api.Post {
+		return mapModelPostToApi(post, mediaURLSigner)
+	})
+}
+
+// expectedPostVersion checks the If-Match header against the post. It
+// returns the version the change has to be applied to, or nil when the header
+// is absent, and false when the header does not match the current version.
+// Only the version part of the entity tags is compared, so that reactions
+// and comments added in the meantime do not fail edits of the post.
+func expectedPostVersion(post *models.Post, ifMatch *string) (*int, bool) {
+	if ifMatch == nil {
+		return nil, true
+	}
+	for _, candidate := range strings.Split(*ifMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return &post.Version, true
+		}
+		if version, ok := postETagVersion(candidate); ok &&
+			version == post.Version {
+			return &post.Version, true
+		}
+	}
+	return nil, false
+}
+
+// postETag is the entity tag of the post as seen by the viewer it was loaded
+// for. It is made of the post version followed by a digest of the counters
+// and viewer dependent fields, which change without bumping the version, of
+// the embedded quoted post and media, and of the expiry of the media URLs,
+// so that they are not kept past it.
+func postETag(
+	post *models.Post,
+	mediaURLSigner *services.MediaURLSigner,
+) string {
+	emojis := slices.Sorted(maps.Keys(post.ReactionCounts))
+	parts := []string{strconv.Itoa(post.CommentsCount)}
+	for _, emoji := range emojis {
+		parts = append(
+			parts,
+			emoji,
+			strconv.Itoa(post.ReactionCounts[emoji]),
+		)
+	}
+	parts = append(
+		parts,
+		strings.Join(post.MyReactions, " "),
+		strconv.FormatBool(post.BookmarkedAt != nil),
+		strconv.FormatBool(post.PinnedPosition != nil),
+		strconv.Itoa(post.RepostsCount),
+		strconv.FormatBool(post.RepostedAt != nil),
+	)
+	if post.Poll != nil {
+		parts = append(
+			parts,
+			strconv.Itoa(post.Poll.VotersCount),
+			strings.Join(post.Poll.MyVotes, " "),
+			strconv.FormatBool(post.Poll.Closed()),
+		)
+	}
+	// Link previews are fetched after the post was saved, without changing
+	// its version.
+	for _, preview := range post.LinkPreviews {
+		parts = append(parts, preview.Url)
+	}
+	// Media are processed after the post was saved, without changing its
+	// version.
+	parts = append(parts, mediaETagParts(post.Media)...)
+	if post.QuotedPost != nil {
+		parts = append(
+			parts,
+			post.QuotedPost.ID,
+			strconv.Itoa(post.QuotedPost.Version),
+			strconv.FormatBool(post.QuotedPost.EditedAt != nil),
+		)
+		for _, preview := range post.QuotedPost.LinkPreviews {
+			parts = append(parts, preview.Url)
+		}
+		parts = append(parts, mediaETagParts(post.QuotedPost.Media)...)
+	}
+	if len(post.Media) > 0 ||
+		(post.QuotedPost != nil && len(post.QuotedPost.Media) > 0) {
+		parts = append(
+			parts,
+			strconv.FormatInt(mediaURLSigner.Expires().Unix(), 10),
+		)
+	}
+	digest := strings.Trim(utils.WeakETag(parts...), `W/"`)[:8]
+	return fmt.Sprintf(`"%d-%s"`, post.Version, digest)
+}
+
+// mediaETagParts lists the processing state of the media for postETag.
+func mediaETagParts(media []*models.Media) []string {
+	var parts []string
+	for _, m := range media {
+		parts = append(parts, m.ID, string(m.Status))
+		for _, variant := range m.Variants {
+			parts = append(parts, variant.Name)
+		}
+	}
+	return parts
+}
+
+// postETagVersion extracts the post version from a strong entity tag built
+// by postETag.
+func postETagVersion(etag string) (int, bool) {
+	if len(etag) < 2 || etag[0] != '"' || etag[len(etag)-1] != '"' {
+		return 0, false
+	}
+	version, _, _ := strings.Cut(etag[1:len(etag)-1], "-")
+	n, err := strconv.Atoi(version)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// checkPostNotModified sets the validators of the post on the response and
+// reports whether the request allows answering with 304 Not Modified.
+func checkPostNotModified(
+	c echo.Context,
+	post *models.Post,
+	modifiedAt time.Time,
+	mediaURLSigner *services.MediaURLSigner,
+) bool {
+	return utils.CheckNotModified(
+		c,
+		postETag(post, mediaURLSigner),
+		postLastModified(post, modifiedAt, mediaURLSigner),
+	)
+}
+
+// postLastModified derives the Last-Modified validator of the post from
+// modifiedAt, when the post or the rows it is shown with last changed, and
+// from what changes with time alone: polls closing and media URLs being
+// signed again.
+func postLastModified(
+	post *models.Post,
+	modifiedAt time.Time,
+	mediaURLSigner *services.MediaURLSigner,
+) time.Time {
+	lastModified := modifiedAt
+	for _, shown := range []*models.Post{post, post.QuotedPost} {
+		if shown == nil {
+			continue
+		}
+		if shown.Poll != nil && shown.Poll.Closed() &&
+			shown.Poll.ClosesAt.After(lastModified) {
+			lastModified = shown.Poll.ClosesAt
+		}
+		if len(shown.Media) > 0 {
+			if signed := mediaURLSigner.Signed(); signed.After(lastModified) {
+				lastModified = signed
+			}
+		}
+	}
+	return lastModified
+}
+
+// postsValidators derives the ETag and Last-Modified validators of a page of
+// posts from the page bounds, the versions of the posts on it and when the
+// list last changed.
+func postsValidators(
+	posts []*models.Post,
+	limit int,
+	offset int,
+	total int,
+	modifiedAt time.Time,
+	mediaURLSigner *services.MediaURLSigner,
+) (string, time.Time) {
+	lastModified := modifiedAt
+	parts := []string{
+		strconv.Itoa(limit),
+		strconv.Itoa(offset),
+		strconv.Itoa(total),
+	}
+	for _, post := range posts {
+		parts = append(
+			parts,
+			post.ID,
+			postETag(post, mediaURLSigner),
+			post.UpdatedAt.String(),
+		)
+		lastModified = postLastModified(post, lastModified, mediaURLSigner)
+	}
+	return utils.WeakETag(parts...), lastModified
+}
+
+// postsPage builds a page of posts ordered by creation time, with a cursor
+// to the next page when the page is full.
+func postsPage(
+	posts []*models.Post,
+	limit int,
+	mediaURLSigner *services.MediaURLSigner,
+) (api.CursorPaginatedPosts, error) {
+	if posts == nil {
+		posts = []*models.Post{}
+	}
+	page := api.CursorPaginatedPosts{
+		Items: mapModelPostsToApi(posts, mediaURLSigner),
+	}
+	if len(posts) == limit {
+		last := posts[len(posts)-1]
+		// Feed items brought in by a repost are ordered by the repost time.
+		createdAt := last.CreatedAt
+		if last.Repost != nil {
+			createdAt = last.Repost.CreatedAt
+		}
+		nextCursor, err := utils.EncodeCursor(models.PostCursor{
+			CreatedAt: createdAt,
+			Id:        last.ID,
+		})
+		if err != nil {
+			return page, echo.NewHTTPError(
+				http.StatusInternalServerError,
+				"Failed to encode cursor",
+			)
+		}
+		page.NextCursor = &nextCursor
+	}
+	return page, nil
+}
+
+func paginationParams(limitParam *int, offsetParam *int) (int, int) {
+	limit := 20
+	if limitParam != nil && *limitParam > 0 {
+		limit = *limitParam
+	}
+	offset := 0
+	if offsetParam != nil && *offsetParam >= 0 {
+		offset = *offsetParam
 	}
+	return limit, offset
 }
@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"apps/api/internal/config"
+	"apps/api/internal/models"
+	"apps/api/internal/services"
+	"apps/api/internal/utils"
+)
+
+func getTestMediaURLSigner(t *testing.T) *services.MediaURLSigner {
+	signer, err := services.NewMediaURLSigner(&config.MediaConfig{
+		URLBase:              "/api/v1/media",
+		URLExpirationMinutes: 60,
+		URLSecret:            "secret",
+	})
+	require.NoError(t, err)
+	return signer
+}
+
+// conditionalGet answers a GET with the headers through check, the way the
+// handlers do, and returns the status and the validators of the response.
+func conditionalGet(
+	headers map[string]string,
+	check func(c echo.Context) bool,
+) (int, http.Header) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	if check(c) {
+		c.NoContent(http.StatusNotModified)
+	} else {
+		c.NoContent(http.StatusOK)
+	}
+	return rec.Code, rec.Header()
+}
+
+func TestPostETag(t *testing.T) {
+	signer := getTestMediaURLSigner(t)
+	newPost := func() *models.Post {
+		return &models.Post{
+			ID:      "post",
+			Version: 1,
+			QuotedPost: &models.Post{
+				ID:      "quoted",
+				Version: 1,
+				Media: []*models.Media{
+					{ID: "media", Status: models.MediaStatusPending},
+				},
+			},
+		}
+	}
+	etag := postETag(newPost(), signer)
+	assert.Equal(t, etag, postETag(newPost(), signer))
+
+	t.Run("should change when the quoted post is edited", func(t *testing.T) {
+		post := newPost()
+		editedAt := time.Now()
+		post.QuotedPost.Version = 2
+		post.QuotedPost.EditedAt = &editedAt
+		assert.NotEqual(t, etag, postETag(post, signer))
+	})
+
+	t.Run("should change when media are processed", func(t *testing.T) {
+		post := newPost()
+		media := post.QuotedPost.Media[0]
+		media.Status = models.MediaStatusReady
+		assert.NotEqual(t, etag, postETag(post, signer))
+
+		ready := postETag(post, signer)
+		media.Variants = []*models.MediaVariant{{Name: "thumbnail"}}
+		assert.NotEqual(t, ready, postETag(post, signer))
+	})
+
+	t.Run("should keep the version of the post", func(t *testing.T) {
+		version, ok := postETagVersion(etag)
+		require.True(t, ok)
+		assert.Equal(t, 1, version)
+	})
+}
+
+func TestCheckPostNotModified(t *testing.T) {
+	signer := getTestMediaURLSigner(t)
+	modifiedAt := time.Date(2025, 1, 2, 3, 4, 5, 600, time.UTC)
+	post := &models.Post{ID: "post", Version: 1}
+	get := func(
+		headers map[string]string,
+		modifiedAt time.Time,
+	) (int, http.Header) {
+		return conditionalGet(headers, func(c echo.Context) bool {
+			return checkPostNotModified(c, post, modifiedAt, signer)
+		})
+	}
+
+	code, header := get(nil, modifiedAt)
+	require.Equal(t, http.StatusOK, code)
+	etag := header.Get("ETag")
+	lastModified := header.Get("Last-Modified")
+	assert.Equal(t, "Thu, 02 Jan 2025 03:04:05 GMT", lastModified)
+
+	t.Run("should answer If-None-Match", func(t *testing.T) {
+		code, _ := get(map[string]string{"If-None-Match": etag}, modifiedAt)
+		assert.Equal(t, http.StatusNotModified, code)
+
+		code, _ = get(map[string]string{"If-None-Match": `"0-0"`}, modifiedAt)
+		assert.Equal(t, http.StatusOK, code)
+	})
+
+	t.Run("should answer If-Modified-Since", func(t *testing.T) {
+		headers := map[string]string{"If-Modified-Since": lastModified}
+		code, _ := get(headers, modifiedAt)
+		assert.Equal(t, http.StatusNotModified, code)
+
+		code, _ = get(headers, modifiedAt.Add(time.Second))
+		assert.Equal(t, http.StatusOK, code)
+	})
+
+	t.Run("should prefer If-None-Match", func(t *testing.T) {
+		code, _ := get(
+			map[string]string{
+				"If-Modified-Since": lastModified,
+				"If-None-Match":     `"0-0"`,
+			},
+			modifiedAt,
+		)
+		assert.Equal(t, http.StatusOK, code)
+	})
+
+	t.Run("should be modified when the poll closes", func(t *testing.T) {
+		post.Poll = &models.Poll{ClosesAt: modifiedAt.Add(time.Minute)}
+		defer func() { post.Poll = nil }()
+
+		code, header := get(
+			map[string]string{"If-Modified-Since": lastModified},
+			modifiedAt,
+		)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(
+			t,
+			"Thu, 02 Jan 2025 03:05:05 GMT",
+			header.Get("Last-Modified"),
+		)
+	})
+
+	t.Run("should be modified when media URLs change", func(t *testing.T) {
+		post.Media = []*models.Media{{ID: "media"}}
+		defer func() { post.Media = nil }()
+
+		code, header := get(
+			map[string]string{"If-Modified-Since": lastModified},
+			modifiedAt,
+		)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(
+			t,
+			signer.Signed().UTC().Format(http.TimeFormat),
+			header.Get("Last-Modified"),
+		)
+	})
+}
+
+func TestPostsValidators(t *testing.T) {
+	signer := getTestMediaURLSigner(t)
+	modifiedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	posts := []*models.Post{
+		{ID: "a", Version: 1, UpdatedAt: modifiedAt.Add(-time.Hour)},
+		{ID: "b", Version: 2, UpdatedAt: modifiedAt.Add(-time.Minute)},
+	}
+	get := func(
+		headers map[string]string,
+		modifiedAt time.Time,
+	) (int, http.Header) {
+		return conditionalGet(headers, func(c echo.Context) bool {
+			etag, lastModified := postsValidators(
+				posts,
+				10,
+				0,
+				len(posts),
+				modifiedAt,
+				signer,
+			)
+			return utils.CheckNotModified(c, etag, lastModified)
+		})
+	}
+
+	code, header := get(nil, modifiedAt)
+	require.Equal(t, http.StatusOK, code)
+	etag := header.Get("ETag")
+	lastModified := header.Get("Last-Modified")
+	assert.Equal(t, "Thu, 02 Jan 2025 03:04:05 GMT", lastModified)
+
+	t.Run("should answer If-None-Match", func(t *testing.T) {
+		code, _ := get(map[string]string{"If-None-Match": etag}, modifiedAt)
+		assert.Equal(t, http.StatusNotModified, code)
+
+		code, _ = get(map[string]string{"If-None-Match": `W/"0"`}, modifiedAt)
+		assert.Equal(t, http.StatusOK, code)
+	})
+
+	t.Run("should answer If-Modified-Since", func(t *testing.T) {
+		headers := map[string]string{"If-Modified-Since": lastModified}
+		code, _ := get(headers, modifiedAt)
+		assert.Equal(t, http.StatusNotModified, code)
+
+		// A post left the list, e.g. it was deleted, and touched the list
+		// without changing the posts on the page.
+		code, _ = get(headers, modifiedAt.Add(time.Second))
+		assert.Equal(t, http.StatusOK, code)
+	})
+}
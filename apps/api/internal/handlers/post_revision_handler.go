@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"apps/api/internal/api"
+	"apps/api/internal/errors"
+	"apps/api/internal/models"
+	"apps/api/internal/repositories"
+	"apps/api/internal/schemas"
+	"apps/api/internal/services"
+	"apps/api/internal/utils"
+)
+
+type PostRevisionHandler struct {
+	linkPreviewService *services.LinkPreviewService
+	mediaURLSigner     *services.MediaURLSigner
+	postRepo           *repositories.PostRepo
+	postRevisionRepo   *repositories.PostRevisionRepo
+}
+
+func NewPostRevisionHandler(
+	linkPreviewService *services.LinkPreviewService,
+	mediaURLSigner *services.MediaURLSigner,
+	postRepo *repositories.PostRepo,
+	postRevisionRepo *repositories.PostRevisionRepo,
+) *PostRevisionHandler {
+	return &PostRevisionHandler{
+		linkPreviewService,
+		mediaURLSigner,
+		postRepo,
+		postRevisionRepo,
+	}
+}
+
+func (h *PostRevisionHandler) GetPostsPostIdRevisions(
+	c echo.Context,
+	postId string,
+	params api.GetPostsPostIdRevisionsParams,
+) error {
+	if errs := schemas.GetPostsPostIdRevisionsParamsSchema.Validate(
+		&params,
+	); errs != nil {
+		return errors.NewValidationError(&errs)
+	}
+
+	if _, err := h.postRepo.GetPostById(
+		c.Request().Context(),
+		c.Get("userId").(string),
+		postId,
+	); err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Post not found")
+	}
+
+	limit, offset := paginationParams(params.Limit, params.Offset)
+
+	postRevisions, total, err := h.postRevisionRepo.GetRevisions(
+		c.Request().Context(),
+		postId,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to retrieve post revisions",
+		)
+	}
+	if postRevisions == nil {
+		postRevisions = []*models.PostRevision{}
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		api.PaginatedPostRevisions{
+			Items:  utils.MapSlice(postRevisions, mapModelPostRevisionToApi),
+			Limit:  &limit,
+			Offset: &offset,
+			Total:  total,
+		},
+	)
+}
+
+func (h *PostRevisionHandler) GetPostsPostIdRevisionsRevision(
+	c echo.Context,
+	postId string,
+	revision int,
+) error {
+	if _, err := h.postRepo.GetPostById(
+		c.Request().Context(),
+		c.Get("userId").(string),
+		postId,
+	); err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Post not found")
+	}
+
+	postRevision, err := h.postRevisionRepo.GetRevision(
+		c.Request().Context(),
+		postId,
+		revision,
+	)
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusNotFound,
+			"Post revision not found",
+		)
+	}
+
+	details := api.PostRevisionDetails{
+		Revision: mapModelPostRevisionToApi(postRevision),
+	}
+
+	var previousTitle, previousContent string
+	if revision > 1 {
+		previous, err := h.postRevisionRepo.GetRevision(
+			c.Request().Context(),
+			postId,
+			revision-1,
+		)
+		if err != nil {
+			return echo.NewHTTPError(
+				http.StatusInternalServerError,
+				"Failed to retrieve previous post revision",
+			)
+		}
+		previousTitle = previous.Title
+		previousContent = previous.Content
+		details.PreviousRevision = &previous.Revision
+	}
+
+	details.TitleDiff = utils.MapSlice(
+		utils.DiffLines(previousTitle, postRevision.Title),
+		mapDiffLineToApi,
+	)
+	details.ContentDiff = utils.MapSlice(
+		utils.DiffLines(previousContent, postRevision.Content),
+		mapDiffLineToApi,
+	)
+
+	return c.JSON(http.StatusOK, details)
+}
+
+func (h *PostRevisionHandler) PostPostsPostIdRevisionsRevisionRestore(
+	c echo.Context,
+	postId string,
+	revision int,
+) error {
+	userId := c.Get("userId").(string)
+
+	post, err := h.postRepo.GetPostById(
+		c.Request().Context(),
+		userId,
+		postId,
+	)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Post not found")
+	}
+	if post.AuthorId != userId {
+		return echo.NewHTTPError(
+			http.StatusForbidden,
+			"You do not have permission to restore this post",
+		)
+	}
+
+	postRevision, err := h.postRevisionRepo.GetRevision(
+		c.Request().Context(),
+		postId,
+		revision,
+	)
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusNotFound,
+			"Post revision not found",
+		)
+	}
+
+	post, err = h.postRepo.UpdatePost(
+		c.Request().Context(),
+		postId,
+		userId,
+		models.PostUpdate{
+			Content:       &postRevision.Content,
+			ContentFormat: &postRevision.ContentFormat,
+			ContentHtml:   &postRevision.ContentHtml,
+			Title:         &postRevision.Title,
+		},
+		nil,
+	)
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to restore post revision",
+		)
+	}
+	h.linkPreviewService.Enqueue(utils.ExtractLinks(post.Content)...)
+
+	return c.JSON(http.StatusOK, mapModelPostToApi(post, h.mediaURLSigner))
+}
+
+func mapModelPostRevisionToApi(
+	postRevision *models.PostRevision,
+) api.PostRevision {
+	return api.PostRevision{
+		Content:       postRevision.Content,
+		ContentFormat: api.ContentFormat(postRevision.ContentFormat),
+		ContentHtml:   postRevision.ContentHtml,
+		CreatedAt:     postRevision.CreatedAt,
+		EditorId:      postRevision.EditorId,
+		Revision:      postRevision.Revision,
+		Title:         postRevision.Title,
+	}
+}
+
+func mapDiffLineToApi(line utils.DiffLine) api.DiffLine {
+	return api.DiffLine{
+		Op:   api.DiffLineOp(line.Op),
+		Text: line.Text,
+	}
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"apps/api/internal/api"
+	"apps/api/internal/errors"
+	"apps/api/internal/models"
+	"apps/api/internal/repositories"
+	"apps/api/internal/schemas"
+	"apps/api/internal/utils"
+)
+
+type PostStatsHandler struct {
+	postRepo     *repositories.PostRepo
+	postViewRepo *repositories.PostViewRepo
+	userRepo     *repositories.UserRepo
+}
+
+func NewPostStatsHandler(
+	postRepo *repositories.PostRepo,
+	postViewRepo *repositories.PostViewRepo,
+	userRepo *repositories.UserRepo,
+) *PostStatsHandler {
+	return &PostStatsHandler{
+		postRepo,
+		postViewRepo,
+		userRepo,
+	}
+}
+
+// GetPostsPostIdStats returns the views of a post. Only its author and
+// admins can read them.
+func (h *PostStatsHandler) GetPostsPostIdStats(
+	c echo.Context,
+	postId string,
+	params api.GetPostsPostIdStatsParams,
+) error {
+	if errs := schemas.GetPostsPostIdStatsParamsSchema.Validate(
+		&params,
+	); errs != nil {
+		return errors.NewValidationError(&errs)
+	}
+
+	userId := c.Get("userId").(string)
+
+	post, err := h.postRepo.GetPostById(c.Request().Context(), userId, postId)
+	if err != nil || post == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Post not found")
+	}
+
+	if post.AuthorId != userId {
+		user, err := h.userRepo.GetUserById(c.Request().Context(), userId)
+		if err != nil || user == nil {
+			return echo.NewHTTPError(
+				http.StatusInternalServerError,
+				"Failed to retrieve user",
+			)
+		}
+		if user.Role != models.UserRoleAdmin {
+			return echo.NewHTTPError(
+				http.StatusForbidden,
+				"Only the author can view post stats",
+			)
+		}
+	}
+
+	days := 30
+	if params.Days != nil {
+		days = *params.Days
+	}
+	since := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 1-days)
+
+	total, views, err := h.postViewRepo.GetPostViews(
+		c.Request().Context(),
+		postId,
+		since,
+	)
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to retrieve post stats",
+		)
+	}
+
+	return c.JSON(http.StatusOK, api.PostStats{
+		PostId:     postId,
+		TotalViews: total,
+		Views: utils.MapSlice(
+			views,
+			func(day *models.PostViewsDay) api.PostViewsDay {
+				return api.PostViewsDay{
+					Date:  openapi_types.Date{Time: day.Day},
+					Views: day.Views,
+				}
+			},
+		),
+	})
+}
@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"apps/api/internal/api"
+	"apps/api/internal/errors"
+	"apps/api/internal/models"
+	"apps/api/internal/repositories"
+	"apps/api/internal/schemas"
+	"apps/api/internal/services"
+	"apps/api/internal/utils"
+)
+
+type ReactionHandler struct {
+	mediaURLSigner *services.MediaURLSigner
+	postRepo       *repositories.PostRepo
+	reactionRepo   *repositories.ReactionRepo
+}
+
+func NewReactionHandler(
+	mediaURLSigner *services.MediaURLSigner,
+	postRepo *repositories.PostRepo,
+	reactionRepo *repositories.ReactionRepo,
+) *ReactionHandler {
+	return &ReactionHandler{
+		mediaURLSigner,
+		postRepo,
+		reactionRepo,
+	}
+}
+
+func (h *ReactionHandler) DeletePostsPostIdReactionsEmoji(
+	c echo.Context,
+	postId string,
+	emoji string,
+) error {
+	if err := validateReactionEmoji(emoji); err != nil {
+		return err
+	}
+
+	userId := c.Get("userId").(string)
+
+	if _, err := h.postRepo.GetPostById(
+		c.Request().Context(),
+		userId,
+		postId,
+	); err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Post not found")
+	}
+
+	if err := h.reactionRepo.RemoveReaction(
+		c.Request().Context(),
+		postId,
+		userId,
+		emoji,
+	); err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to remove reaction",
+		)
+	}
+
+	return h.reactedPost(c, userId, postId)
+}
+
+func (h *ReactionHandler) GetPostsPostIdReactions(
+	c echo.Context,
+	postId string,
+	params api.GetPostsPostIdReactionsParams,
+) error {
+	if errs := schemas.GetPostsPostIdReactionsParamsSchema.Validate(
+		&params,
+	); errs != nil {
+		return errors.NewValidationError(&errs)
+	}
+
+	if _, err := h.postRepo.GetPostById(
+		c.Request().Context(),
+		c.Get("userId").(string),
+		postId,
+	); err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Post not found")
+	}
+
+	limit, offset := paginationParams(params.Limit, params.Offset)
+
+	reactions, total, err := h.reactionRepo.GetReactions(
+		c.Request().Context(),
+		c.Get("userId").(string),
+		postId,
+		params.Emoji,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to retrieve reactions",
+		)
+	}
+	if reactions == nil {
+		reactions = []*models.Reaction{}
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		api.PaginatedReactions{
+			Items:  utils.MapSlice(reactions, mapModelReactionToApi),
+			Limit:  &limit,
+			Offset: &offset,
+			Total:  total,
+		},
+	)
+}
+
+func (h *ReactionHandler) PutPostsPostIdReactionsEmoji(
+	c echo.Context,
+	postId string,
+	emoji string,
+) error {
+	if err := validateReactionEmoji(emoji); err != nil {
+		return err
+	}
+
+	userId := c.Get("userId").(string)
+
+	if _, err := h.postRepo.GetPostById(
+		c.Request().Context(),
+		userId,
+		postId,
+	); err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Post not found")
+	}
+
+	if err := h.reactionRepo.AddReaction(
+		c.Request().Context(),
+		postId,
+		userId,
+		emoji,
+	); err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to add reaction",
+		)
+	}
+
+	return h.reactedPost(c, userId, postId)
+}
+
+// reactedPost responds with the post after the reactions of the user on it
+// have changed.
+func (h *ReactionHandler) reactedPost(
+	c echo.Context,
+	userId string,
+	postId string,
+) error {
+	post, err := h.postRepo.GetPostById(c.Request().Context(), userId, postId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Post not found")
+	}
+
+	c.Response().Header().Set("ETag", postETag(post, h.mediaURLSigner))
+	return c.JSON(http.StatusOK, mapModelPostToApi(post, h.mediaURLSigner))
+}
+
+func validateReactionEmoji(emoji string) error {
+	params := struct{ Emoji string }{emoji}
+	if errs := schemas.ReactionEmojiSchema.Validate(&params); errs != nil {
+		return errors.NewValidationError(&errs)
+	}
+	return nil
+}
+
+func mapModelReactionToApi(reaction *models.Reaction) api.Reaction {
+	if reaction == nil {
+		return api.Reaction{}
+	}
+	return api.Reaction{
+		CreatedAt: reaction.CreatedAt,
+		Emoji:     reaction.Emoji,
+		UserId:    reaction.UserId,
+	}
+}
@@ -0,0 +1,281 @@
+package handlers
+
+import (
+	stderrors "errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"apps/api/internal/api"
+	"apps/api/internal/errors"
+	"apps/api/internal/models"
+	"apps/api/internal/repositories"
+	"apps/api/internal/schemas"
+	"apps/api/internal/utils"
+)
+
+type ReportHandler struct {
+	postRepo   *repositories.PostRepo
+	reportRepo *repositories.ReportRepo
+	userRepo   *repositories.UserRepo
+}
+
+func NewReportHandler(
+	postRepo *repositories.PostRepo,
+	reportRepo *repositories.ReportRepo,
+	userRepo *repositories.UserRepo,
+) *ReportHandler {
+	return &ReportHandler{
+		postRepo,
+		reportRepo,
+		userRepo,
+	}
+}
+
+func (h *ReportHandler) GetModerationReports(
+	c echo.Context,
+	params api.GetModerationReportsParams,
+) error {
+	if errs := schemas.GetModerationReportsParamsSchema.Validate(
+		&params,
+	); errs != nil {
+		return errors.NewValidationError(&errs)
+	}
+
+	if err := h.requireModerator(c); err != nil {
+		return err
+	}
+
+	limit := 20
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	var cursor *models.ReportCursor
+	if params.Cursor != nil {
+		cursor = &models.ReportCursor{}
+		if err := utils.DecodeCursor(*params.Cursor, cursor); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid cursor")
+		}
+	}
+	var status *models.ReportStatus
+	if params.Status != nil {
+		reportStatus := models.ReportStatus(*params.Status)
+		status = &reportStatus
+	}
+
+	reports, err := h.reportRepo.GetReports(
+		c.Request().Context(),
+		status,
+		cursor,
+		limit,
+	)
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to retrieve reports",
+		)
+	}
+	if reports == nil {
+		reports = []*models.Report{}
+	}
+
+	page := api.CursorPaginatedReports{
+		Items: utils.MapSlice(reports, mapModelReportToApi),
+	}
+	if len(reports) == limit {
+		last := reports[len(reports)-1]
+		nextCursor, err := utils.EncodeCursor(models.ReportCursor{
+			CreatedAt: last.CreatedAt,
+			Id:        last.ID,
+		})
+		if err != nil {
+			return echo.NewHTTPError(
+				http.StatusInternalServerError,
+				"Failed to encode cursor",
+			)
+		}
+		page.NextCursor = &nextCursor
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
+
+func (h *ReportHandler) PostModerationReportsReportIdClaim(
+	c echo.Context,
+	reportId string,
+) error {
+	if err := h.requireModerator(c); err != nil {
+		return err
+	}
+
+	if _, err := h.reportRepo.GetReportById(
+		c.Request().Context(),
+		reportId,
+	); err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Report not found")
+	}
+
+	report, err := h.reportRepo.ClaimReport(
+		c.Request().Context(),
+		reportId,
+		c.Get("userId").(string),
+	)
+	if stderrors.Is(err, repositories.ErrReportNotOpen) {
+		return echo.NewHTTPError(http.StatusConflict, "Report is not open")
+	}
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to claim report",
+		)
+	}
+
+	return c.JSON(http.StatusOK, mapModelReportToApi(report))
+}
+
+func (h *ReportHandler) PostModerationReportsReportIdResolve(
+	c echo.Context,
+	reportId string,
+) error {
+	var req api.ResolveReportRequest
+	if err := utils.BindRequest(c, &req); err != nil {
+		return err
+	}
+
+	if errs := schemas.ResolveReportRequestSchema.Validate(&req); errs != nil {
+		return errors.NewValidationError(&errs)
+	}
+
+	if err := h.requireModerator(c); err != nil {
+		return err
+	}
+
+	if _, err := h.reportRepo.GetReportById(
+		c.Request().Context(),
+		reportId,
+	); err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Report not found")
+	}
+
+	report, err := h.reportRepo.ResolveReport(
+		c.Request().Context(),
+		reportId,
+		c.Get("userId").(string),
+		models.ReportAction(req.Action),
+	)
+	if stderrors.Is(err, repositories.ErrReportNotClaimed) {
+		return echo.NewHTTPError(
+			http.StatusConflict,
+			"Report must be claimed by you before it is resolved",
+		)
+	}
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to resolve report",
+		)
+	}
+
+	return c.JSON(http.StatusOK, mapModelReportToApi(report))
+}
+
+func (h *ReportHandler) PostPostsPostIdReports(
+	c echo.Context,
+	postId string,
+) error {
+	var req api.CreateReportRequest
+	if err := utils.BindRequest(c, &req); err != nil {
+		return err
+	}
+
+	if errs := schemas.CreateReportRequestSchema.Validate(&req); errs != nil {
+		return errors.NewValidationError(&errs)
+	}
+
+	userId := c.Get("userId").(string)
+
+	post, err := h.postRepo.GetPostById(c.Request().Context(), userId, postId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Post not found")
+	}
+	if post.AuthorId == userId {
+		return echo.NewHTTPError(
+			http.StatusBadRequest,
+			"You cannot report your own post",
+		)
+	}
+
+	report, err := h.reportRepo.CreateReport(
+		c.Request().Context(),
+		models.ReportCreate{
+			Details:    req.Details,
+			PostId:     postId,
+			Reason:     models.ReportReason(req.Reason),
+			ReporterId: &userId,
+		},
+	)
+	if stderrors.Is(err, repositories.ErrReportExists) {
+		return echo.NewHTTPError(
+			http.StatusConflict,
+			"You already reported this post",
+		)
+	}
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to create report",
+		)
+	}
+
+	return c.JSON(http.StatusCreated, mapModelReportToApi(report))
+}
+
+// requireModerator fails unless the current user may work on the moderation
+// queue.
+func (h *ReportHandler) requireModerator(c echo.Context) error {
+	user, err := h.userRepo.GetUserById(
+		c.Request().Context(),
+		c.Get("userId").(string),
+	)
+	if err != nil || user == nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to retrieve user",
+		)
+	}
+	if !user.CanModerate() {
+		return echo.NewHTTPError(
+			http.StatusForbidden,
+			"Only moderators can access reports",
+		)
+	}
+
+	return nil
+}
+
+func mapModelReportToApi(report *models.Report) api.Report {
+	if report == nil {
+		return api.Report{}
+	}
+
+	var action *api.ReportAction
+	if report.Action != nil {
+		reportAction := api.ReportAction(*report.Action)
+		action = &reportAction
+	}
+
+	return api.Report{
+		Action:     action,
+		ClaimedAt:  report.ClaimedAt,
+		ClaimedBy:  report.ClaimedBy,
+		CreatedAt:  report.CreatedAt,
+		Details:    report.Details,
+		Id:         report.ID,
+		PostId:     report.PostId,
+		Reason:     api.ReportReason(report.Reason),
+		ReporterId: report.ReporterId,
+		ResolvedAt: report.ResolvedAt,
+		ResolvedBy: report.ResolvedBy,
+		Status:     api.ReportStatus(report.Status),
+	}
+}
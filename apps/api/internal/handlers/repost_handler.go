@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"apps/api/internal/models"
+	"apps/api/internal/repositories"
+	"apps/api/internal/services"
+)
+
+type RepostHandler struct {
+	mediaURLSigner *services.MediaURLSigner
+	postRepo       *repositories.PostRepo
+	repostRepo     *repositories.RepostRepo
+}
+
+func NewRepostHandler(
+	mediaURLSigner *services.MediaURLSigner,
+	postRepo *repositories.PostRepo,
+	repostRepo *repositories.RepostRepo,
+) *RepostHandler {
+	return &RepostHandler{
+		mediaURLSigner,
+		postRepo,
+		repostRepo,
+	}
+}
+
+func (h *RepostHandler) DeletePostsPostIdRepost(
+	c echo.Context,
+	postId string,
+) error {
+	userId := c.Get("userId").(string)
+
+	if _, err := h.postRepo.GetPostById(
+		c.Request().Context(),
+		userId,
+		postId,
+	); err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Post not found")
+	}
+
+	if err := h.repostRepo.RemoveRepost(
+		c.Request().Context(),
+		userId,
+		postId,
+	); err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to remove repost",
+		)
+	}
+
+	return h.currentPost(c, userId, postId)
+}
+
+func (h *RepostHandler) PutPostsPostIdRepost(
+	c echo.Context,
+	postId string,
+) error {
+	userId := c.Get("userId").(string)
+
+	post, err := h.postRepo.GetPostById(
+		c.Request().Context(),
+		userId,
+		postId,
+	)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Post not found")
+	}
+	if post.Visibility != models.PostVisibilityPublic {
+		return echo.NewHTTPError(
+			http.StatusBadRequest,
+			"Only public posts can be reposted",
+		)
+	}
+
+	if err := h.repostRepo.AddRepost(
+		c.Request().Context(),
+		userId,
+		postId,
+	); err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to add repost",
+		)
+	}
+
+	return h.currentPost(c, userId, postId)
+}
+
+// currentPost responds with the post as changed by the repost or its
+// removal.
+func (h *RepostHandler) currentPost(
+	c echo.Context,
+	userId string,
+	postId string,
+) error {
+	post, err := h.postRepo.GetPostById(
+		c.Request().Context(),
+		userId,
+		postId,
+	)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Post not found")
+	}
+
+	return c.JSON(http.StatusOK, mapModelPostToApi(post, h.mediaURLSigner))
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"apps/api/internal/api"
+	"apps/api/internal/errors"
+	"apps/api/internal/models"
+	"apps/api/internal/repositories"
+	"apps/api/internal/schemas"
+	"apps/api/internal/services"
+	"apps/api/internal/utils"
+)
+
+type TagHandler struct {
+	mediaURLSigner *services.MediaURLSigner
+	postRepo       *repositories.PostRepo
+	tagRepo        *repositories.TagRepo
+}
+
+func NewTagHandler(
+	mediaURLSigner *services.MediaURLSigner,
+	postRepo *repositories.PostRepo,
+	tagRepo *repositories.TagRepo,
+) *TagHandler {
+	return &TagHandler{
+		mediaURLSigner,
+		postRepo,
+		tagRepo,
+	}
+}
+
+func (h *TagHandler) GetTagsPopular(
+	c echo.Context,
+	params api.GetTagsPopularParams,
+) error {
+	if errs := schemas.GetTagsPopularParamsSchema.Validate(
+		&params,
+	); errs != nil {
+		return errors.NewValidationError(&errs)
+	}
+
+	days := 7
+	if params.Days != nil {
+		days = *params.Days
+	}
+	limit := 20
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	tags, err := h.tagRepo.GetPopularTags(
+		c.Request().Context(),
+		time.Now().AddDate(0, 0, -days),
+		limit,
+	)
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to retrieve popular tags",
+		)
+	}
+	if tags == nil {
+		tags = []*models.PopularTag{}
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		api.PopularTags{
+			Items: utils.MapSlice(tags, mapModelPopularTagToApi),
+		},
+	)
+}
+
+func (h *TagHandler) GetTagsTagPosts(
+	c echo.Context,
+	tag string,
+	params api.GetTagsTagPostsParams,
+) error {
+	tagParams := struct{ Tag string }{tag}
+	if errs := schemas.TagSchema.Validate(&tagParams); errs != nil {
+		return errors.NewValidationError(&errs)
+	}
+	if errs := schemas.GetTagsTagPostsParamsSchema.Validate(
+		&params,
+	); errs != nil {
+		return errors.NewValidationError(&errs)
+	}
+
+	limit := 20
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	var cursor *models.PostCursor
+	if params.Cursor != nil {
+		cursor = &models.PostCursor{}
+		if err := utils.DecodeCursor(*params.Cursor, cursor); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid cursor")
+		}
+	}
+
+	posts, err := h.postRepo.GetPostsByTag(
+		c.Request().Context(),
+		c.Get("userId").(string),
+		utils.NormalizeTag(tagParams.Tag),
+		cursor,
+		limit,
+	)
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to retrieve posts",
+		)
+	}
+
+	page, err := postsPage(posts, limit, h.mediaURLSigner)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
+
+func mapModelPopularTagToApi(tag *models.PopularTag) api.PopularTag {
+	if tag == nil {
+		return api.PopularTag{}
+	}
+	return api.PopularTag{
+		Name:       tag.Name,
+		PostsCount: tag.PostsCount,
+	}
+}
@@ -2,18 +2,25 @@ package handlers
 
 import (
 	"apps/api/internal/api"
+	"apps/api/internal/models"
 	"apps/api/internal/repositories"
+	"apps/api/internal/utils"
 	"net/http"
+	"strconv"
 
 	"github.com/labstack/echo/v4"
 )
 
 type UserHandler struct {
-	userRepo *repositories.UserRepo
+	followRepo *repositories.FollowRepo
+	userRepo   *repositories.UserRepo
 }
 
-func NewUserHandler(userRepo *repositories.UserRepo) *UserHandler {
-	return &UserHandler{userRepo: userRepo}
+func NewUserHandler(
+	followRepo *repositories.FollowRepo,
+	userRepo *repositories.UserRepo,
+) *UserHandler {
+	return &UserHandler{followRepo: followRepo, userRepo: userRepo}
 }
 
 func (h *UserHandler) GetUsersMe(
@@ -35,10 +42,62 @@ func (h *UserHandler) GetUsersMe(
 		)
 	}
 
+	if checkUserNotModified(c, user) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
 	return c.JSON(
 		http.StatusOK,
 		api.User{
-			Email: user.Email,
-			Id:    user.ID,
+			Email:          user.Email,
+			FollowersCount: user.FollowersCount,
+			FollowingCount: user.FollowingCount,
+			Handle:         user.Handle,
+			Id:             user.ID,
+			Role:           api.UserRole(user.Role),
 		})
 }
+
+func (h *UserHandler) GetUsersUserId(
+	c echo.Context,
+	userId string,
+) error {
+	user, err := h.userRepo.GetUserById(c.Request().Context(), userId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "User not found")
+	}
+
+	followed, err := h.followRepo.GetFollowedIds(
+		c.Request().Context(),
+		c.Get("userId").(string),
+		[]string{user.ID},
+	)
+	if err != nil {
+		return echo.NewHTTPError(
+			http.StatusInternalServerError,
+			"Failed to retrieve user",
+		)
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		mapModelUserToProfileApi(user, followed[user.ID]),
+	)
+}
+
+// checkUserNotModified sets the validators of the user on the response and
+// reports whether the request allows answering with 304 Not Modified. Follow
+// counts are updated along with updated_at, which is the Last-Modified
+// validator.
+func checkUserNotModified(c echo.Context, user *models.User) bool {
+	etag := utils.WeakETag(
+		user.ID,
+		user.Email,
+		user.Handle,
+		user.UpdatedAt.String(),
+		strconv.Itoa(user.FollowersCount),
+		strconv.Itoa(user.FollowingCount),
+		string(user.Role),
+	)
+	return utils.CheckNotModified(c, etag, user.UpdatedAt)
+}
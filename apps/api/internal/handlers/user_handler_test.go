@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"apps/api/internal/models"
+)
+
+func TestCheckUserNotModified(t *testing.T) {
+	user := &models.User{
+		ID:        "user",
+		Email:     "user@example.com",
+		UpdatedAt: time.Date(2025, 1, 2, 3, 4, 5, 600, time.UTC),
+	}
+	get := func(headers map[string]string) (int, http.Header) {
+		return conditionalGet(headers, func(c echo.Context) bool {
+			return checkUserNotModified(c, user)
+		})
+	}
+
+	code, header := get(nil)
+	require.Equal(t, http.StatusOK, code)
+	etag := header.Get("ETag")
+	lastModified := header.Get("Last-Modified")
+	assert.Equal(t, "Thu, 02 Jan 2025 03:04:05 GMT", lastModified)
+
+	code, _ = get(map[string]string{"If-None-Match": etag})
+	assert.Equal(t, http.StatusNotModified, code)
+	code, _ = get(map[string]string{"If-Modified-Since": lastModified})
+	assert.Equal(t, http.StatusNotModified, code)
+
+	t.Run("should be modified when followed", func(t *testing.T) {
+		user.FollowersCount++
+		user.UpdatedAt = user.UpdatedAt.Add(time.Second)
+
+		code, _ := get(map[string]string{"If-None-Match": etag})
+		assert.Equal(t, http.StatusOK, code)
+		code, _ = get(map[string]string{"If-Modified-Since": lastModified})
+		assert.Equal(t, http.StatusOK, code)
+	})
+}
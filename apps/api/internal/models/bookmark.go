@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+)
+
+type Bookmark struct {
+	UserId    string    `db:"user_id"    json:"userId"`
+	PostId    string    `db:"post_id"    json:"postId"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+}
+
+// BookmarkCursor is the keyset position of the last bookmark of a page.
+type BookmarkCursor struct {
+	CreatedAt time.Time `json:"c"`
+	PostId    string    `json:"p"`
+}
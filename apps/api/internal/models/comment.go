@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+)
+
+// Comment is a comment on a post. AuthorId is nil once the author deleted
+// their account.
+type Comment struct {
+	ID           string    `db:"id"            fieldtag:"pk" json:"id"`
+	PostId       string    `db:"post_id"                     json:"postId"`
+	ParentId     *string   `db:"parent_id"                   json:"parentId"`
+	AuthorId     *string   `db:"author_id"                   json:"authorId"`
+	Content      string    `db:"content"                     json:"content"`
+	RepliesCount int       `db:"replies_count"               json:"repliesCount"`
+	CreatedAt    time.Time `db:"created_at"                  json:"createdAt"`
+	UpdatedAt    time.Time `db:"updated_at"                  json:"updatedAt"`
+
+	// Mentions are the mentions of existing users in the content, in the
+	// order they appear.
+	Mentions []*Mention `db:"-" json:"mentions"`
+}
+
+type CommentCreate struct {
+	AuthorId string  `db:"author_id" json:"authorId"`
+	Content  string  `db:"content"   json:"content"`
+	ParentId *string `db:"parent_id" json:"parentId"`
+	PostId   string  `db:"post_id"   json:"postId"`
+}
+
+type CommentSort string
+
+const (
+	CommentSortNewest CommentSort = "newest"
+	CommentSortTop    CommentSort = "top"
+)
+
+type CommentListParams struct {
+	Cursor   *CommentCursor
+	Limit    int
+	ParentId *string
+	PostId   string
+	Sort     CommentSort
+	// ViewerId is the user reading the comments. Comments of users that
+	// blocked the viewer or were blocked by them are left out.
+	ViewerId string
+}
+
+// CommentCursor is the keyset position of the last comment of a page.
+// RepliesCount is only used when sorting by top.
+type CommentCursor struct {
+	CreatedAt    time.Time `json:"c"`
+	Id           string    `json:"i"`
+	RepliesCount int       `json:"r,omitempty"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+)
+
+type Follow struct {
+	FollowerId string    `db:"follower_id" json:"followerId"`
+	FolloweeId string    `db:"followee_id" json:"followeeId"`
+	CreatedAt  time.Time `db:"created_at"  json:"createdAt"`
+}
+
+// FollowCursor is the keyset position of the last follow of a page.
+type FollowCursor struct {
+	CreatedAt time.Time `json:"c"`
+	UserId    string    `json:"u"`
+}
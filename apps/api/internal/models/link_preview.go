@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+)
+
+// LinkPreview is the metadata of a page linked from posts, fetched in the
+// background and shared by all posts linking to the page.
+type LinkPreview struct {
+	Url            string            `db:"url"              fieldtag:"pk" json:"url"`
+	Status         LinkPreviewStatus `db:"status"                         json:"status"`
+	Title          *string           `db:"title"                          json:"title"`
+	Description    *string           `db:"description"                    json:"description"`
+	ImageUrl       *string           `db:"image_url"                      json:"imageUrl"`
+	SiteName       *string           `db:"site_name"                      json:"siteName"`
+	FetchStartedAt *time.Time        `db:"fetch_started_at"               json:"fetchStartedAt"`
+	FetchedAt      *time.Time        `db:"fetched_at"                     json:"fetchedAt"`
+	CreatedAt      time.Time         `db:"created_at"                     json:"createdAt"`
+}
+
+// LinkPreviewFetched is the metadata found on a page, fields the page does
+// not provide are nil.
+type LinkPreviewFetched struct {
+	Description *string `json:"description"`
+	ImageUrl    *string `json:"imageUrl"`
+	SiteName    *string `json:"siteName"`
+	Title       *string `json:"title"`
+}
+
+type LinkPreviewStatus string
+
+const (
+	LinkPreviewStatusPending LinkPreviewStatus = "pending"
+	LinkPreviewStatusReady   LinkPreviewStatus = "ready"
+	LinkPreviewStatusFailed  LinkPreviewStatus = "failed"
+)
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+)
+
+// Media is an uploaded file. It is orphaned until it is attached to a post.
+type Media struct {
+	ID                  string      `db:"id"                    fieldtag:"pk" json:"id"`
+	OwnerId             *string     `db:"owner_id"                            json:"ownerId"`
+	PostId              *string     `db:"post_id"                             json:"postId"`
+	Position            *int        `db:"position"                            json:"position"`
+	ContentType         string      `db:"content_type"                        json:"contentType"`
+	SizeBytes           int64       `db:"size_bytes"                          json:"sizeBytes"`
+	StorageKey          string      `db:"storage_key"                         json:"storageKey"`
+	CreatedAt           time.Time   `db:"created_at"                          json:"createdAt"`
+	Status              MediaStatus `db:"status"                              json:"status"`
+	ProcessingStartedAt *time.Time  `db:"processing_started_at"               json:"processingStartedAt"`
+	Width               *int        `db:"width"                               json:"width"`
+	Height              *int        `db:"height"                              json:"height"`
+	Blurhash            *string     `db:"blurhash"                            json:"blurhash"`
+
+	// Variants are stored in the media_variants table.
+	Variants []*MediaVariant `db:"-" json:"variants"`
+}
+
+type MediaCreate struct {
+	OwnerId     string `db:"owner_id"     json:"ownerId"`
+	ContentType string `db:"content_type" json:"contentType"`
+	SizeBytes   int64  `db:"size_bytes"   json:"sizeBytes"`
+	StorageKey  string `db:"storage_key"  json:"storageKey"`
+}
+
+// MediaProcessed is the outcome of processing an image. The original is
+// replaced by a copy without metadata of SizeBytes bytes.
+type MediaProcessed struct {
+	Blurhash  string          `json:"blurhash"`
+	Height    int             `json:"height"`
+	SizeBytes int64           `json:"sizeBytes"`
+	Variants  []*MediaVariant `json:"variants"`
+	Width     int             `json:"width"`
+}
+
+// MediaStatus tells how far the processing of a media got. Media that are
+// not images are ready without processing.
+type MediaStatus string
+
+const (
+	MediaStatusPending    MediaStatus = "pending"
+	MediaStatusProcessing MediaStatus = "processing"
+	MediaStatusReady      MediaStatus = "ready"
+	MediaStatusFailed     MediaStatus = "failed"
+)
+
+// MediaVariant is a resized copy of an image, e.g. its thumbnail.
+type MediaVariant struct {
+	MediaId     string `db:"media_id"     json:"mediaId"`
+	Name        string `db:"name"         json:"name"`
+	ContentType string `db:"content_type" json:"contentType"`
+	Width       int    `db:"width"        json:"width"`
+	Height      int    `db:"height"       json:"height"`
+	SizeBytes   int64  `db:"size_bytes"   json:"sizeBytes"`
+	StorageKey  string `db:"storage_key"  json:"storageKey"`
+}
@@ -0,0 +1,10 @@
+package models
+
+// Mention is an @handle mention of a user in the content of a post or
+// comment. Offset and Length count UTF-16 code units of the content.
+type Mention struct {
+	Handle string `json:"handle"`
+	Length int    `json:"length"`
+	Offset int    `json:"offset"`
+	UserId string `json:"userId"`
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"time"
+)
+
+// Notification is a group of the notifications of a user with the same type
+// and target, e.g. the reactions on one of their posts. ID, PostId,
+// CommentId and Payload are those of the latest notification of the group.
+type Notification struct {
+	ID   string           `json:"id"`
+	Type NotificationType `json:"type"`
+	// ActorIds are the users who caused the latest notifications of the
+	// group, most recent first and at most three.
+	ActorIds    []string            `json:"actorIds"`
+	ActorsCount int                 `json:"actorsCount"`
+	PostId      *string             `json:"postId"`
+	CommentId   *string             `json:"commentId"`
+	Payload     NotificationPayload `json:"payload"`
+	IsRead      bool                `json:"isRead"`
+	CreatedAt   time.Time           `json:"createdAt"`
+}
+
+type NotificationType string
+
+const (
+	// NotificationTypeFollow tells a user about a new follower.
+	NotificationTypeFollow NotificationType = "follow"
+	// NotificationTypeReaction tells the author about a reaction on their
+	// post, Payload.Emoji is set.
+	NotificationTypeReaction NotificationType = "reaction"
+	// NotificationTypeComment tells the author about a comment on their
+	// post.
+	NotificationTypeComment NotificationType = "comment"
+	// NotificationTypeMention tells a user they were mentioned in a post, or
+	// in a comment when CommentId is set.
+	NotificationTypeMention NotificationType = "mention"
+	// NotificationTypeModeration tells a reporter how their report was
+	// resolved, Payload.ReportId and Payload.Action are set.
+	NotificationTypeModeration NotificationType = "moderation"
+)
+
+// NotificationPayload holds the details of a notification that depend on
+// its type.
+type NotificationPayload struct {
+	Action   *ReportAction `json:"action,omitempty"`
+	Emoji    *string       `json:"emoji,omitempty"`
+	ReportId *string       `json:"reportId,omitempty"`
+}
+
+type NotificationCreate struct {
+	ActorId   *string
+	CommentId *string
+	Payload   NotificationPayload
+	PostId    *string
+	Type      NotificationType
+	UserId    string
+}
+
+type NotificationListParams struct {
+	Cursor *NotificationCursor
+	Limit  int
+	// UnreadOnly leaves out groups without unread notifications.
+	UnreadOnly bool
+	UserId     string
+}
+
+// NotificationCursor is the keyset position of the latest notification of
+// the last group of a page.
+type NotificationCursor struct {
+	CreatedAt time.Time `json:"c"`
+	Id        string    `json:"i"`
+}
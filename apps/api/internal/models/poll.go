@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+)
+
+type Poll struct {
+	PostId         string    `db:"post_id"         json:"postId"`
+	MultipleChoice bool      `db:"multiple_choice" json:"multipleChoice"`
+	ClosesAt       time.Time `db:"closes_at"       json:"closesAt"`
+	VotersCount    int       `db:"voters_count"    json:"votersCount"`
+
+	// Options are stored in the poll_options table.
+	Options []*PollOption `db:"-" json:"options"`
+
+	// MyVotes holds the ids of the options the user reading the poll voted
+	// for and is loaded separately from the polls table.
+	MyVotes []string `db:"-" json:"myVotes"`
+}
+
+// Closed reports whether the poll stopped accepting votes.
+func (p *Poll) Closed() bool {
+	return !p.ClosesAt.After(time.Now())
+}
+
+// ResultsVisible reports whether the vote counts can be shown to the user
+// reading the poll, which is once they voted or the poll closed.
+func (p *Poll) ResultsVisible() bool {
+	return len(p.MyVotes) > 0 || p.Closed()
+}
+
+type PollOption struct {
+	ID         string `db:"id"          fieldtag:"pk" json:"id"`
+	PostId     string `db:"post_id"                   json:"postId"`
+	Position   int    `db:"position"                  json:"position"`
+	Text       string `db:"text"                      json:"text"`
+	VotesCount int    `db:"votes_count"               json:"votesCount"`
+}
+
+type PollCreate struct {
+	ClosesAt       time.Time `json:"closesAt"`
+	MultipleChoice bool      `json:"multipleChoice"`
+	Options        []string  `json:"options"`
+}
@@ -5,22 +5,109 @@ import (
 )
 
 type Post struct {
-	ID        string    `db:"id"         fieldtag:"pk" json:"id"`
-	AuthorId  string    `db:"author_id"                json:"authorId"`
-	Content   string    `db:"content"                  json:"content"`
-	Title     string    `db:"title"                    json:"title"`
-	CreatedAt time.Time `db:"created_at"               json:"createdAt"`
-	UpdatedAt time.Time `db:"updated_at"               json:"updatedAt"`
+	ID             string         `db:"id"              fieldtag:"pk" json:"id"`
+	AuthorId       string         `db:"author_id"                     json:"authorId"`
+	Content        string         `db:"content"                       json:"content"`
+	ContentFormat  ContentFormat  `db:"content_format"                json:"contentFormat"`
+	ContentHtml    string         `db:"content_html"                  json:"contentHtml"`
+	Title          string         `db:"title"                         json:"title"`
+	CreatedAt      time.Time      `db:"created_at"                    json:"createdAt"`
+	UpdatedAt      time.Time      `db:"updated_at"                    json:"updatedAt"`
+	DeletedAt      *time.Time     `db:"deleted_at"                    json:"deletedAt"`
+	EditedAt       *time.Time     `db:"edited_at"                     json:"editedAt"`
+	Version        int            `db:"version"                       json:"version"`
+	CommentsCount  int            `db:"comments_count"                json:"commentsCount"`
+	ReactionCounts map[string]int `db:"reaction_counts"               json:"reactionCounts"`
+	Visibility     PostVisibility `db:"visibility"                    json:"visibility"`
+	PinnedPosition *int           `db:"pinned_position"               json:"pinnedPosition"`
+	RepostsCount   int            `db:"reposts_count"                 json:"repostsCount"`
+	QuotedPostId   *string        `db:"quoted_post_id"                json:"quotedPostId"`
+
+	// Tags are stored in the post_tags table.
+	Tags []string `db:"-" json:"tags"`
+	// Media are stored in the media table, in the order they are shown.
+	Media []*Media `db:"-" json:"media"`
+	// LinkPreviews are the ready previews of the links in the content, in
+	// the order they appear.
+	LinkPreviews []*LinkPreview `db:"-" json:"linkPreviews"`
+	// Mentions are the mentions of existing users in the content, in the
+	// order they appear.
+	Mentions []*Mention `db:"-" json:"mentions"`
+	// Poll is stored in the polls tables.
+	Poll *Poll `db:"-" json:"poll"`
+	// QuotedPost is nil when the quoted post cannot be read by the user
+	// reading the post, e.g. because it was deleted.
+	QuotedPost *Post `db:"-" json:"quotedPost"`
+	// Repost is set on feed items that reached the feed through a repost.
+	Repost *PostRepost `db:"-" json:"repost"`
+
+	// Fields below depend on the user reading the post and are loaded
+	// separately from the posts table.
+	BookmarkedAt *time.Time `db:"-" json:"bookmarkedAt"`
+	MyReactions  []string   `db:"-" json:"myReactions"`
+	RepostedAt   *time.Time `db:"-" json:"repostedAt"`
+}
+
+// PostRepost tells who shared a post to their followers and when.
+type PostRepost struct {
+	UserId    string    `json:"userId"`
+	CreatedAt time.Time `json:"createdAt"`
 }
 
 type PostCreate struct {
-	Content  string `db:"content"   json:"content"`
-	Title    string `db:"title"     json:"title"`
-	AuthorId string `db:"author_id" json:"authorId"`
+	Content       string         `db:"content"        json:"content"`
+	ContentFormat ContentFormat  `db:"content_format" json:"contentFormat"`
+	ContentHtml   string         `db:"content_html"   json:"contentHtml"`
+	Title         string         `db:"title"          json:"title"`
+	AuthorId      string         `db:"author_id"      json:"authorId"`
+	MediaIds      []string       `db:"-"              json:"mediaIds"`
+	Poll          *PollCreate    `db:"-"              json:"poll"`
+	QuotedPostId  *string        `db:"quoted_post_id" json:"quotedPostId"`
+	Tags          []string       `db:"-"              json:"tags"`
+	Visibility    PostVisibility `db:"visibility"     json:"visibility"`
 }
 
 type PostUpdate struct {
-	AuthorId *string `db:"author_id" json:"authorId"`
-	Content  *string `db:"content"   json:"content"`
-	Title    *string `db:"title"     json:"title"`
+	AuthorId      *string         `db:"author_id"      json:"authorId"`
+	Content       *string         `db:"content"        json:"content"`
+	ContentFormat *ContentFormat  `db:"content_format" json:"contentFormat"`
+	ContentHtml   *string         `db:"content_html"   json:"contentHtml"`
+	Title         *string         `db:"title"          json:"title"`
+	Tags          *[]string       `db:"-"              json:"tags"`
+	Visibility    *PostVisibility `db:"visibility"     json:"visibility"`
+}
+
+// ContentFormat tells how the content of a post is written. The content is
+// rendered to sanitized HTML, stored as ContentHtml, whenever it is saved.
+type ContentFormat string
+
+const (
+	ContentFormatPlain    ContentFormat = "plain"
+	ContentFormatMarkdown ContentFormat = "markdown"
+)
+
+// PostVisibility tells who can read a post. Unlisted posts can be read by
+// anyone who knows their id but never show up in lists.
+type PostVisibility string
+
+const (
+	PostVisibilityPublic    PostVisibility = "public"
+	PostVisibilityFollowers PostVisibility = "followers"
+	PostVisibilityPrivate   PostVisibility = "private"
+	PostVisibilityUnlisted  PostVisibility = "unlisted"
+)
+
+// UserPostCursor is the keyset position of the last post of a page of the
+// posts of a user, pinned posts first.
+type UserPostCursor struct {
+	PinnedPosition *int      `json:"p"`
+	CreatedAt      time.Time `json:"c"`
+	Id             string    `json:"i"`
+}
+
+// PostCursor is the keyset position of the last post of a page ordered by
+// creation time.
+type PostCursor struct {
+	CreatedAt time.Time `json:"c"`
+	Id        string    `json:"i"`
 }
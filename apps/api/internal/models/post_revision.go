@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+)
+
+type PostRevision struct {
+	ID            string        `db:"id"             fieldtag:"pk" json:"id"`
+	PostId        string        `db:"post_id"                      json:"postId"`
+	Revision      int           `db:"revision"                     json:"revision"`
+	Title         string        `db:"title"                        json:"title"`
+	Content       string        `db:"content"                      json:"content"`
+	ContentFormat ContentFormat `db:"content_format"               json:"contentFormat"`
+	ContentHtml   string        `db:"content_html"                 json:"contentHtml"`
+	EditorId      *string       `db:"editor_id"                    json:"editorId"`
+	CreatedAt     time.Time     `db:"created_at"                   json:"createdAt"`
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+)
+
+// PostView is a view of a post. ViewerKey identifies the user or device the
+// post was viewed on, each viewer is counted once per post and day.
+type PostView struct {
+	Day       time.Time
+	PostId    string
+	ViewerKey string
+}
+
+// PostViewsDay is the number of viewers of a post on a day.
+type PostViewsDay struct {
+	Day   time.Time `db:"day"   json:"day"`
+	Views int       `db:"views" json:"views"`
+}
@@ -0,0 +1,12 @@
+package models
+
+import (
+	"time"
+)
+
+type Reaction struct {
+	PostId    string    `db:"post_id"    json:"postId"`
+	UserId    string    `db:"user_id"    json:"userId"`
+	Emoji     string    `db:"emoji"      json:"emoji"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+)
+
+// Report asks moderators to review a post. Reports without a reporter are
+// raised by the content filter.
+type Report struct {
+	ID         string        `db:"id"          fieldtag:"pk" json:"id"`
+	PostId     string        `db:"post_id"                   json:"postId"`
+	ReporterId *string       `db:"reporter_id"               json:"reporterId"`
+	Reason     ReportReason  `db:"reason"                    json:"reason"`
+	Details    *string       `db:"details"                   json:"details"`
+	Status     ReportStatus  `db:"status"                    json:"status"`
+	ClaimedBy  *string       `db:"claimed_by"                json:"claimedBy"`
+	ClaimedAt  *time.Time    `db:"claimed_at"                json:"claimedAt"`
+	ResolvedBy *string       `db:"resolved_by"               json:"resolvedBy"`
+	ResolvedAt *time.Time    `db:"resolved_at"               json:"resolvedAt"`
+	Action     *ReportAction `db:"action"                    json:"action"`
+	CreatedAt  time.Time     `db:"created_at"                json:"createdAt"`
+}
+
+type ReportCreate struct {
+	Details    *string      `db:"details"     json:"details"`
+	PostId     string       `db:"post_id"     json:"postId"`
+	Reason     ReportReason `db:"reason"      json:"reason"`
+	ReporterId *string      `db:"reporter_id" json:"reporterId"`
+}
+
+type ReportReason string
+
+const (
+	ReportReasonSpam           ReportReason = "spam"
+	ReportReasonHarassment     ReportReason = "harassment"
+	ReportReasonHate           ReportReason = "hate"
+	ReportReasonViolence       ReportReason = "violence"
+	ReportReasonSexual         ReportReason = "sexual"
+	ReportReasonMisinformation ReportReason = "misinformation"
+	ReportReasonOther          ReportReason = "other"
+)
+
+type ReportStatus string
+
+const (
+	ReportStatusOpen     ReportStatus = "open"
+	ReportStatusClaimed  ReportStatus = "claimed"
+	ReportStatusResolved ReportStatus = "resolved"
+)
+
+type ReportAction string
+
+const (
+	ReportActionDismiss       ReportAction = "dismiss"
+	ReportActionHidePost      ReportAction = "hide_post"
+	ReportActionDeletePost    ReportAction = "delete_post"
+	ReportActionSuspendAuthor ReportAction = "suspend_author"
+)
+
+// ReportCursor is the keyset position of the last report of a page.
+type ReportCursor struct {
+	CreatedAt time.Time `json:"c"`
+	Id        string    `json:"i"`
+}
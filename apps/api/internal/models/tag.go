@@ -0,0 +1,6 @@
+package models
+
+type PopularTag struct {
+	Name       string `db:"name"        json:"name"`
+	PostsCount int    `db:"posts_count" json:"postsCount"`
+}
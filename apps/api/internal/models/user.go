@@ -5,14 +5,33 @@ import (
 )
 
 type User struct {
-	ID           string    `db:"id"            fieldtag:"pk" json:"id"`
-	Email        string    `db:"email"                       json:"email"`
-	PasswordHash string    `db:"password_hash"               json:"-"`
-	CreatedAt    time.Time `db:"created_at"                  json:"createdAt"`
-	UpdatedAt    time.Time `db:"updated_at"                  json:"updatedAt"`
+	ID             string     `db:"id"              fieldtag:"pk" json:"id"`
+	Email          string     `db:"email"                         json:"email"`
+	Handle         string     `db:"handle"                        json:"handle"`
+	PasswordHash   string     `db:"password_hash"                 json:"-"`
+	CreatedAt      time.Time  `db:"created_at"                    json:"createdAt"`
+	UpdatedAt      time.Time  `db:"updated_at"                    json:"updatedAt"`
+	FollowersCount int        `db:"followers_count"               json:"followersCount"`
+	FollowingCount int        `db:"following_count"               json:"followingCount"`
+	Role           UserRole   `db:"role"                          json:"role"`
+	SuspendedAt    *time.Time `db:"suspended_at"                  json:"suspendedAt"`
+}
+
+type UserRole string
+
+const (
+	UserRoleUser      UserRole = "user"
+	UserRoleModerator UserRole = "moderator"
+	UserRoleAdmin     UserRole = "admin"
+)
+
+// CanModerate reports whether the user may work on the moderation queue.
+func (u *User) CanModerate() bool {
+	return u.Role == UserRoleModerator || u.Role == UserRoleAdmin
 }
 
 type UserCreate struct {
 	Email        string `db:"email"         json:"email"`
+	Handle       string `db:"handle"        json:"handle"`
 	PasswordHash string `db:"password_hash" json:"-"`
 }
@@ -0,0 +1,134 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type BlockRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewBlockRepo(db *pgxpool.Pool) *BlockRepo {
+	return &BlockRepo{db: db}
+}
+
+// Block makes blockerId block blockedId and removes the follows between both
+// users in either direction. Blocking a user twice is a no-op.
+func (r *BlockRepo) Block(
+	ctx context.Context,
+	blockerId string,
+	blockedId string,
+) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockUsers(ctx, tx, blockerId, blockedId); err != nil {
+		return err
+	}
+
+	ib := sqlbuilder.PostgreSQL.NewInsertBuilder()
+	ib.InsertInto("user_blocks")
+	ib.Cols("blocker_id", "blocked_id")
+	ib.Values(blockerId, blockedId)
+	ib.SQL("ON CONFLICT DO NOTHING")
+	sql, args := ib.Build()
+
+	tag, err := tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("Failed to block user: %w", err)
+	}
+	if tag.RowsAffected() > 0 {
+		if err := touchUsers(ctx, tx, blockerId, blockedId); err != nil {
+			return err
+		}
+	}
+
+	rows, err := tx.Query(
+		ctx,
+		`DELETE FROM follows
+		WHERE (follower_id = $1 AND followee_id = $2)
+			OR (follower_id = $2 AND followee_id = $1)
+		RETURNING follower_id, followee_id`,
+		blockerId,
+		blockedId,
+	)
+	if err != nil {
+		return fmt.Errorf("Failed to remove follows: %w", err)
+	}
+
+	var removed [][2]string
+	for rows.Next() {
+		var follow [2]string
+		if err := rows.Scan(&follow[0], &follow[1]); err != nil {
+			rows.Close()
+			return fmt.Errorf("Failed to scan follow: %w", err)
+		}
+		removed = append(removed, follow)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("Failed to remove follows: %w", err)
+	}
+
+	for _, follow := range removed {
+		err := updateFollowCounts(ctx, tx, follow[0], follow[1], -1)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("Failed to block user: %w", err)
+	}
+
+	return nil
+}
+
+// Unblock removes the block of blockedId by blockerId. Follows removed by the
+// block are not restored.
+func (r *BlockRepo) Unblock(
+	ctx context.Context,
+	blockerId string,
+	blockedId string,
+) error {
+	db := sqlbuilder.PostgreSQL.NewDeleteBuilder()
+	db.DeleteFrom("user_blocks")
+	db.Where(
+		db.Equal("blocker_id", blockerId),
+		db.Equal("blocked_id", blockedId),
+	)
+	sql, args := db.Build()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockUsers(ctx, tx, blockerId, blockedId); err != nil {
+		return err
+	}
+
+	tag, err := tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("Failed to unblock user: %w", err)
+	}
+	if tag.RowsAffected() > 0 {
+		if err := touchUsers(ctx, tx, blockerId, blockedId); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("Failed to unblock user: %w", err)
+	}
+
+	return nil
+}
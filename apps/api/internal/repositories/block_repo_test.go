@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"apps/api/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockRepo_Block(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should hide users from each other", func(t *testing.T) {
+		cleanupTestDatabase()
+		blockRepo := NewBlockRepo(testDbService.GetDB())
+		followRepo := getTestFollowRepo()
+		postRepo := getTestPostRepo()
+		alice := createTestAuthor(t, "alice@example.com")
+		bob := createTestAuthor(t, "bob@example.com")
+		post := createTestPost(t, alice.ID, "hidden")
+		createTestComment(t, post, bob.ID, nil)
+
+		require.NoError(t, followRepo.Follow(ctx, alice.ID, bob.ID))
+		require.NoError(t, followRepo.Follow(ctx, bob.ID, alice.ID))
+		require.NoError(t, blockRepo.Block(ctx, alice.ID, bob.ID))
+		require.NoError(t, blockRepo.Block(ctx, alice.ID, bob.ID))
+
+		alice, err := getTestUserRepo().GetUserById(ctx, alice.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, alice.FollowersCount)
+		assert.Equal(t, 0, alice.FollowingCount)
+
+		err = followRepo.Follow(ctx, bob.ID, alice.ID)
+		assert.ErrorIs(t, err, ErrUserBlocked)
+
+		_, err = postRepo.GetPostById(ctx, bob.ID, post.ID)
+		assert.Error(t, err)
+		posts, total, err := postRepo.GetPosts(ctx, bob.ID, 10, 0)
+		require.NoError(t, err)
+		assert.Empty(t, posts)
+		assert.Equal(t, 0, total)
+
+		comments, err := getTestCommentRepo().GetComments(
+			ctx,
+			models.CommentListParams{
+				Limit:    10,
+				PostId:   post.ID,
+				ViewerId: alice.ID,
+			},
+		)
+		require.NoError(t, err)
+		assert.Empty(t, comments)
+
+		require.NoError(t, blockRepo.Unblock(ctx, alice.ID, bob.ID))
+		_, err = postRepo.GetPostById(ctx, bob.ID, post.ID)
+		require.NoError(t, err)
+		require.NoError(t, followRepo.Follow(ctx, bob.ID, alice.ID))
+	})
+}
+
+func TestMuteRepo_Mute(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should only leave muted posts out of lists", func(t *testing.T) {
+		cleanupTestDatabase()
+		muteRepo := NewMuteRepo(testDbService.GetDB())
+		postRepo := getTestPostRepo()
+		alice := createTestAuthor(t, "alice@example.com")
+		bob := createTestAuthor(t, "bob@example.com")
+		post := createTestPost(t, bob.ID, "muted")
+		require.NoError(t, getTestFollowRepo().Follow(ctx, alice.ID, bob.ID))
+
+		require.NoError(t, muteRepo.Mute(ctx, alice.ID, bob.ID))
+
+		feed, err := postRepo.GetFeed(ctx, alice.ID, nil, 10)
+		require.NoError(t, err)
+		assert.Empty(t, feed)
+		_, err = postRepo.GetPostById(ctx, alice.ID, post.ID)
+		require.NoError(t, err)
+
+		require.NoError(t, muteRepo.Unmute(ctx, alice.ID, bob.ID))
+		feed, err = postRepo.GetFeed(ctx, alice.ID, nil, 10)
+		require.NoError(t, err)
+		require.Len(t, feed, 1)
+		assert.Equal(t, post.ID, feed[0].ID)
+	})
+}
@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type BookmarkRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewBookmarkRepo(db *pgxpool.Pool) *BookmarkRepo {
+	return &BookmarkRepo{db: db}
+}
+
+// AddBookmark saves the post for the user. Bookmarking a post twice keeps
+// the original bookmark time.
+func (r *BookmarkRepo) AddBookmark(
+	ctx context.Context,
+	userId string,
+	postId string,
+) error {
+	ib := sqlbuilder.PostgreSQL.NewInsertBuilder()
+	ib.InsertInto("bookmarks")
+	ib.Cols("user_id", "post_id")
+	ib.Values(userId, postId)
+	ib.SQL("ON CONFLICT DO NOTHING")
+	sql, args := ib.Build()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("Failed to add bookmark: %w", err)
+	}
+	if tag.RowsAffected() > 0 {
+		if err := touchPosts(ctx, tx, "id = $1", postId); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("Failed to add bookmark: %w", err)
+	}
+
+	return nil
+}
+
+func (r *BookmarkRepo) RemoveBookmark(
+	ctx context.Context,
+	userId string,
+	postId string,
+) error {
+	db := sqlbuilder.PostgreSQL.NewDeleteBuilder()
+	db.DeleteFrom("bookmarks")
+	db.Where(db.Equal("user_id", userId), db.Equal("post_id", postId))
+	sql, args := db.Build()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("Failed to remove bookmark: %w", err)
+	}
+	if tag.RowsAffected() > 0 {
+		if err := touchPosts(ctx, tx, "id = $1", postId); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("Failed to remove bookmark: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"apps/api/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getTestBookmarkRepo() *BookmarkRepo {
+	return NewBookmarkRepo(testDbService.GetDB())
+}
+
+func TestBookmarkRepo_Bookmarks(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should paginate bookmarks with cursor", func(t *testing.T) {
+		cleanupTestDatabase()
+		bookmarkRepo := getTestBookmarkRepo()
+		postRepo := getTestPostRepo()
+		author := createTestAuthor(t, "bookmarks@example.com")
+
+		var bookmarked []string
+		for _, title := range []string{"a", "b", "c"} {
+			post := createTestPost(t, author.ID, title)
+			err := bookmarkRepo.AddBookmark(ctx, author.ID, post.ID)
+			require.NoError(t, err)
+			bookmarked = append(bookmarked, post.ID)
+		}
+		createTestPost(t, author.ID, "not bookmarked")
+
+		page, err := postRepo.GetBookmarkedPosts(ctx, author.ID, nil, 2)
+		require.NoError(t, err)
+		require.Len(t, page, 2)
+		assert.Equal(t, bookmarked[2], page[0].ID)
+		assert.Equal(t, bookmarked[1], page[1].ID)
+		assert.NotNil(t, page[0].BookmarkedAt)
+
+		page, err = postRepo.GetBookmarkedPosts(
+			ctx,
+			author.ID,
+			&models.BookmarkCursor{
+				CreatedAt: *page[1].BookmarkedAt,
+				PostId:    page[1].ID,
+			},
+			2,
+		)
+		require.NoError(t, err)
+		require.Len(t, page, 1)
+		assert.Equal(t, bookmarked[0], page[0].ID)
+
+		post, err := postRepo.GetPostById(ctx, author.ID, bookmarked[0])
+		require.NoError(t, err)
+		assert.NotNil(t, post.BookmarkedAt)
+
+		require.NoError(
+			t,
+			bookmarkRepo.RemoveBookmark(ctx, author.ID, bookmarked[0]),
+		)
+		post, err = postRepo.GetPostById(ctx, author.ID, bookmarked[0])
+		require.NoError(t, err)
+		assert.Nil(t, post.BookmarkedAt)
+	})
+
+	t.Run(
+		"should skip trashed posts and drop bookmarks on purge",
+		func(t *testing.T) {
+			cleanupTestDatabase()
+			bookmarkRepo := getTestBookmarkRepo()
+			postRepo := getTestPostRepo()
+			author := createTestAuthor(t, "purged@example.com")
+			post := createTestPost(t, author.ID, "purged")
+			err := bookmarkRepo.AddBookmark(ctx, author.ID, post.ID)
+			require.NoError(t, err)
+
+			require.NoError(t, postRepo.DeletePost(ctx, post.ID, nil))
+			page, err := postRepo.GetBookmarkedPosts(ctx, author.ID, nil, 10)
+			require.NoError(t, err)
+			assert.Empty(t, page)
+
+			_, err = postRepo.PurgeTrashedPosts(ctx, time.Now().Add(time.Hour))
+			require.NoError(t, err)
+
+			var count int
+			err = testDbService.GetDB().QueryRow(
+				ctx,
+				`SELECT COUNT(*) FROM bookmarks WHERE user_id = $1`,
+				author.ID,
+			).Scan(&count)
+			require.NoError(t, err)
+			assert.Equal(t, 0, count)
+		},
+	)
+}
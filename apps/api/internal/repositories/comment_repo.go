@@ -0,0 +1,348 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"apps/api/internal/models"
+)
+
+type CommentRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewCommentRepo(db *pgxpool.Pool) *CommentRepo {
+	return &CommentRepo{db: db}
+}
+
+var commentStruct = sqlbuilder.NewStruct(new(models.Comment)).
+	For(sqlbuilder.PostgreSQL)
+
+// CreateComment inserts the comment, increments the comment counter of the
+// post and the reply counter of the parent comment and notifies the author
+// of the post.
+func (r *CommentRepo) CreateComment(
+	ctx context.Context,
+	params models.CommentCreate,
+) (*models.Comment, error) {
+	ib := sqlbuilder.PostgreSQL.NewInsertBuilder()
+	ib.InsertInto("comments")
+	ib.Cols("author_id", "content", "parent_id", "post_id")
+	ib.Values(
+		params.AuthorId,
+		params.Content,
+		params.ParentId,
+		params.PostId,
+	)
+	ib.Returning(strings.Join(commentStruct.Columns(), ","))
+	sql, args := ib.Build()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var comment models.Comment
+	err = tx.QueryRow(ctx, sql, args...).Scan(commentStruct.Addr(&comment)...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create comment: %w", err)
+	}
+
+	var postAuthorId string
+	err = tx.QueryRow(
+		ctx,
+		`UPDATE posts SET
+			comments_count = comments_count + 1,
+			updated_at = NOW()
+		WHERE id = $1
+		RETURNING author_id`,
+		params.PostId,
+	).Scan(&postAuthorId)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to update comments count: %w", err)
+	}
+
+	if params.ParentId != nil {
+		_, err = tx.Exec(
+			ctx,
+			`UPDATE comments SET replies_count = replies_count + 1
+			WHERE id = $1`,
+			*params.ParentId,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to update replies count: %w", err)
+		}
+	}
+
+	err = insertNotification(ctx, tx, models.NotificationCreate{
+		ActorId:   &params.AuthorId,
+		CommentId: &comment.ID,
+		PostId:    &comment.PostId,
+		Type:      models.NotificationTypeComment,
+		UserId:    postAuthorId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	comment.Mentions, err = setMentions(
+		ctx,
+		tx,
+		params.AuthorId,
+		comment.PostId,
+		&comment.ID,
+		comment.Content,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("Failed to create comment: %w", err)
+	}
+
+	return &comment, nil
+}
+
+// DeleteComment removes the comment together with all of its replies and
+// keeps the post and parent counters in sync.
+func (r *CommentRepo) DeleteComment(
+	ctx context.Context,
+	comment *models.Comment,
+) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var deleted int
+	err = tx.QueryRow(
+		ctx,
+		`WITH RECURSIVE thread AS (
+			SELECT id FROM comments WHERE id = $1
+			UNION ALL
+			SELECT comments.id FROM comments
+			JOIN thread ON comments.parent_id = thread.id
+		)
+		SELECT COUNT(*) FROM thread`,
+		comment.ID,
+	).Scan(&deleted)
+	if err != nil {
+		return fmt.Errorf("Failed to count comment replies: %w", err)
+	}
+
+	db := sqlbuilder.PostgreSQL.NewDeleteBuilder()
+	db.DeleteFrom("comments")
+	db.Where(db.Equal("id", comment.ID))
+	sql, args := db.Build()
+
+	if _, err := tx.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("Failed to delete comment: %w", err)
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`UPDATE posts SET
+			comments_count = GREATEST(comments_count - $2, 0),
+			updated_at = NOW()
+		WHERE id = $1`,
+		comment.PostId,
+		deleted,
+	)
+	if err != nil {
+		return fmt.Errorf("Failed to update comments count: %w", err)
+	}
+
+	if comment.ParentId != nil {
+		_, err = tx.Exec(
+			ctx,
+			`UPDATE comments SET replies_count = GREATEST(replies_count - 1, 0)
+			WHERE id = $1`,
+			*comment.ParentId,
+		)
+		if err != nil {
+			return fmt.Errorf("Failed to update replies count: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("Failed to delete comment: %w", err)
+	}
+
+	return nil
+}
+
+// GetCommentById returns the comment of the post. When viewerId is set,
+// comments of users that blocked the viewer or were blocked by them are not
+// found.
+func (r *CommentRepo) GetCommentById(
+	ctx context.Context,
+	viewerId string,
+	postId string,
+	id string,
+) (*models.Comment, error) {
+	sb := commentStruct.SelectFrom("comments")
+	sb.Where(sb.Equal("id", id), sb.Equal("post_id", postId))
+	if viewerId != "" {
+		sb.Where(notBlockedCondition(sb.Var(viewerId), "author_id"))
+	}
+	sql, args := sb.Build()
+
+	var comment models.Comment
+	err := r.db.QueryRow(ctx, sql, args...).Scan(
+		commentStruct.Addr(&comment)...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get comment by id: %w", err)
+	}
+
+	err = r.loadMentions(ctx, []*models.Comment{&comment})
+	if err != nil {
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+// GetComments returns a page of comments of the post that reply to
+// params.ParentId, or top level comments when it is nil.
+func (r *CommentRepo) GetComments(
+	ctx context.Context,
+	params models.CommentListParams,
+) ([]*models.Comment, error) {
+	sb := commentStruct.SelectFrom("comments")
+	sb.Where(sb.Equal("post_id", params.PostId))
+	if params.ParentId != nil {
+		sb.Where(sb.Equal("parent_id", *params.ParentId))
+	} else {
+		sb.Where(sb.IsNull("parent_id"))
+	}
+	if params.ViewerId != "" {
+		sb.Where(notBlockedCondition(sb.Var(params.ViewerId), "author_id"))
+	}
+
+	switch params.Sort {
+	case models.CommentSortTop:
+		if params.Cursor != nil {
+			sb.Where(fmt.Sprintf(
+				"(replies_count, created_at, id) < (%s, %s, %s)",
+				sb.Var(params.Cursor.RepliesCount),
+				sb.Var(params.Cursor.CreatedAt),
+				sb.Var(params.Cursor.Id),
+			))
+		}
+		sb.OrderBy("replies_count DESC", "created_at DESC", "id DESC")
+	default:
+		if params.Cursor != nil {
+			sb.Where(fmt.Sprintf(
+				"(created_at, id) < (%s, %s)",
+				sb.Var(params.Cursor.CreatedAt),
+				sb.Var(params.Cursor.Id),
+			))
+		}
+		sb.OrderBy("created_at DESC", "id DESC")
+	}
+	sb.Limit(params.Limit)
+	sql, args := sb.Build()
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query comments: %w", err)
+	}
+	defer rows.Close()
+
+	var comments []*models.Comment
+	for rows.Next() {
+		var comment models.Comment
+		err := rows.Scan(commentStruct.Addr(&comment)...)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to scan comment: %w", err)
+		}
+		comments = append(comments, &comment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Failed to read comments: %w", err)
+	}
+
+	if err := r.loadMentions(ctx, comments); err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+// UpdateComment replaces the content of the comment. Comments whose author
+// deleted their account can not be updated.
+func (r *CommentRepo) UpdateComment(
+	ctx context.Context,
+	id string,
+	content string,
+) (*models.Comment, error) {
+	ub := sqlbuilder.PostgreSQL.NewUpdateBuilder()
+	ub.Update("comments")
+	ub.Set(ub.Assign("content", content), "updated_at = NOW()")
+	ub.Where(ub.Equal("id", id), ub.IsNotNull("author_id"))
+	ub.SQL("RETURNING " + strings.Join(commentStruct.Columns(), ","))
+	sql, args := ub.Build()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var comment models.Comment
+	err = tx.QueryRow(ctx, sql, args...).Scan(
+		commentStruct.Addr(&comment)...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to update comment: %w", err)
+	}
+
+	comment.Mentions, err = setMentions(
+		ctx,
+		tx,
+		*comment.AuthorId,
+		comment.PostId,
+		&comment.ID,
+		comment.Content,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("Failed to update comment: %w", err)
+	}
+
+	return &comment, nil
+}
+
+func (r *CommentRepo) loadMentions(
+	ctx context.Context,
+	comments []*models.Comment,
+) error {
+	ids := make([]string, 0, len(comments))
+	for _, comment := range comments {
+		ids = append(ids, comment.ID)
+	}
+
+	mentions, err := getCommentMentions(ctx, r.db, ids)
+	if err != nil {
+		return err
+	}
+	for _, comment := range comments {
+		comment.Mentions = mentions[comment.ID]
+		if comment.Mentions == nil {
+			comment.Mentions = []*models.Mention{}
+		}
+	}
+
+	return nil
+}
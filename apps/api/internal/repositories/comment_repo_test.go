@@ -0,0 +1,190 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"apps/api/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getTestCommentRepo() *CommentRepo {
+	return NewCommentRepo(testDbService.GetDB())
+}
+
+func createTestComment(
+	t *testing.T,
+	post *models.Post,
+	authorId string,
+	parentId *string,
+) *models.Comment {
+	comment, err := getTestCommentRepo().CreateComment(
+		context.Background(),
+		models.CommentCreate{
+			AuthorId: authorId,
+			Content:  "comment",
+			ParentId: parentId,
+			PostId:   post.ID,
+		},
+	)
+	require.NoError(t, err)
+	return comment
+}
+
+func TestCommentRepo_Counters(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should keep counters in sync with thread", func(t *testing.T) {
+		cleanupTestDatabase()
+		commentRepo := getTestCommentRepo()
+		postRepo := getTestPostRepo()
+		author := createTestAuthor(t, "comments@example.com")
+		post := createTestPost(t, author.ID, "discussed")
+
+		root := createTestComment(t, post, author.ID, nil)
+		reply := createTestComment(t, post, author.ID, &root.ID)
+		createTestComment(t, post, author.ID, &reply.ID)
+		createTestComment(t, post, author.ID, nil)
+
+		post, err := postRepo.GetPostById(ctx, "", post.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 4, post.CommentsCount)
+
+		root, err = commentRepo.GetCommentById(ctx, "", post.ID, root.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, root.RepliesCount)
+
+		require.NoError(t, commentRepo.DeleteComment(ctx, reply))
+
+		post, err = postRepo.GetPostById(ctx, "", post.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, post.CommentsCount)
+
+		root, err = commentRepo.GetCommentById(ctx, "", post.ID, root.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, root.RepliesCount)
+	})
+}
+
+func TestCommentRepo_GetComments(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should paginate newest comments with cursor", func(t *testing.T) {
+		cleanupTestDatabase()
+		commentRepo := getTestCommentRepo()
+		author := createTestAuthor(t, "pages@example.com")
+		post := createTestPost(t, author.ID, "paged")
+
+		var created []string
+		for range 5 {
+			comment := createTestComment(t, post, author.ID, nil)
+			created = append(created, comment.ID)
+		}
+
+		var seen []string
+		params := models.CommentListParams{
+			Limit:  2,
+			PostId: post.ID,
+			Sort:   models.CommentSortNewest,
+		}
+		for {
+			comments, err := commentRepo.GetComments(ctx, params)
+			require.NoError(t, err)
+			for _, comment := range comments {
+				seen = append(seen, comment.ID)
+			}
+			if len(comments) < params.Limit {
+				break
+			}
+			last := comments[len(comments)-1]
+			params.Cursor = &models.CommentCursor{
+				CreatedAt: last.CreatedAt,
+				Id:        last.ID,
+			}
+		}
+
+		require.Len(t, seen, len(created))
+		for i, id := range seen {
+			assert.Equal(t, created[len(created)-1-i], id, fmt.Sprint(i))
+		}
+	})
+
+	t.Run("should order top comments by replies", func(t *testing.T) {
+		cleanupTestDatabase()
+		commentRepo := getTestCommentRepo()
+		author := createTestAuthor(t, "top@example.com")
+		post := createTestPost(t, author.ID, "top")
+
+		quiet := createTestComment(t, post, author.ID, nil)
+		popular := createTestComment(t, post, author.ID, nil)
+		createTestComment(t, post, author.ID, &popular.ID)
+		createTestComment(t, post, author.ID, &popular.ID)
+
+		comments, err := commentRepo.GetComments(ctx, models.CommentListParams{
+			Limit:  10,
+			PostId: post.ID,
+			Sort:   models.CommentSortTop,
+		})
+		require.NoError(t, err)
+		require.Len(t, comments, 2)
+		assert.Equal(t, popular.ID, comments[0].ID)
+		assert.Equal(t, quiet.ID, comments[1].ID)
+
+		replies, err := commentRepo.GetComments(ctx, models.CommentListParams{
+			Limit:    10,
+			ParentId: &popular.ID,
+			PostId:   post.ID,
+			Sort:     models.CommentSortNewest,
+		})
+		require.NoError(t, err)
+		assert.Len(t, replies, 2)
+	})
+
+	t.Run("should keep comments of deleted users", func(t *testing.T) {
+		cleanupTestDatabase()
+		commentRepo := getTestCommentRepo()
+		author := createTestAuthor(t, "author@example.com")
+		commenter := createTestAuthor(t, "commenter@example.com")
+		post := createTestPost(t, author.ID, "orphaned")
+		comment := createTestComment(t, post, commenter.ID, nil)
+
+		_, err := testDbService.GetDB().Exec(
+			ctx,
+			"DELETE FROM users WHERE id = $1",
+			commenter.ID,
+		)
+		require.NoError(t, err)
+
+		fetched, err := commentRepo.GetCommentById(ctx, "", post.ID, comment.ID)
+		require.NoError(t, err)
+		assert.Nil(t, fetched.AuthorId)
+
+		comments, err := commentRepo.GetComments(ctx, models.CommentListParams{
+			Limit:    10,
+			PostId:   post.ID,
+			ViewerId: author.ID,
+		})
+		require.NoError(t, err)
+		assert.Len(t, comments, 1)
+	})
+
+	t.Run("should hide comments of blocked users", func(t *testing.T) {
+		cleanupTestDatabase()
+		commentRepo := getTestCommentRepo()
+		author := createTestAuthor(t, "author@example.com")
+		blocked := createTestAuthor(t, "blocked@example.com")
+		post := createTestPost(t, author.ID, "blocked")
+		comment := createTestComment(t, post, blocked.ID, nil)
+
+		blockRepo := NewBlockRepo(testDbService.GetDB())
+		require.NoError(t, blockRepo.Block(ctx, author.ID, blocked.ID))
+
+		_, err := commentRepo.GetCommentById(ctx, author.ID, post.ID, comment.ID)
+		assert.Error(t, err)
+		_, err = commentRepo.GetCommentById(ctx, "", post.ID, comment.ID)
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,31 @@
+package repositories
+
+import "fmt"
+
+// notBlockedCondition is an SQL condition that holds when neither of the
+// users blocked the other. Both arguments are SQL expressions, e.g. a
+// placeholder and a column.
+func notBlockedCondition(viewerId string, userId string) string {
+	return fmt.Sprintf(
+		`NOT EXISTS (
+			SELECT 1 FROM user_blocks
+			WHERE (blocker_id = %[1]s AND blocked_id = %[2]s)
+				OR (blocker_id = %[2]s AND blocked_id = %[1]s)
+		)`,
+		viewerId,
+		userId,
+	)
+}
+
+// notMutedCondition is an SQL condition that holds when the viewer did not
+// mute the user. Both arguments are SQL expressions.
+func notMutedCondition(viewerId string, userId string) string {
+	return fmt.Sprintf(
+		`NOT EXISTS (
+			SELECT 1 FROM user_mutes
+			WHERE muter_id = %s AND muted_id = %s
+		)`,
+		viewerId,
+		userId,
+	)
+}
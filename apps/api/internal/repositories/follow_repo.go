@@ -0,0 +1,328 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"apps/api/internal/models"
+)
+
+type FollowRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewFollowRepo(db *pgxpool.Pool) *FollowRepo {
+	return &FollowRepo{db: db}
+}
+
+var ErrUserBlocked = errors.New("User blocked")
+
+var followStruct = sqlbuilder.NewStruct(new(models.Follow)).
+	For(sqlbuilder.PostgreSQL)
+
+// Follow makes followerId follow followeeId. Following a user twice is a
+// no-op. The follower and following counters of both users are only changed
+// when a follow was actually inserted, which is also when the followee is
+// notified. ErrUserBlocked is returned when either of the users blocked the
+// other.
+func (r *FollowRepo) Follow(
+	ctx context.Context,
+	followerId string,
+	followeeId string,
+) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockUsers(ctx, tx, followerId, followeeId); err != nil {
+		return err
+	}
+
+	var blocked bool
+	err = tx.QueryRow(
+		ctx,
+		fmt.Sprintf("SELECT NOT %s", notBlockedCondition("$1", "$2")),
+		followerId,
+		followeeId,
+	).Scan(&blocked)
+	if err != nil {
+		return fmt.Errorf("Failed to check blocks: %w", err)
+	}
+	if blocked {
+		return ErrUserBlocked
+	}
+
+	ib := sqlbuilder.PostgreSQL.NewInsertBuilder()
+	ib.InsertInto("follows")
+	ib.Cols("follower_id", "followee_id")
+	ib.Values(followerId, followeeId)
+	ib.SQL("ON CONFLICT DO NOTHING")
+	sql, args := ib.Build()
+
+	tag, err := tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("Failed to follow user: %w", err)
+	}
+
+	if tag.RowsAffected() > 0 {
+		err = updateFollowCounts(ctx, tx, followerId, followeeId, 1)
+		if err != nil {
+			return err
+		}
+		err = insertNotification(ctx, tx, models.NotificationCreate{
+			ActorId: &followerId,
+			Type:    models.NotificationTypeFollow,
+			UserId:  followeeId,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("Failed to follow user: %w", err)
+	}
+
+	return nil
+}
+
+// GetFollowers returns a page of the follows of userId, most recent first.
+// Followers that blocked viewerId or were blocked by them are left out.
+func (r *FollowRepo) GetFollowers(
+	ctx context.Context,
+	viewerId string,
+	userId string,
+	cursor *models.FollowCursor,
+	limit int,
+) ([]*models.Follow, error) {
+	return r.getFollows(
+		ctx,
+		"followee_id",
+		"follower_id",
+		viewerId,
+		userId,
+		cursor,
+		limit,
+	)
+}
+
+// GetFollowing returns a page of the follows made by userId, most recent
+// first. Followed users that blocked viewerId or were blocked by them are
+// left out.
+func (r *FollowRepo) GetFollowing(
+	ctx context.Context,
+	viewerId string,
+	userId string,
+	cursor *models.FollowCursor,
+	limit int,
+) ([]*models.Follow, error) {
+	return r.getFollows(
+		ctx,
+		"follower_id",
+		"followee_id",
+		viewerId,
+		userId,
+		cursor,
+		limit,
+	)
+}
+
+// GetFollowedIds returns which of the given users followerId follows.
+func (r *FollowRepo) GetFollowedIds(
+	ctx context.Context,
+	followerId string,
+	userIds []string,
+) (map[string]bool, error) {
+	followed := make(map[string]bool, len(userIds))
+	if len(userIds) == 0 {
+		return followed, nil
+	}
+
+	rows, err := r.db.Query(
+		ctx,
+		`SELECT followee_id FROM follows
+		WHERE follower_id = $1 AND followee_id = ANY($2::uuid[])`,
+		followerId,
+		userIds,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query follows: %w", err)
+	}
+
+	followeeIds, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read follows: %w", err)
+	}
+	for _, followeeId := range followeeIds {
+		followed[followeeId] = true
+	}
+
+	return followed, nil
+}
+
+func (r *FollowRepo) Unfollow(
+	ctx context.Context,
+	followerId string,
+	followeeId string,
+) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	db := sqlbuilder.PostgreSQL.NewDeleteBuilder()
+	db.DeleteFrom("follows")
+	db.Where(
+		db.Equal("follower_id", followerId),
+		db.Equal("followee_id", followeeId),
+	)
+	sql, args := db.Build()
+
+	tag, err := tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("Failed to unfollow user: %w", err)
+	}
+
+	if tag.RowsAffected() > 0 {
+		err = updateFollowCounts(ctx, tx, followerId, followeeId, -1)
+		if err != nil {
+			return err
+		}
+		err = deleteNotification(ctx, tx, models.NotificationCreate{
+			ActorId: &followerId,
+			Type:    models.NotificationTypeFollow,
+			UserId:  followeeId,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("Failed to unfollow user: %w", err)
+	}
+
+	return nil
+}
+
+// getFollows lists the follows where column equals userId, keyed by the
+// user in the other column.
+func (r *FollowRepo) getFollows(
+	ctx context.Context,
+	column string,
+	otherColumn string,
+	viewerId string,
+	userId string,
+	cursor *models.FollowCursor,
+	limit int,
+) ([]*models.Follow, error) {
+	sb := followStruct.SelectFrom("follows")
+	sb.Where(sb.Equal(column, userId))
+	if viewerId != "" {
+		sb.Where(notBlockedCondition(sb.Var(viewerId), otherColumn))
+	}
+	if cursor != nil {
+		sb.Where(fmt.Sprintf(
+			"(created_at, %s) < (%s, %s)",
+			otherColumn,
+			sb.Var(cursor.CreatedAt),
+			sb.Var(cursor.UserId),
+		))
+	}
+	sb.OrderBy("created_at DESC", otherColumn+" DESC")
+	sb.Limit(limit)
+	sql, args := sb.Build()
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query follows: %w", err)
+	}
+	defer rows.Close()
+
+	var follows []*models.Follow
+	for rows.Next() {
+		var follow models.Follow
+		err := rows.Scan(followStruct.Addr(&follow)...)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to scan follow: %w", err)
+		}
+		follows = append(follows, &follow)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Failed to read follows: %w", err)
+	}
+
+	return follows, nil
+}
+
+// lockUsers locks the rows of both users in order of their ids. Follows and
+// blocks between the same users take this lock first, so that a follow can
+// never be inserted next to a block.
+func lockUsers(
+	ctx context.Context,
+	tx pgx.Tx,
+	userId string,
+	otherId string,
+) error {
+	_, err := tx.Exec(
+		ctx,
+		`SELECT id FROM users
+		WHERE id IN ($1, $2)
+		ORDER BY id
+		FOR NO KEY UPDATE`,
+		userId,
+		otherId,
+	)
+	if err != nil {
+		return fmt.Errorf("Failed to lock users: %w", err)
+	}
+
+	return nil
+}
+
+func updateFollowCounts(
+	ctx context.Context,
+	tx pgx.Tx,
+	followerId string,
+	followeeId string,
+	delta int,
+) error {
+	// Both rows are locked in order of their ids, so that concurrent follows
+	// between the same users cannot deadlock. NO KEY UPDATE does not conflict
+	// with the key share locks taken by the foreign keys of follows.
+	_, err := tx.Exec(
+		ctx,
+		`UPDATE users SET
+			following_count = GREATEST(
+				following_count + CASE WHEN id = $1 THEN $3 ELSE 0 END,
+				0
+			),
+			followers_count = GREATEST(
+				followers_count + CASE WHEN id = $2 THEN $3 ELSE 0 END,
+				0
+			),
+			updated_at = NOW()
+		WHERE id IN (
+			SELECT id FROM users
+			WHERE id IN ($1, $2)
+			ORDER BY id
+			FOR NO KEY UPDATE
+		)`,
+		followerId,
+		followeeId,
+		delta,
+	)
+	if err != nil {
+		return fmt.Errorf("Failed to update follow counts: %w", err)
+	}
+
+	return nil
+}
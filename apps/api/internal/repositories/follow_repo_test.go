@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"apps/api/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getTestFollowRepo() *FollowRepo {
+	return NewFollowRepo(testDbService.GetDB())
+}
+
+func TestFollowRepo_Follow(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should keep follow counts in sync", func(t *testing.T) {
+		cleanupTestDatabase()
+		followRepo := getTestFollowRepo()
+		userRepo := getTestUserRepo()
+		alice := createTestAuthor(t, "alice@example.com")
+		bob := createTestAuthor(t, "bob@example.com")
+
+		require.NoError(t, followRepo.Follow(ctx, alice.ID, bob.ID))
+		require.NoError(t, followRepo.Follow(ctx, alice.ID, bob.ID))
+		require.NoError(t, followRepo.Follow(ctx, bob.ID, alice.ID))
+
+		alice, err := userRepo.GetUserById(ctx, alice.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, alice.FollowersCount)
+		assert.Equal(t, 1, alice.FollowingCount)
+
+		require.NoError(t, followRepo.Unfollow(ctx, alice.ID, bob.ID))
+		require.NoError(t, followRepo.Unfollow(ctx, alice.ID, bob.ID))
+
+		bob, err = userRepo.GetUserById(ctx, bob.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, bob.FollowersCount)
+		assert.Equal(t, 1, bob.FollowingCount)
+
+		followed, err := followRepo.GetFollowedIds(
+			ctx,
+			bob.ID,
+			[]string{alice.ID},
+		)
+		require.NoError(t, err)
+		assert.True(t, followed[alice.ID])
+	})
+
+	t.Run("should reject following yourself", func(t *testing.T) {
+		cleanupTestDatabase()
+		alice := createTestAuthor(t, "self@example.com")
+
+		err := getTestFollowRepo().Follow(ctx, alice.ID, alice.ID)
+		assert.Error(t, err)
+	})
+
+	t.Run(
+		"should keep counts consistent under concurrent follows",
+		func(t *testing.T) {
+			cleanupTestDatabase()
+			followRepo := getTestFollowRepo()
+			star := createTestAuthor(t, "star@example.com")
+
+			var wg sync.WaitGroup
+			for i := range 10 {
+				fan := createTestAuthor(t, fmt.Sprintf("fan%d@example.com", i))
+				for range 2 {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						err := followRepo.Follow(ctx, fan.ID, star.ID)
+						assert.NoError(t, err)
+					}()
+				}
+			}
+			wg.Wait()
+
+			star, err := getTestUserRepo().GetUserById(ctx, star.ID)
+			require.NoError(t, err)
+			assert.Equal(t, 10, star.FollowersCount)
+		},
+	)
+}
+
+func TestFollowRepo_GetFollowers(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should paginate followers with cursor", func(t *testing.T) {
+		cleanupTestDatabase()
+		followRepo := getTestFollowRepo()
+		star := createTestAuthor(t, "paged-star@example.com")
+
+		var fans []string
+		for i := range 3 {
+			fan := createTestAuthor(t, fmt.Sprintf("paged%d@example.com", i))
+			require.NoError(t, followRepo.Follow(ctx, fan.ID, star.ID))
+			fans = append(fans, fan.ID)
+		}
+
+		page, err := followRepo.GetFollowers(ctx, "", star.ID, nil, 2)
+		require.NoError(t, err)
+		require.Len(t, page, 2)
+		assert.Equal(t, fans[2], page[0].FollowerId)
+		assert.Equal(t, fans[1], page[1].FollowerId)
+
+		page, err = followRepo.GetFollowers(
+			ctx,
+			"",
+			star.ID,
+			&models.FollowCursor{
+				CreatedAt: page[1].CreatedAt,
+				UserId:    page[1].FollowerId,
+			},
+			2,
+		)
+		require.NoError(t, err)
+		require.Len(t, page, 1)
+		assert.Equal(t, fans[0], page[0].FollowerId)
+
+		following, err := followRepo.GetFollowing(ctx, "", fans[0], nil, 10)
+		require.NoError(t, err)
+		require.Len(t, following, 1)
+		assert.Equal(t, star.ID, following[0].FolloweeId)
+	})
+}
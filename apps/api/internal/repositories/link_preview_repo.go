@@ -0,0 +1,266 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"apps/api/internal/models"
+	"apps/api/internal/utils"
+)
+
+// maxPostLinks is the number of links of a post that get a preview.
+const maxPostLinks = 3
+
+var ErrLinkPreviewNotDue = errors.New("Link preview is not due for fetching")
+
+type LinkPreviewRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewLinkPreviewRepo(db *pgxpool.Pool) *LinkPreviewRepo {
+	return &LinkPreviewRepo{db: db}
+}
+
+var linkPreviewStruct = sqlbuilder.NewStruct(new(models.LinkPreview)).
+	For(sqlbuilder.PostgreSQL)
+
+func (r *LinkPreviewRepo) GetLinkPreview(
+	ctx context.Context,
+	url string,
+) (*models.LinkPreview, error) {
+	sb := linkPreviewStruct.SelectFrom("link_previews")
+	sb.Where(sb.Equal("url", url))
+	sql, args := sb.Build()
+
+	var preview models.LinkPreview
+	err := r.db.QueryRow(ctx, sql, args...).Scan(
+		linkPreviewStruct.Addr(&preview)...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get link preview: %w", err)
+	}
+
+	return &preview, nil
+}
+
+// GetUnfetchedLinkPreviewUrls returns up to limit URLs that were never
+// fetched, skipping those being fetched since staleBefore, oldest first.
+func (r *LinkPreviewRepo) GetUnfetchedLinkPreviewUrls(
+	ctx context.Context,
+	staleBefore time.Time,
+	limit int,
+) ([]string, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select("url")
+	sb.From("link_previews")
+	sb.Where(
+		sb.IsNull("fetched_at"),
+		sb.Or(
+			sb.IsNull("fetch_started_at"),
+			sb.LessThan("fetch_started_at", staleBefore),
+		),
+	)
+	sb.OrderBy("created_at")
+	sb.Limit(limit)
+	sql, args := sb.Build()
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query unfetched links: %w", err)
+	}
+	urls, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read unfetched links: %w", err)
+	}
+
+	return urls, nil
+}
+
+// ClaimLinkPreviewFetch marks the link preview as being fetched, so that no
+// other worker fetches it meanwhile. Previews are due when they were never
+// fetched or fetched before refreshBefore, and no fetch started since
+// staleBefore. ErrLinkPreviewNotDue is returned otherwise.
+func (r *LinkPreviewRepo) ClaimLinkPreviewFetch(
+	ctx context.Context,
+	url string,
+	refreshBefore time.Time,
+	staleBefore time.Time,
+) error {
+	ub := sqlbuilder.PostgreSQL.NewUpdateBuilder()
+	ub.Update("link_previews")
+	ub.Set("fetch_started_at = NOW()")
+	ub.Where(
+		ub.Equal("url", url),
+		ub.Or(
+			ub.IsNull("fetched_at"),
+			ub.LessThan("fetched_at", refreshBefore),
+		),
+		ub.Or(
+			ub.IsNull("fetch_started_at"),
+			ub.LessThan("fetch_started_at", staleBefore),
+		),
+	)
+	sql, args := ub.Build()
+
+	tag, err := r.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("Failed to claim link preview: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf(
+			"Failed to claim link preview: %w",
+			ErrLinkPreviewNotDue,
+		)
+	}
+
+	return nil
+}
+
+// CompleteLinkPreviewFetch saves the metadata fetched for the URL, or marks
+// the preview as failed when fetched is nil. Failed previews are fetched
+// again once they are due for a refresh.
+func (r *LinkPreviewRepo) CompleteLinkPreviewFetch(
+	ctx context.Context,
+	url string,
+	fetched *models.LinkPreviewFetched,
+) error {
+	ub := sqlbuilder.PostgreSQL.NewUpdateBuilder()
+	ub.Update("link_previews")
+	ub.Set("fetch_started_at = NULL", "fetched_at = NOW()")
+	if fetched != nil {
+		ub.SetMore(
+			ub.Assign("status", models.LinkPreviewStatusReady),
+			ub.Assign("description", fetched.Description),
+			ub.Assign("image_url", fetched.ImageUrl),
+			ub.Assign("site_name", fetched.SiteName),
+			ub.Assign("title", fetched.Title),
+		)
+	} else {
+		ub.SetMore(ub.Assign("status", models.LinkPreviewStatusFailed))
+	}
+	ub.Where(ub.Equal("url", url))
+	sql, args := ub.Build()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("Failed to complete link preview: %w", err)
+	}
+	err = touchPosts(
+		ctx,
+		tx,
+		"id IN (SELECT post_id FROM post_links WHERE url = $1)",
+		url,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("Failed to complete link preview: %w", err)
+	}
+
+	return nil
+}
+
+// setPostLinks records the first links of the post content, adding the ones
+// never seen before to the link previews to fetch.
+func setPostLinks(ctx context.Context, tx pgx.Tx, post *models.Post) error {
+	links := utils.ExtractLinks(post.Content)
+	if len(links) > maxPostLinks {
+		links = links[:maxPostLinks]
+	}
+
+	_, err := tx.Exec(ctx, `DELETE FROM post_links WHERE post_id = $1`, post.ID)
+	if err != nil {
+		return fmt.Errorf("Failed to clear post links: %w", err)
+	}
+	if len(links) == 0 {
+		return nil
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`INSERT INTO link_previews (url)
+		SELECT unnest($1::text[])
+		ON CONFLICT (url) DO NOTHING`,
+		links,
+	)
+	if err != nil {
+		return fmt.Errorf("Failed to add link previews: %w", err)
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`INSERT INTO post_links (post_id, position, url)
+		SELECT $1, position, url
+		FROM unnest($2::text[]) WITH ORDINALITY AS t(url, position)`,
+		post.ID,
+		links,
+	)
+	if err != nil {
+		return fmt.Errorf("Failed to set post links: %w", err)
+	}
+
+	return nil
+}
+
+// getPostLinkPreviews returns the ready link previews of the posts by post
+// id, in the order the links appear in the posts.
+func getPostLinkPreviews(
+	ctx context.Context,
+	db querier,
+	postIds []string,
+) (map[string][]*models.LinkPreview, error) {
+	previews := map[string][]*models.LinkPreview{}
+	if len(postIds) == 0 {
+		return previews, nil
+	}
+	ids := make([]any, len(postIds))
+	for i, id := range postIds {
+		ids[i] = id
+	}
+
+	sb := linkPreviewStruct.SelectFrom("link_previews")
+	sb.SelectMore("post_links.post_id")
+	sb.Join("post_links", "post_links.url = link_previews.url")
+	sb.Where(
+		sb.In("post_links.post_id", ids...),
+		sb.Equal("link_previews.status", models.LinkPreviewStatusReady),
+	)
+	sb.OrderBy("post_links.post_id", "post_links.position")
+	sql, args := sb.Build()
+
+	rows, err := db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query link previews: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var preview models.LinkPreview
+		var postId string
+		err := rows.Scan(
+			append(linkPreviewStruct.Addr(&preview), &postId)...,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to scan link preview: %w", err)
+		}
+		previews[postId] = append(previews[postId], &preview)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Failed to read link previews: %w", err)
+	}
+
+	return previews, nil
+}
@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"apps/api/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkPreviewRepo(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should record the first links of posts", func(t *testing.T) {
+		cleanupTestDatabase()
+		linkPreviewRepo := NewLinkPreviewRepo(testDbService.GetDB())
+		author := createTestAuthor(t, "author@example.com")
+
+		post, err := getTestPostRepo().CreatePost(ctx, models.PostCreate{
+			AuthorId: author.ID,
+			Content: "https://a.example.com https://b.example.com " +
+				"https://c.example.com https://d.example.com",
+			Title: "links",
+		})
+		require.NoError(t, err)
+		assert.Empty(t, post.LinkPreviews)
+
+		urls, err := linkPreviewRepo.GetUnfetchedLinkPreviewUrls(
+			ctx,
+			time.Now(),
+			10,
+		)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{
+			"https://a.example.com",
+			"https://b.example.com",
+			"https://c.example.com",
+		}, urls)
+	})
+
+	t.Run("should attach fetched previews to posts", func(t *testing.T) {
+		cleanupTestDatabase()
+		linkPreviewRepo := NewLinkPreviewRepo(testDbService.GetDB())
+		author := createTestAuthor(t, "author@example.com")
+
+		post, err := getTestPostRepo().CreatePost(ctx, models.PostCreate{
+			AuthorId: author.ID,
+			Content:  "https://b.example.com and https://a.example.com",
+			Title:    "links",
+		})
+		require.NoError(t, err)
+
+		title := "Title"
+		for _, url := range []string{
+			"https://a.example.com",
+			"https://b.example.com",
+		} {
+			err := linkPreviewRepo.ClaimLinkPreviewFetch(
+				ctx,
+				url,
+				time.Now(),
+				time.Now().Add(-time.Hour),
+			)
+			require.NoError(t, err)
+			err = linkPreviewRepo.CompleteLinkPreviewFetch(
+				ctx,
+				url,
+				&models.LinkPreviewFetched{Title: &title},
+			)
+			require.NoError(t, err)
+		}
+
+		fetched, err := getTestPostRepo().GetPostById(ctx, "", post.ID)
+		require.NoError(t, err)
+		require.Len(t, fetched.LinkPreviews, 2)
+		assert.Equal(t, "https://b.example.com", fetched.LinkPreviews[0].Url)
+		assert.Equal(t, "https://a.example.com", fetched.LinkPreviews[1].Url)
+		assert.Equal(t, "Title", *fetched.LinkPreviews[0].Title)
+	})
+
+	t.Run("should claim due previews once", func(t *testing.T) {
+		cleanupTestDatabase()
+		linkPreviewRepo := NewLinkPreviewRepo(testDbService.GetDB())
+		author := createTestAuthor(t, "author@example.com")
+		url := "https://example.com"
+
+		_, err := getTestPostRepo().CreatePost(ctx, models.PostCreate{
+			AuthorId: author.ID,
+			Content:  url,
+			Title:    "link",
+		})
+		require.NoError(t, err)
+
+		staleBefore := time.Now().Add(-time.Hour)
+		err = linkPreviewRepo.ClaimLinkPreviewFetch(
+			ctx,
+			url,
+			time.Now(),
+			staleBefore,
+		)
+		require.NoError(t, err)
+		err = linkPreviewRepo.ClaimLinkPreviewFetch(
+			ctx,
+			url,
+			time.Now(),
+			staleBefore,
+		)
+		assert.ErrorIs(t, err, ErrLinkPreviewNotDue)
+
+		err = linkPreviewRepo.CompleteLinkPreviewFetch(ctx, url, nil)
+		require.NoError(t, err)
+		preview, err := linkPreviewRepo.GetLinkPreview(ctx, url)
+		require.NoError(t, err)
+		assert.Equal(t, models.LinkPreviewStatusFailed, preview.Status)
+
+		// Fetched previews are due again once they are older than
+		// refreshBefore.
+		err = linkPreviewRepo.ClaimLinkPreviewFetch(
+			ctx,
+			url,
+			time.Now().Add(-time.Hour),
+			staleBefore,
+		)
+		assert.ErrorIs(t, err, ErrLinkPreviewNotDue)
+		err = linkPreviewRepo.ClaimLinkPreviewFetch(
+			ctx,
+			url,
+			time.Now().Add(time.Hour),
+			staleBefore,
+		)
+		assert.NoError(t, err)
+	})
+}
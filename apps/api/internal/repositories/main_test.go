@@ -111,7 +111,7 @@ func cleanupTestDatabase() {
 	db := testDbService.GetDB()
 	_, _ = db.Exec(
 		context.Background(),
-		"TRUNCATE TABLE users CASCADE",
+		"TRUNCATE TABLE users, link_previews CASCADE",
 	)
 }
 
@@ -0,0 +1,517 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"apps/api/internal/models"
+)
+
+var (
+	ErrMediaUnavailable = errors.New("Media not found or already attached")
+	ErrMediaNotPending  = errors.New("Media is not pending processing")
+)
+
+type MediaRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewMediaRepo(db *pgxpool.Pool) *MediaRepo {
+	return &MediaRepo{db: db}
+}
+
+// querier runs queries on the pool or in a transaction.
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+var mediaStruct = sqlbuilder.NewStruct(new(models.Media)).
+	For(sqlbuilder.PostgreSQL)
+
+var mediaVariantStruct = sqlbuilder.NewStruct(new(models.MediaVariant)).
+	For(sqlbuilder.PostgreSQL)
+
+func (r *MediaRepo) CreateMedia(
+	ctx context.Context,
+	params models.MediaCreate,
+) (*models.Media, error) {
+	ib := sqlbuilder.PostgreSQL.NewInsertBuilder()
+	ib.InsertInto("media")
+	ib.Cols("owner_id", "content_type", "size_bytes", "storage_key")
+	ib.Values(
+		params.OwnerId,
+		params.ContentType,
+		params.SizeBytes,
+		params.StorageKey,
+	)
+	ib.Returning(strings.Join(mediaStruct.Columns(), ","))
+	sql, args := ib.Build()
+
+	var media models.Media
+	err := r.db.QueryRow(ctx, sql, args...).Scan(mediaStruct.Addr(&media)...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create media: %w", err)
+	}
+
+	return &media, nil
+}
+
+func (r *MediaRepo) GetMediaById(
+	ctx context.Context,
+	id string,
+) (*models.Media, error) {
+	sb := mediaStruct.SelectFrom("media")
+	sb.Where(sb.Equal("id", id))
+	sql, args := sb.Build()
+
+	var media models.Media
+	err := r.db.QueryRow(ctx, sql, args...).Scan(mediaStruct.Addr(&media)...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get media by id: %w", err)
+	}
+
+	variants, err := getMediaVariants(ctx, r.db, []string{media.ID})
+	if err != nil {
+		return nil, err
+	}
+	media.Variants = variants[media.ID]
+
+	return &media, nil
+}
+
+func (r *MediaRepo) DeleteMedia(ctx context.Context, id string) error {
+	db := sqlbuilder.PostgreSQL.NewDeleteBuilder()
+	db.DeleteFrom("media")
+	db.Where(db.Equal("id", id))
+	sql, args := db.Build()
+
+	if _, err := r.db.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("Failed to delete media: %w", err)
+	}
+
+	return nil
+}
+
+// GetUnprocessedMediaIds returns up to limit media that are pending
+// processing, or whose processing started before staleBefore and never
+// finished, oldest first.
+func (r *MediaRepo) GetUnprocessedMediaIds(
+	ctx context.Context,
+	staleBefore time.Time,
+	limit int,
+) ([]string, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select("id")
+	sb.From("media")
+	sb.Where(sb.Or(
+		sb.Equal("status", models.MediaStatusPending),
+		sb.And(
+			sb.Equal("status", models.MediaStatusProcessing),
+			sb.LessThan("processing_started_at", staleBefore),
+		),
+	))
+	sb.OrderBy("created_at")
+	sb.Limit(limit)
+	sql, args := sb.Build()
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query unprocessed media: %w", err)
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read unprocessed media: %w", err)
+	}
+
+	return ids, nil
+}
+
+// ClaimMediaProcessing marks the media as processing, so that no other
+// worker processes it meanwhile. Media whose processing started before
+// staleBefore are claimed again. ErrMediaNotPending is returned when the
+// media is processed or being processed already.
+func (r *MediaRepo) ClaimMediaProcessing(
+	ctx context.Context,
+	id string,
+	staleBefore time.Time,
+) (*models.Media, error) {
+	ub := sqlbuilder.PostgreSQL.NewUpdateBuilder()
+	ub.Update("media")
+	ub.Set(
+		ub.Assign("status", models.MediaStatusProcessing),
+		"processing_started_at = NOW()",
+	)
+	ub.Where(
+		ub.Equal("id", id),
+		ub.Or(
+			ub.Equal("status", models.MediaStatusPending),
+			ub.And(
+				ub.Equal("status", models.MediaStatusProcessing),
+				ub.LessThan("processing_started_at", staleBefore),
+			),
+		),
+	)
+	ub.SQL("RETURNING " + strings.Join(mediaStruct.Columns(), ","))
+	sql, args := ub.Build()
+
+	var media models.Media
+	err := r.db.QueryRow(ctx, sql, args...).Scan(mediaStruct.Addr(&media)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf(
+			"Failed to claim media processing: %w",
+			ErrMediaNotPending,
+		)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to claim media processing: %w", err)
+	}
+
+	return &media, nil
+}
+
+// CompleteMediaProcessing marks the media as ready and saves the outcome of
+// processing it, which is nil for media that are not processed.
+func (r *MediaRepo) CompleteMediaProcessing(
+	ctx context.Context,
+	id string,
+	processed *models.MediaProcessed,
+) error {
+	ub := sqlbuilder.PostgreSQL.NewUpdateBuilder()
+	ub.Update("media")
+	ub.Set(ub.Assign("status", models.MediaStatusReady))
+	if processed != nil {
+		ub.SetMore(
+			ub.Assign("blurhash", processed.Blurhash),
+			ub.Assign("height", processed.Height),
+			ub.Assign("size_bytes", processed.SizeBytes),
+			ub.Assign("width", processed.Width),
+		)
+	}
+	ub.Where(ub.Equal("id", id))
+	sql, args := ub.Build()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("Failed to complete media processing: %w", err)
+	}
+
+	if processed != nil && len(processed.Variants) > 0 {
+		ib := sqlbuilder.PostgreSQL.NewInsertBuilder()
+		ib.InsertInto("media_variants")
+		ib.Cols(
+			"media_id",
+			"name",
+			"content_type",
+			"width",
+			"height",
+			"size_bytes",
+			"storage_key",
+		)
+		for _, variant := range processed.Variants {
+			ib.Values(
+				id,
+				variant.Name,
+				variant.ContentType,
+				variant.Width,
+				variant.Height,
+				variant.SizeBytes,
+				variant.StorageKey,
+			)
+		}
+		ib.SQL(`ON CONFLICT (media_id, name) DO UPDATE SET
+			content_type = EXCLUDED.content_type,
+			width = EXCLUDED.width,
+			height = EXCLUDED.height,
+			size_bytes = EXCLUDED.size_bytes,
+			storage_key = EXCLUDED.storage_key`)
+		sql, args := ib.Build()
+
+		if _, err := tx.Exec(ctx, sql, args...); err != nil {
+			return fmt.Errorf("Failed to save media variants: %w", err)
+		}
+	}
+
+	err = touchPosts(
+		ctx,
+		tx,
+		"id = (SELECT post_id FROM media WHERE id = $1)",
+		id,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("Failed to complete media processing: %w", err)
+	}
+
+	return nil
+}
+
+// FailMediaProcessing marks media that could not be processed, e.g. because
+// they are not valid images.
+func (r *MediaRepo) FailMediaProcessing(ctx context.Context, id string) error {
+	ub := sqlbuilder.PostgreSQL.NewUpdateBuilder()
+	ub.Update("media")
+	ub.Set(ub.Assign("status", models.MediaStatusFailed))
+	ub.Where(ub.Equal("id", id))
+	sql, args := ub.Build()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("Failed to fail media processing: %w", err)
+	}
+	err = touchPosts(
+		ctx,
+		tx,
+		"id = (SELECT post_id FROM media WHERE id = $1)",
+		id,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("Failed to fail media processing: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteOrphanedMedia deletes up to limit media that were created before the
+// given time and are not attached to a post, and returns how many were
+// deleted. deleteBlob is called for each of them while their rows are
+// locked, so that they cannot be attached meanwhile, and rows are only
+// deleted once their blob and the blobs of their variants are. Media of posts
+// purged from the trash become orphans again.
+func (r *MediaRepo) DeleteOrphanedMedia(
+	ctx context.Context,
+	createdBefore time.Time,
+	limit int,
+	deleteBlob func(ctx context.Context, key string) error,
+) (int, error) {
+	sb := mediaStruct.SelectFrom("media")
+	sb.Where(sb.IsNull("post_id"), sb.LessThan("created_at", createdBefore))
+	sb.OrderBy("created_at")
+	sb.Limit(limit)
+	sb.SQL("FOR UPDATE SKIP LOCKED")
+	sql, args := sb.Build()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return 0, fmt.Errorf("Failed to query orphaned media: %w", err)
+	}
+	orphans, err := pgx.CollectRows(
+		rows,
+		func(row pgx.CollectableRow) (models.Media, error) {
+			var media models.Media
+			err := row.Scan(mediaStruct.Addr(&media)...)
+			return media, err
+		},
+	)
+	if err != nil {
+		return 0, fmt.Errorf("Failed to read orphaned media: %w", err)
+	}
+
+	ids := make([]string, 0, len(orphans))
+	for _, orphan := range orphans {
+		ids = append(ids, orphan.ID)
+	}
+	variants, err := getMediaVariants(ctx, tx, ids)
+	if err != nil {
+		return 0, err
+	}
+
+	var deleted []string
+	var blobErr error
+	for _, orphan := range orphans {
+		for _, variant := range variants[orphan.ID] {
+			if blobErr = deleteBlob(ctx, variant.StorageKey); blobErr != nil {
+				break
+			}
+		}
+		if blobErr != nil {
+			break
+		}
+		if blobErr = deleteBlob(ctx, orphan.StorageKey); blobErr != nil {
+			break
+		}
+		deleted = append(deleted, orphan.ID)
+	}
+
+	if len(deleted) > 0 {
+		_, err = tx.Exec(
+			ctx,
+			`DELETE FROM media WHERE id = ANY($1::uuid[])`,
+			deleted,
+		)
+		if err != nil {
+			return 0, fmt.Errorf("Failed to delete orphaned media: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("Failed to delete orphaned media: %w", err)
+	}
+	if blobErr != nil {
+		return len(deleted), fmt.Errorf(
+			"Failed to delete orphaned media: %w",
+			blobErr,
+		)
+	}
+
+	return len(deleted), nil
+}
+
+// attachMedia attaches media of the owner that are not attached yet to the
+// post, in the given order. ErrMediaUnavailable is returned when any of them
+// is missing, owned by someone else or attached already.
+func attachMedia(
+	ctx context.Context,
+	tx pgx.Tx,
+	postId string,
+	ownerId string,
+	mediaIds []string,
+) error {
+	if len(mediaIds) == 0 {
+		return nil
+	}
+
+	tag, err := tx.Exec(
+		ctx,
+		`UPDATE media SET post_id = $1, position = attached.position
+		FROM unnest($2::uuid[]) WITH ORDINALITY AS attached(id, position)
+		WHERE media.id = attached.id
+			AND media.owner_id = $3
+			AND media.post_id IS NULL`,
+		postId,
+		mediaIds,
+		ownerId,
+	)
+	if err != nil {
+		return fmt.Errorf("Failed to attach media: %w", err)
+	}
+	if tag.RowsAffected() != int64(len(mediaIds)) {
+		return fmt.Errorf("Failed to attach media: %w", ErrMediaUnavailable)
+	}
+
+	return nil
+}
+
+// getPostMedia returns the media of the posts by post id, in order, with
+// their variants.
+func getPostMedia(
+	ctx context.Context,
+	db *pgxpool.Pool,
+	postIds []string,
+) (map[string][]*models.Media, error) {
+	media := map[string][]*models.Media{}
+	if len(postIds) == 0 {
+		return media, nil
+	}
+	ids := make([]any, len(postIds))
+	for i, id := range postIds {
+		ids[i] = id
+	}
+
+	sb := mediaStruct.SelectFrom("media")
+	sb.Where(sb.In("post_id", ids...))
+	sb.OrderBy("post_id", "position")
+	sql, args := sb.Build()
+
+	rows, err := db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query post media: %w", err)
+	}
+	defer rows.Close()
+
+	var mediaIds []string
+	var all []*models.Media
+	for rows.Next() {
+		var item models.Media
+		if err := rows.Scan(mediaStruct.Addr(&item)...); err != nil {
+			return nil, fmt.Errorf("Failed to scan post media: %w", err)
+		}
+		media[*item.PostId] = append(media[*item.PostId], &item)
+		mediaIds = append(mediaIds, item.ID)
+		all = append(all, &item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Failed to read post media: %w", err)
+	}
+
+	variants, err := getMediaVariants(ctx, db, mediaIds)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range all {
+		item.Variants = variants[item.ID]
+	}
+
+	return media, nil
+}
+
+// getMediaVariants returns the variants of the media by media id, smallest
+// first.
+func getMediaVariants(
+	ctx context.Context,
+	db querier,
+	mediaIds []string,
+) (map[string][]*models.MediaVariant, error) {
+	variants := map[string][]*models.MediaVariant{}
+	if len(mediaIds) == 0 {
+		return variants, nil
+	}
+	ids := make([]any, len(mediaIds))
+	for i, id := range mediaIds {
+		ids[i] = id
+	}
+
+	sb := mediaVariantStruct.SelectFrom("media_variants")
+	sb.Where(sb.In("media_id", ids...))
+	sb.OrderBy("media_id", "width", "name")
+	sql, args := sb.Build()
+
+	rows, err := db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query media variants: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var variant models.MediaVariant
+		err := rows.Scan(mediaVariantStruct.Addr(&variant)...)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to scan media variant: %w", err)
+		}
+		variants[variant.MediaId] = append(variants[variant.MediaId], &variant)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Failed to read media variants: %w", err)
+	}
+
+	return variants, nil
+}
@@ -0,0 +1,214 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"apps/api/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestMedia(t *testing.T, ownerId string, key string) *models.Media {
+	media, err := NewMediaRepo(testDbService.GetDB()).CreateMedia(
+		context.Background(),
+		models.MediaCreate{
+			ContentType: "image/png",
+			OwnerId:     ownerId,
+			SizeBytes:   100,
+			StorageKey:  key,
+		},
+	)
+	require.NoError(t, err)
+	return media
+}
+
+func TestMediaRepo_Attach(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should attach media to posts in order", func(t *testing.T) {
+		cleanupTestDatabase()
+		author := createTestAuthor(t, "author@example.com")
+		first := createTestMedia(t, author.ID, "media/first")
+		second := createTestMedia(t, author.ID, "media/second")
+
+		post, err := getTestPostRepo().CreatePost(ctx, models.PostCreate{
+			AuthorId: author.ID,
+			Content:  "content",
+			MediaIds: []string{second.ID, first.ID},
+			Title:    "with media",
+		})
+		require.NoError(t, err)
+		require.Len(t, post.Media, 2)
+		assert.Equal(t, second.ID, post.Media[0].ID)
+		assert.Equal(t, first.ID, post.Media[1].ID)
+
+		_, err = getTestPostRepo().CreatePost(ctx, models.PostCreate{
+			AuthorId: author.ID,
+			Content:  "content",
+			MediaIds: []string{first.ID},
+			Title:    "reusing media",
+		})
+		assert.ErrorIs(t, err, ErrMediaUnavailable)
+	})
+
+	t.Run("should not attach media of other users", func(t *testing.T) {
+		cleanupTestDatabase()
+		author := createTestAuthor(t, "author@example.com")
+		other := createTestAuthor(t, "other@example.com")
+		media := createTestMedia(t, other.ID, "media/other")
+
+		_, err := getTestPostRepo().CreatePost(ctx, models.PostCreate{
+			AuthorId: author.ID,
+			Content:  "content",
+			MediaIds: []string{media.ID},
+			Title:    "stolen media",
+		})
+		assert.ErrorIs(t, err, ErrMediaUnavailable)
+	})
+}
+
+func TestMediaRepo_DeleteOrphanedMedia(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should delete old unattached media", func(t *testing.T) {
+		cleanupTestDatabase()
+		mediaRepo := NewMediaRepo(testDbService.GetDB())
+		author := createTestAuthor(t, "author@example.com")
+		orphan := createTestMedia(t, author.ID, "media/orphan")
+		recent := createTestMedia(t, author.ID, "media/recent")
+		attached := createTestMedia(t, author.ID, "media/attached")
+		_, err := getTestPostRepo().CreatePost(ctx, models.PostCreate{
+			AuthorId: author.ID,
+			Content:  "content",
+			MediaIds: []string{attached.ID},
+			Title:    "with media",
+		})
+		require.NoError(t, err)
+		_, err = testDbService.GetDB().Exec(
+			ctx,
+			`UPDATE media SET created_at = NOW() - INTERVAL '2 days'
+			WHERE id = ANY($1::uuid[])`,
+			[]string{orphan.ID, attached.ID},
+		)
+		require.NoError(t, err)
+
+		var deletedKeys []string
+		deleted, err := mediaRepo.DeleteOrphanedMedia(
+			ctx,
+			time.Now().Add(-24*time.Hour),
+			10,
+			func(_ context.Context, key string) error {
+				deletedKeys = append(deletedKeys, key)
+				return nil
+			},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, 1, deleted)
+		assert.Equal(t, []string{"media/orphan"}, deletedKeys)
+
+		_, err = mediaRepo.GetMediaById(ctx, orphan.ID)
+		assert.Error(t, err)
+		_, err = mediaRepo.GetMediaById(ctx, recent.ID)
+		assert.NoError(t, err)
+		_, err = mediaRepo.GetMediaById(ctx, attached.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should keep media whose blob was not deleted", func(t *testing.T) {
+		cleanupTestDatabase()
+		mediaRepo := NewMediaRepo(testDbService.GetDB())
+		author := createTestAuthor(t, "author@example.com")
+		orphan := createTestMedia(t, author.ID, "media/orphan")
+
+		deleted, err := mediaRepo.DeleteOrphanedMedia(
+			ctx,
+			time.Now().Add(time.Hour),
+			10,
+			func(_ context.Context, _ string) error {
+				return errors.New("storage unavailable")
+			},
+		)
+		assert.Error(t, err)
+		assert.Equal(t, 0, deleted)
+
+		_, err = mediaRepo.GetMediaById(ctx, orphan.ID)
+		assert.NoError(t, err)
+	})
+}
+
+func TestMediaRepo_Processing(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should claim pending media once", func(t *testing.T) {
+		cleanupTestDatabase()
+		mediaRepo := NewMediaRepo(testDbService.GetDB())
+		author := createTestAuthor(t, "author@example.com")
+		media := createTestMedia(t, author.ID, "media/image")
+		assert.Equal(t, models.MediaStatusPending, media.Status)
+
+		ids, err := mediaRepo.GetUnprocessedMediaIds(ctx, time.Now(), 10)
+		require.NoError(t, err)
+		assert.Equal(t, []string{media.ID}, ids)
+
+		staleBefore := time.Now().Add(-time.Hour)
+		claimed, err := mediaRepo.ClaimMediaProcessing(ctx, media.ID, staleBefore)
+		require.NoError(t, err)
+		assert.Equal(t, models.MediaStatusProcessing, claimed.Status)
+
+		_, err = mediaRepo.ClaimMediaProcessing(ctx, media.ID, staleBefore)
+		assert.ErrorIs(t, err, ErrMediaNotPending)
+
+		// Processing that started before staleBefore is claimed again.
+		_, err = mediaRepo.ClaimMediaProcessing(
+			ctx,
+			media.ID,
+			time.Now().Add(time.Hour),
+		)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should save processed images with their variants", func(t *testing.T) {
+		cleanupTestDatabase()
+		mediaRepo := NewMediaRepo(testDbService.GetDB())
+		author := createTestAuthor(t, "author@example.com")
+		media := createTestMedia(t, author.ID, "media/image")
+
+		err := mediaRepo.CompleteMediaProcessing(
+			ctx,
+			media.ID,
+			&models.MediaProcessed{
+				Blurhash:  "LEHV6nWB2yk8pyo0adR*.7kCMdnj",
+				Height:    400,
+				SizeBytes: 90,
+				Variants: []*models.MediaVariant{
+					{
+						ContentType: "image/jpeg",
+						Height:      200,
+						Name:        "thumbnail",
+						SizeBytes:   10,
+						StorageKey:  "media/image-thumbnail",
+						Width:       200,
+					},
+				},
+				Width: 800,
+			},
+		)
+		require.NoError(t, err)
+
+		processed, err := mediaRepo.GetMediaById(ctx, media.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.MediaStatusReady, processed.Status)
+		assert.Equal(t, 800, *processed.Width)
+		assert.Equal(t, int64(90), processed.SizeBytes)
+		require.Len(t, processed.Variants, 1)
+		assert.Equal(t, "thumbnail", processed.Variants[0].Name)
+
+		ids, err := mediaRepo.GetUnprocessedMediaIds(ctx, time.Now(), 10)
+		require.NoError(t, err)
+		assert.Empty(t, ids)
+	})
+}
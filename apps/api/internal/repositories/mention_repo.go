@@ -0,0 +1,253 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+
+	"apps/api/internal/models"
+	"apps/api/internal/utils"
+)
+
+// maxMentions is the number of mentions stored for a post or comment.
+const maxMentions = 20
+
+// setMentions replaces the mentions of the post, or of the comment on the
+// post when commentId is set, with the mentions of existing users in the
+// content. Users mentioned for the first time are notified.
+func setMentions(
+	ctx context.Context,
+	tx pgx.Tx,
+	authorId string,
+	postId string,
+	commentId *string,
+	content string,
+) ([]*models.Mention, error) {
+	extracted := utils.ExtractMentions(content)
+	if len(extracted) > maxMentions {
+		extracted = extracted[:maxMentions]
+	}
+	handles := make([]string, len(extracted))
+	offsets := make([]int, len(extracted))
+	lengths := make([]int, len(extracted))
+	for i, mention := range extracted {
+		handles[i] = mention.Handle
+		offsets[i] = mention.Offset
+		lengths[i] = mention.Length
+	}
+
+	target, targetId := "post_id = $1 AND comment_id IS NULL", postId
+	if commentId != nil {
+		target, targetId = "comment_id = $1", *commentId
+	}
+	rows, err := tx.Query(
+		ctx,
+		`DELETE FROM mentions WHERE `+target+` RETURNING user_id`,
+		targetId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to clear mentions: %w", err)
+	}
+	previous, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read mentions: %w", err)
+	}
+
+	rows, err = tx.Query(
+		ctx,
+		`WITH inserted AS (
+			INSERT INTO mentions (post_id, comment_id, user_id, start_offset, length)
+			SELECT $1::uuid, $2::uuid, users.id, t.start_offset, t.length
+			FROM unnest($3::text[], $4::int[], $5::int[])
+				AS t(handle, start_offset, length)
+			JOIN users ON users.handle = t.handle
+			RETURNING user_id, start_offset, length
+		)
+		SELECT inserted.user_id, users.handle, inserted.start_offset,
+			inserted.length
+		FROM inserted
+		JOIN users ON users.id = inserted.user_id
+		ORDER BY inserted.start_offset`,
+		postId,
+		commentId,
+		handles,
+		offsets,
+		lengths,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to set mentions: %w", err)
+	}
+	mentions, err := pgx.CollectRows(rows, scanMention)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read mentions: %w", err)
+	}
+
+	var mentioned []string
+	for _, mention := range mentions {
+		if !slices.Contains(previous, mention.UserId) &&
+			!slices.Contains(mentioned, mention.UserId) {
+			mentioned = append(mentioned, mention.UserId)
+		}
+	}
+	err = notifyMentions(ctx, tx, authorId, postId, commentId, mentioned)
+	if err != nil {
+		return nil, err
+	}
+
+	return mentions, nil
+}
+
+// notifyMentions notifies the mentioned users that can read the post,
+// leaving out the author and users that blocked the author or were blocked
+// by them.
+func notifyMentions(
+	ctx context.Context,
+	tx pgx.Tx,
+	authorId string,
+	postId string,
+	commentId *string,
+	userIds []string,
+) error {
+	if len(userIds) == 0 {
+		return nil
+	}
+
+	_, err := tx.Exec(
+		ctx,
+		fmt.Sprintf(
+			`INSERT INTO notifications
+				(user_id, type, actor_id, post_id, comment_id, group_key)
+			SELECT users.id, $4::text, $1::uuid, posts.id, $3::uuid,
+				COALESCE($3::uuid, posts.id)::text
+			FROM users
+			JOIN posts ON posts.id = $2::uuid
+			WHERE users.id = ANY($5::uuid[])
+				AND users.id <> $1::uuid
+				AND (
+					posts.author_id = users.id
+					OR posts.visibility IN ($6, $7)
+					OR (
+						posts.visibility = $8
+						AND EXISTS (
+							SELECT 1 FROM follows
+							WHERE follower_id = users.id
+								AND followee_id = posts.author_id
+						)
+					)
+				)
+				AND %s`,
+			notBlockedCondition("users.id", "$1::uuid"),
+		),
+		authorId,
+		postId,
+		commentId,
+		models.NotificationTypeMention,
+		userIds,
+		models.PostVisibilityPublic,
+		models.PostVisibilityUnlisted,
+		models.PostVisibilityFollowers,
+	)
+	if err != nil {
+		return fmt.Errorf("Failed to notify mentioned users: %w", err)
+	}
+
+	return nil
+}
+
+// getPostMentions returns the mentions in the content of the posts by post
+// id, in the order they appear.
+func getPostMentions(
+	ctx context.Context,
+	db querier,
+	postIds []string,
+) (map[string][]*models.Mention, error) {
+	return getMentions(
+		ctx,
+		db,
+		"mentions.post_id = ANY($1::uuid[]) AND mentions.comment_id IS NULL",
+		"mentions.post_id",
+		postIds,
+	)
+}
+
+// getCommentMentions returns the mentions in the content of the comments by
+// comment id, in the order they appear.
+func getCommentMentions(
+	ctx context.Context,
+	db querier,
+	commentIds []string,
+) (map[string][]*models.Mention, error) {
+	return getMentions(
+		ctx,
+		db,
+		"mentions.comment_id = ANY($1::uuid[])",
+		"mentions.comment_id",
+		commentIds,
+	)
+}
+
+func getMentions(
+	ctx context.Context,
+	db querier,
+	condition string,
+	targetColumn string,
+	ids []string,
+) (map[string][]*models.Mention, error) {
+	mentions := map[string][]*models.Mention{}
+	if len(ids) == 0 {
+		return mentions, nil
+	}
+
+	rows, err := db.Query(
+		ctx,
+		fmt.Sprintf(
+			`SELECT mentions.user_id, users.handle, mentions.start_offset,
+				mentions.length, %[1]s
+			FROM mentions
+			JOIN users ON users.id = mentions.user_id
+			WHERE %[2]s
+			ORDER BY %[1]s, mentions.start_offset`,
+			targetColumn,
+			condition,
+		),
+		ids,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query mentions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var mention models.Mention
+		var targetId string
+		err := rows.Scan(
+			&mention.UserId,
+			&mention.Handle,
+			&mention.Offset,
+			&mention.Length,
+			&targetId,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to scan mention: %w", err)
+		}
+		mentions[targetId] = append(mentions[targetId], &mention)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Failed to read mentions: %w", err)
+	}
+
+	return mentions, nil
+}
+
+func scanMention(row pgx.CollectableRow) (*models.Mention, error) {
+	var mention models.Mention
+	err := row.Scan(
+		&mention.UserId,
+		&mention.Handle,
+		&mention.Offset,
+		&mention.Length,
+	)
+	return &mention, err
+}
@@ -0,0 +1,120 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"apps/api/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func countMentionNotifications(t *testing.T, userId string) int {
+	var count int
+	err := testDbService.GetDB().QueryRow(
+		context.Background(),
+		`SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND type = $2`,
+		userId,
+		models.NotificationTypeMention,
+	).Scan(&count)
+	require.NoError(t, err)
+	return count
+}
+
+func TestMentions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should store mentions of existing users", func(t *testing.T) {
+		cleanupTestDatabase()
+		author := createTestAuthor(t, "author@example.com")
+		bob := createTestAuthor(t, "bob@example.com")
+
+		post, err := getTestPostRepo().CreatePost(ctx, models.PostCreate{
+			AuthorId: author.ID,
+			Content:  "Hi @Bob and @nobody, @bob!",
+			Title:    "mentions",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []*models.Mention{
+			{Handle: "bob", Length: 4, Offset: 3, UserId: bob.ID},
+			{Handle: "bob", Length: 4, Offset: 21, UserId: bob.ID},
+		}, post.Mentions)
+		assert.Equal(t, 1, countMentionNotifications(t, bob.ID))
+
+		fetched, err := getTestPostRepo().GetPostById(ctx, "", post.ID)
+		require.NoError(t, err)
+		assert.Equal(t, post.Mentions, fetched.Mentions)
+
+		// Users mentioned before are not notified again on edits.
+		content := "Hi @bob and @author"
+		updated, err := getTestPostRepo().UpdatePost(
+			ctx,
+			post.ID,
+			author.ID,
+			models.PostUpdate{Content: &content},
+			nil,
+		)
+		require.NoError(t, err)
+		assert.Len(t, updated.Mentions, 2)
+		assert.Equal(t, 1, countMentionNotifications(t, bob.ID))
+		assert.Equal(t, 0, countMentionNotifications(t, author.ID))
+	})
+
+	t.Run("should not notify users that cannot read the post", func(t *testing.T) {
+		cleanupTestDatabase()
+		author := createTestAuthor(t, "author@example.com")
+		blocker := createTestAuthor(t, "blocker@example.com")
+		stranger := createTestAuthor(t, "stranger@example.com")
+		follower := createTestAuthor(t, "follower@example.com")
+		blockRepo := NewBlockRepo(testDbService.GetDB())
+		require.NoError(t, blockRepo.Block(ctx, blocker.ID, author.ID))
+		followRepo := NewFollowRepo(testDbService.GetDB())
+		require.NoError(t, followRepo.Follow(ctx, follower.ID, author.ID))
+
+		_, err := getTestPostRepo().CreatePost(ctx, models.PostCreate{
+			AuthorId:   author.ID,
+			Content:    "@blocker @stranger @follower",
+			Title:      "followers only",
+			Visibility: models.PostVisibilityFollowers,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, countMentionNotifications(t, blocker.ID))
+		assert.Equal(t, 0, countMentionNotifications(t, stranger.ID))
+		assert.Equal(t, 1, countMentionNotifications(t, follower.ID))
+	})
+
+	t.Run("should store mentions in comments", func(t *testing.T) {
+		cleanupTestDatabase()
+		author := createTestAuthor(t, "author@example.com")
+		bob := createTestAuthor(t, "bob@example.com")
+		post := createTestPost(t, author.ID, "post")
+
+		comment, err := getTestCommentRepo().CreateComment(
+			ctx,
+			models.CommentCreate{
+				AuthorId: author.ID,
+				Content:  "cc @bob",
+				PostId:   post.ID,
+			},
+		)
+		require.NoError(t, err)
+		require.Len(t, comment.Mentions, 1)
+		assert.Equal(t, 3, comment.Mentions[0].Offset)
+		assert.Equal(t, 1, countMentionNotifications(t, bob.ID))
+
+		fetched, err := getTestCommentRepo().GetCommentById(
+			ctx,
+			"",
+			post.ID,
+			comment.ID,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, comment.Mentions, fetched.Mentions)
+
+		fetchedPost, err := getTestPostRepo().GetPostById(ctx, "", post.ID)
+		require.NoError(t, err)
+		assert.Empty(t, fetchedPost.Mentions)
+	})
+}
@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type MuteRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewMuteRepo(db *pgxpool.Pool) *MuteRepo {
+	return &MuteRepo{db: db}
+}
+
+// Mute makes muterId mute mutedId. Muting a user twice is a no-op.
+func (r *MuteRepo) Mute(
+	ctx context.Context,
+	muterId string,
+	mutedId string,
+) error {
+	ib := sqlbuilder.PostgreSQL.NewInsertBuilder()
+	ib.InsertInto("user_mutes")
+	ib.Cols("muter_id", "muted_id")
+	ib.Values(muterId, mutedId)
+	ib.SQL("ON CONFLICT DO NOTHING")
+	sql, args := ib.Build()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("Failed to mute user: %w", err)
+	}
+	if tag.RowsAffected() > 0 {
+		if err := touchUsers(ctx, tx, muterId); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("Failed to mute user: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MuteRepo) Unmute(
+	ctx context.Context,
+	muterId string,
+	mutedId string,
+) error {
+	db := sqlbuilder.PostgreSQL.NewDeleteBuilder()
+	db.DeleteFrom("user_mutes")
+	db.Where(
+		db.Equal("muter_id", muterId),
+		db.Equal("muted_id", mutedId),
+	)
+	sql, args := db.Build()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("Failed to unmute user: %w", err)
+	}
+	if tag.RowsAffected() > 0 {
+		if err := touchUsers(ctx, tx, muterId); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("Failed to unmute user: %w", err)
+	}
+
+	return nil
+}
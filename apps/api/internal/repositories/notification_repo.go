@@ -0,0 +1,299 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"apps/api/internal/models"
+)
+
+// notificationGroupActors is the number of actors listed per group.
+const notificationGroupActors = 3
+
+var ErrNotificationNotFound = errors.New("Notification not found")
+
+type NotificationRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewNotificationRepo(db *pgxpool.Pool) *NotificationRepo {
+	return &NotificationRepo{db: db}
+}
+
+// GetNotifications returns a page of the notification groups of the user,
+// ordered by their latest notification, most recent first.
+func (r *NotificationRepo) GetNotifications(
+	ctx context.Context,
+	params models.NotificationListParams,
+) ([]*models.Notification, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select(
+		"notifications.id",
+		"notifications.type",
+		fmt.Sprintf(
+			`ARRAY(
+				SELECT grouped.actor_id::text
+				FROM notifications AS grouped
+				WHERE grouped.user_id = notifications.user_id
+					AND grouped.type = notification_groups.type
+					AND grouped.group_key = notification_groups.group_key
+					AND grouped.actor_id IS NOT NULL
+				GROUP BY grouped.actor_id
+				ORDER BY MAX(grouped.created_at) DESC
+				LIMIT %d
+			)`,
+			notificationGroupActors,
+		),
+		"notification_groups.actors_count",
+		"notifications.post_id",
+		"notifications.comment_id",
+		"notifications.payload",
+		"NOT notification_groups.unread",
+		"notification_groups.latest_at",
+	)
+	sb.From(fmt.Sprintf(
+		`(
+			SELECT type, group_key,
+				(ARRAY_AGG(id ORDER BY created_at DESC, id DESC))[1]
+					AS latest_id,
+				MAX(created_at) AS latest_at,
+				COUNT(DISTINCT actor_id) AS actors_count,
+				BOOL_OR(read_at IS NULL) AS unread
+			FROM notifications
+			WHERE user_id = %s
+			GROUP BY type, group_key
+		) AS notification_groups`,
+		sb.Var(params.UserId),
+	))
+	sb.Join(
+		"notifications",
+		"notifications.id = notification_groups.latest_id",
+	)
+	if params.UnreadOnly {
+		sb.Where("notification_groups.unread")
+	}
+	if params.Cursor != nil {
+		sb.Where(fmt.Sprintf(
+			"(notification_groups.latest_at, notification_groups.latest_id)"+
+				" < (%s, %s)",
+			sb.Var(params.Cursor.CreatedAt),
+			sb.Var(params.Cursor.Id),
+		))
+	}
+	sb.OrderBy(
+		"notification_groups.latest_at DESC",
+		"notification_groups.latest_id DESC",
+	)
+	sb.Limit(params.Limit)
+	sql, args := sb.Build()
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []*models.Notification
+	for rows.Next() {
+		var notification models.Notification
+		err := rows.Scan(
+			&notification.ID,
+			&notification.Type,
+			&notification.ActorIds,
+			&notification.ActorsCount,
+			&notification.PostId,
+			&notification.CommentId,
+			&notification.Payload,
+			&notification.IsRead,
+			&notification.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to scan notification: %w", err)
+		}
+		notifications = append(notifications, &notification)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Failed to read notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+// CountUnreadNotifications returns the number of notification groups of the
+// user with unread notifications.
+func (r *NotificationRepo) CountUnreadNotifications(
+	ctx context.Context,
+	userId string,
+) (int, error) {
+	var count int
+	err := r.db.QueryRow(
+		ctx,
+		`SELECT COUNT(*) FROM (
+			SELECT DISTINCT type, group_key
+			FROM notifications
+			WHERE user_id = $1 AND read_at IS NULL
+		) AS unread_groups`,
+		userId,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("Failed to count notifications: %w", err)
+	}
+
+	return count, nil
+}
+
+// MarkNotificationRead marks the group of the notification as read, up to
+// the notification itself so that notifications which arrived later stay
+// unread. ErrNotificationNotFound is returned when the user has no such
+// notification.
+func (r *NotificationRepo) MarkNotificationRead(
+	ctx context.Context,
+	userId string,
+	id string,
+) error {
+	var notificationType models.NotificationType
+	var groupKey string
+	var createdAt time.Time
+	err := r.db.QueryRow(
+		ctx,
+		`SELECT type, group_key, created_at
+		FROM notifications
+		WHERE id = $1 AND user_id = $2`,
+		id,
+		userId,
+	).Scan(&notificationType, &groupKey, &createdAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf(
+			"Failed to mark notification read: %w",
+			ErrNotificationNotFound,
+		)
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to get notification: %w", err)
+	}
+
+	_, err = r.db.Exec(
+		ctx,
+		`UPDATE notifications SET read_at = NOW()
+		WHERE user_id = $1
+			AND type = $2
+			AND group_key = $3
+			AND created_at <= $4
+			AND read_at IS NULL`,
+		userId,
+		notificationType,
+		groupKey,
+		createdAt,
+	)
+	if err != nil {
+		return fmt.Errorf("Failed to mark notification read: %w", err)
+	}
+
+	return nil
+}
+
+// MarkAllNotificationsRead marks all notifications of the user as read.
+func (r *NotificationRepo) MarkAllNotificationsRead(
+	ctx context.Context,
+	userId string,
+) error {
+	_, err := r.db.Exec(
+		ctx,
+		`UPDATE notifications SET read_at = NOW()
+		WHERE user_id = $1 AND read_at IS NULL`,
+		userId,
+	)
+	if err != nil {
+		return fmt.Errorf("Failed to mark notifications read: %w", err)
+	}
+
+	return nil
+}
+
+// notificationGroupKey identifies the target notifications are grouped by
+// within their type: all follows of a user, the reactions or comments on a
+// post, a mention or a report.
+func notificationGroupKey(params models.NotificationCreate) string {
+	switch params.Type {
+	case models.NotificationTypeReaction, models.NotificationTypeComment:
+		return *params.PostId
+	case models.NotificationTypeMention:
+		if params.CommentId != nil {
+			return *params.CommentId
+		}
+		return *params.PostId
+	case models.NotificationTypeModeration:
+		return *params.Payload.ReportId
+	}
+	return ""
+}
+
+// insertNotification notifies the user, unless the user is the actor or
+// either of them blocked the other.
+func insertNotification(
+	ctx context.Context,
+	tx pgx.Tx,
+	params models.NotificationCreate,
+) error {
+	_, err := tx.Exec(
+		ctx,
+		fmt.Sprintf(
+			`INSERT INTO notifications (
+				user_id, type, actor_id, post_id, comment_id, group_key,
+				payload
+			)
+			SELECT $1::uuid, $2::text, $3::uuid, $4::uuid, $5::uuid, $6::text,
+				$7::jsonb
+			WHERE $1::uuid IS DISTINCT FROM $3::uuid AND %s`,
+			notBlockedCondition("$1::uuid", "$3::uuid"),
+		),
+		params.UserId,
+		params.Type,
+		params.ActorId,
+		params.PostId,
+		params.CommentId,
+		notificationGroupKey(params),
+		params.Payload,
+	)
+	if err != nil {
+		return fmt.Errorf("Failed to notify user: %w", err)
+	}
+
+	return nil
+}
+
+// deleteNotification takes back the notifications created with the same
+// params, e.g. when a reaction is removed again.
+func deleteNotification(
+	ctx context.Context,
+	tx pgx.Tx,
+	params models.NotificationCreate,
+) error {
+	_, err := tx.Exec(
+		ctx,
+		`DELETE FROM notifications
+		WHERE user_id = $1
+			AND type = $2
+			AND actor_id IS NOT DISTINCT FROM $3::uuid
+			AND post_id IS NOT DISTINCT FROM $4::uuid
+			AND comment_id IS NOT DISTINCT FROM $5::uuid
+			AND payload = $6::jsonb`,
+		params.UserId,
+		params.Type,
+		params.ActorId,
+		params.PostId,
+		params.CommentId,
+		params.Payload,
+	)
+	if err != nil {
+		return fmt.Errorf("Failed to delete notification: %w", err)
+	}
+
+	return nil
+}
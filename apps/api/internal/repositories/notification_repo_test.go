@@ -0,0 +1,230 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"apps/api/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getTestNotificationRepo() *NotificationRepo {
+	return NewNotificationRepo(testDbService.GetDB())
+}
+
+func getTestNotifications(
+	t *testing.T,
+	userId string,
+	unreadOnly bool,
+) []*models.Notification {
+	notifications, err := getTestNotificationRepo().GetNotifications(
+		context.Background(),
+		models.NotificationListParams{
+			Limit:      20,
+			UnreadOnly: unreadOnly,
+			UserId:     userId,
+		},
+	)
+	require.NoError(t, err)
+	return notifications
+}
+
+func TestNotificationRepo(t *testing.T) {
+	ctx := context.Background()
+	reactionRepo := getTestReactionRepo()
+
+	t.Run("should group notifications on the same target", func(t *testing.T) {
+		cleanupTestDatabase()
+		author := createTestAuthor(t, "author@example.com")
+		post := createTestPost(t, author.ID, "grouped")
+		other := createTestPost(t, author.ID, "other")
+		var fans []*models.User
+		for _, email := range []string{
+			"a@example.com",
+			"b@example.com",
+			"c@example.com",
+			"d@example.com",
+		} {
+			fan := createTestAuthor(t, email)
+			fans = append(fans, fan)
+			err := reactionRepo.AddReaction(ctx, post.ID, fan.ID, "👍")
+			require.NoError(t, err)
+		}
+		// A second emoji of the same user counts the user once.
+		err := reactionRepo.AddReaction(ctx, post.ID, fans[3].ID, "❤️")
+		require.NoError(t, err)
+		err = reactionRepo.AddReaction(ctx, post.ID, author.ID, "👍")
+		require.NoError(t, err)
+		err = reactionRepo.AddReaction(ctx, other.ID, fans[0].ID, "👍")
+		require.NoError(t, err)
+		createTestComment(t, post, fans[1].ID, nil)
+
+		notifications := getTestNotifications(t, author.ID, false)
+		require.Len(t, notifications, 3)
+
+		assert.Equal(t, models.NotificationTypeComment, notifications[0].Type)
+		assert.Equal(t, []string{fans[1].ID}, notifications[0].ActorIds)
+		assert.NotNil(t, notifications[0].CommentId)
+
+		assert.Equal(t, models.NotificationTypeReaction, notifications[1].Type)
+		assert.Equal(t, other.ID, *notifications[1].PostId)
+		assert.Equal(t, 1, notifications[1].ActorsCount)
+
+		grouped := notifications[2]
+		assert.Equal(t, models.NotificationTypeReaction, grouped.Type)
+		assert.Equal(t, post.ID, *grouped.PostId)
+		assert.Equal(t, "❤️", *grouped.Payload.Emoji)
+		assert.Equal(t, 4, grouped.ActorsCount)
+		assert.Equal(
+			t,
+			[]string{fans[3].ID, fans[2].ID, fans[1].ID},
+			grouped.ActorIds,
+		)
+		assert.False(t, grouped.IsRead)
+	})
+
+	t.Run("should take back removed reactions", func(t *testing.T) {
+		cleanupTestDatabase()
+		author := createTestAuthor(t, "author@example.com")
+		fan := createTestAuthor(t, "fan@example.com")
+		post := createTestPost(t, author.ID, "post")
+
+		require.NoError(t, reactionRepo.AddReaction(ctx, post.ID, fan.ID, "👍"))
+		require.NoError(t, reactionRepo.AddReaction(ctx, post.ID, fan.ID, "🎉"))
+		err := reactionRepo.RemoveReaction(ctx, post.ID, fan.ID, "🎉")
+		require.NoError(t, err)
+
+		notifications := getTestNotifications(t, author.ID, false)
+		require.Len(t, notifications, 1)
+		assert.Equal(t, "👍", *notifications[0].Payload.Emoji)
+
+		err = reactionRepo.RemoveReaction(ctx, post.ID, fan.ID, "👍")
+		require.NoError(t, err)
+		assert.Empty(t, getTestNotifications(t, author.ID, false))
+	})
+
+	t.Run("should notify followees and reporters", func(t *testing.T) {
+		cleanupTestDatabase()
+		author := createTestAuthor(t, "author@example.com")
+		follower := createTestAuthor(t, "follower@example.com")
+		moderator := createTestAuthor(t, "moderator@example.com")
+		post := createTestPost(t, author.ID, "post")
+
+		followRepo := getTestFollowRepo()
+		require.NoError(t, followRepo.Follow(ctx, follower.ID, author.ID))
+		notifications := getTestNotifications(t, author.ID, false)
+		require.Len(t, notifications, 1)
+		assert.Equal(t, models.NotificationTypeFollow, notifications[0].Type)
+		require.NoError(t, followRepo.Unfollow(ctx, follower.ID, author.ID))
+		assert.Empty(t, getTestNotifications(t, author.ID, false))
+
+		reportRepo := getTestReportRepo()
+		report, err := reportRepo.CreateReport(ctx, models.ReportCreate{
+			PostId:     post.ID,
+			Reason:     models.ReportReasonSpam,
+			ReporterId: &follower.ID,
+		})
+		require.NoError(t, err)
+		_, err = reportRepo.ClaimReport(ctx, report.ID, moderator.ID)
+		require.NoError(t, err)
+		_, err = reportRepo.ResolveReport(
+			ctx,
+			report.ID,
+			moderator.ID,
+			models.ReportActionHidePost,
+		)
+		require.NoError(t, err)
+
+		notifications = getTestNotifications(t, follower.ID, false)
+		require.Len(t, notifications, 1)
+		moderation := notifications[0]
+		assert.Equal(t, models.NotificationTypeModeration, moderation.Type)
+		assert.Empty(t, moderation.ActorIds)
+		assert.Equal(t, report.ID, *moderation.Payload.ReportId)
+		assert.Equal(t, models.ReportActionHidePost, *moderation.Payload.Action)
+	})
+
+	t.Run("should paginate groups with a cursor", func(t *testing.T) {
+		cleanupTestDatabase()
+		author := createTestAuthor(t, "author@example.com")
+		fan := createTestAuthor(t, "fan@example.com")
+		var posts []*models.Post
+		for _, title := range []string{"first", "second", "third"} {
+			post := createTestPost(t, author.ID, title)
+			posts = append(posts, post)
+			err := reactionRepo.AddReaction(ctx, post.ID, fan.ID, "👍")
+			require.NoError(t, err)
+		}
+
+		var postIds []string
+		var cursor *models.NotificationCursor
+		for {
+			page, err := getTestNotificationRepo().GetNotifications(
+				ctx,
+				models.NotificationListParams{
+					Cursor: cursor,
+					Limit:  2,
+					UserId: author.ID,
+				},
+			)
+			require.NoError(t, err)
+			for _, notification := range page {
+				postIds = append(postIds, *notification.PostId)
+			}
+			if len(page) < 2 {
+				break
+			}
+			last := page[len(page)-1]
+			cursor = &models.NotificationCursor{
+				CreatedAt: last.CreatedAt,
+				Id:        last.ID,
+			}
+		}
+		assert.Equal(t, []string{posts[2].ID, posts[1].ID, posts[0].ID}, postIds)
+	})
+
+	t.Run("should mark notifications read", func(t *testing.T) {
+		cleanupTestDatabase()
+		author := createTestAuthor(t, "author@example.com")
+		fan := createTestAuthor(t, "fan@example.com")
+		late := createTestAuthor(t, "late@example.com")
+		post := createTestPost(t, author.ID, "post")
+		other := createTestPost(t, author.ID, "other")
+		notificationRepo := getTestNotificationRepo()
+
+		require.NoError(t, reactionRepo.AddReaction(ctx, post.ID, fan.ID, "👍"))
+		require.NoError(t, reactionRepo.AddReaction(ctx, other.ID, fan.ID, "👍"))
+		count, err := notificationRepo.CountUnreadNotifications(ctx, author.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		notifications := getTestNotifications(t, author.ID, false)
+		require.Len(t, notifications, 2)
+		read := notifications[1]
+		require.NoError(t, reactionRepo.AddReaction(ctx, post.ID, late.ID, "👍"))
+
+		// Notifications after the one marked read stay unread.
+		err = notificationRepo.MarkNotificationRead(ctx, author.ID, read.ID)
+		require.NoError(t, err)
+		notifications = getTestNotifications(t, author.ID, false)
+		require.Len(t, notifications, 2)
+		assert.False(t, notifications[0].IsRead)
+		assert.Equal(t, post.ID, *notifications[0].PostId)
+		assert.Len(t, getTestNotifications(t, author.ID, true), 2)
+
+		err = notificationRepo.MarkNotificationRead(ctx, fan.ID, read.ID)
+		assert.ErrorIs(t, err, ErrNotificationNotFound)
+
+		err = notificationRepo.MarkAllNotificationsRead(ctx, author.ID)
+		require.NoError(t, err)
+		count, err = notificationRepo.CountUnreadNotifications(ctx, author.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+		assert.Empty(t, getTestNotifications(t, author.ID, true))
+		notifications = getTestNotifications(t, author.ID, false)
+		require.Len(t, notifications, 2)
+		assert.True(t, notifications[0].IsRead)
+	})
+}
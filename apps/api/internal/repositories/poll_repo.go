@@ -0,0 +1,287 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"apps/api/internal/models"
+)
+
+var (
+	ErrPollNotFound       = errors.New("Poll not found")
+	ErrPollClosed         = errors.New("Poll is closed")
+	ErrPollAlreadyVoted   = errors.New("User already voted in poll")
+	ErrPollInvalidOptions = errors.New("Invalid poll options")
+)
+
+type PollRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewPollRepo(db *pgxpool.Pool) *PollRepo {
+	return &PollRepo{db: db}
+}
+
+var pollStruct = sqlbuilder.NewStruct(new(models.Poll)).
+	For(sqlbuilder.PostgreSQL)
+
+var pollOptionStruct = sqlbuilder.NewStruct(new(models.PollOption)).
+	For(sqlbuilder.PostgreSQL)
+
+// GetPoll returns the poll of the post with the votes of viewerId.
+// ErrPollNotFound is returned when the post has no poll.
+func (r *PollRepo) GetPoll(
+	ctx context.Context,
+	viewerId string,
+	postId string,
+) (*models.Poll, error) {
+	polls, err := getPolls(ctx, r.db, viewerId, []string{postId})
+	if err != nil {
+		return nil, err
+	}
+	poll, ok := polls[postId]
+	if !ok {
+		return nil, fmt.Errorf("Failed to get poll: %w", ErrPollNotFound)
+	}
+
+	return poll, nil
+}
+
+// Vote records the vote of the user for the options of the poll of the post.
+// Votes on a poll are serialized by locking the poll, so that a user cannot
+// vote twice and the counters stay exact under concurrent votes. Users vote
+// once, for a single option unless the poll is multiple choice.
+func (r *PollRepo) Vote(
+	ctx context.Context,
+	postId string,
+	userId string,
+	optionIds []string,
+) error {
+	optionIds = slices.Compact(slices.Sorted(slices.Values(optionIds)))
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var multipleChoice, closed bool
+	err = tx.QueryRow(
+		ctx,
+		`SELECT multiple_choice, closes_at <= NOW()
+		FROM polls
+		WHERE post_id = $1
+		FOR UPDATE`,
+		postId,
+	).Scan(&multipleChoice, &closed)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("Failed to vote: %w", ErrPollNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to vote: %w", err)
+	}
+	if closed {
+		return fmt.Errorf("Failed to vote: %w", ErrPollClosed)
+	}
+	if len(optionIds) == 0 || (!multipleChoice && len(optionIds) > 1) {
+		return fmt.Errorf("Failed to vote: %w", ErrPollInvalidOptions)
+	}
+
+	var voted bool
+	err = tx.QueryRow(
+		ctx,
+		`SELECT EXISTS (
+			SELECT 1 FROM poll_votes WHERE post_id = $1 AND user_id = $2
+		)`,
+		postId,
+		userId,
+	).Scan(&voted)
+	if err != nil {
+		return fmt.Errorf("Failed to vote: %w", err)
+	}
+	if voted {
+		return fmt.Errorf("Failed to vote: %w", ErrPollAlreadyVoted)
+	}
+
+	tag, err := tx.Exec(
+		ctx,
+		`UPDATE poll_options SET votes_count = votes_count + 1
+		WHERE post_id = $1 AND id = ANY($2::uuid[])`,
+		postId,
+		optionIds,
+	)
+	if err != nil {
+		return fmt.Errorf("Failed to vote: %w", err)
+	}
+	if tag.RowsAffected() != int64(len(optionIds)) {
+		return fmt.Errorf("Failed to vote: %w", ErrPollInvalidOptions)
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`INSERT INTO poll_votes (post_id, user_id, option_id)
+		SELECT $1, $2, option_id FROM unnest($3::uuid[]) AS option_id`,
+		postId,
+		userId,
+		optionIds,
+	)
+	if err != nil {
+		return fmt.Errorf("Failed to vote: %w", err)
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`UPDATE polls SET voters_count = voters_count + 1 WHERE post_id = $1`,
+		postId,
+	)
+	if err != nil {
+		return fmt.Errorf("Failed to vote: %w", err)
+	}
+	if err := touchPosts(ctx, tx, "id = $1", postId); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("Failed to vote: %w", err)
+	}
+
+	return nil
+}
+
+// insertPoll attaches a poll with the options in the given order to the
+// post.
+func insertPoll(
+	ctx context.Context,
+	tx pgx.Tx,
+	postId string,
+	params *models.PollCreate,
+) error {
+	ib := sqlbuilder.PostgreSQL.NewInsertBuilder()
+	ib.InsertInto("polls")
+	ib.Cols("post_id", "multiple_choice", "closes_at")
+	ib.Values(postId, params.MultipleChoice, params.ClosesAt)
+	sql, args := ib.Build()
+
+	if _, err := tx.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("Failed to create poll: %w", err)
+	}
+
+	_, err := tx.Exec(
+		ctx,
+		`INSERT INTO poll_options (post_id, position, text)
+		SELECT $1, options.position, options.text
+		FROM unnest($2::text[]) WITH ORDINALITY AS options(text, position)`,
+		postId,
+		params.Options,
+	)
+	if err != nil {
+		return fmt.Errorf("Failed to create poll options: %w", err)
+	}
+
+	return nil
+}
+
+// getPolls returns the polls of the posts by post id, with their options in
+// order and the votes of viewerId.
+func getPolls(
+	ctx context.Context,
+	db *pgxpool.Pool,
+	viewerId string,
+	postIds []string,
+) (map[string]*models.Poll, error) {
+	polls := map[string]*models.Poll{}
+	if len(postIds) == 0 {
+		return polls, nil
+	}
+	ids := make([]any, len(postIds))
+	for i, id := range postIds {
+		ids[i] = id
+	}
+
+	sb := pollStruct.SelectFrom("polls")
+	sb.Where(sb.In("post_id", ids...))
+	sql, args := sb.Build()
+
+	rows, err := db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query polls: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var poll models.Poll
+		if err := rows.Scan(pollStruct.Addr(&poll)...); err != nil {
+			return nil, fmt.Errorf("Failed to scan poll: %w", err)
+		}
+		poll.Options = []*models.PollOption{}
+		poll.MyVotes = []string{}
+		polls[poll.PostId] = &poll
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Failed to read polls: %w", err)
+	}
+	if len(polls) == 0 {
+		return polls, nil
+	}
+
+	ob := pollOptionStruct.SelectFrom("poll_options")
+	ob.Where(ob.In("post_id", ids...))
+	ob.OrderBy("post_id", "position")
+	sql, args = ob.Build()
+
+	rows, err = db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query poll options: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var option models.PollOption
+		if err := rows.Scan(pollOptionStruct.Addr(&option)...); err != nil {
+			return nil, fmt.Errorf("Failed to scan poll option: %w", err)
+		}
+		poll := polls[option.PostId]
+		poll.Options = append(poll.Options, &option)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Failed to read poll options: %w", err)
+	}
+
+	if viewerId == "" {
+		return polls, nil
+	}
+
+	rows, err = db.Query(
+		ctx,
+		`SELECT post_id, array_agg(option_id ORDER BY option_id)
+		FROM poll_votes
+		WHERE user_id = $1 AND post_id = ANY($2::uuid[])
+		GROUP BY post_id`,
+		viewerId,
+		postIds,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query poll votes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var postId string
+		var optionIds []string
+		if err := rows.Scan(&postId, &optionIds); err != nil {
+			return nil, fmt.Errorf("Failed to scan poll votes: %w", err)
+		}
+		polls[postId].MyVotes = optionIds
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Failed to read poll votes: %w", err)
+	}
+
+	return polls, nil
+}
@@ -0,0 +1,134 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"apps/api/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestPoll(
+	t *testing.T,
+	authorId string,
+	multipleChoice bool,
+	closesAt time.Time,
+) *models.Post {
+	post, err := getTestPostRepo().CreatePost(
+		context.Background(),
+		models.PostCreate{
+			AuthorId: authorId,
+			Content:  "content of poll",
+			Poll: &models.PollCreate{
+				ClosesAt:       closesAt,
+				MultipleChoice: multipleChoice,
+				Options:        []string{"first", "second", "third"},
+			},
+			Title: "poll",
+		},
+	)
+	require.NoError(t, err)
+	require.NotNil(t, post.Poll)
+	require.Len(t, post.Poll.Options, 3)
+	return post
+}
+
+func TestPollRepo_Vote(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should count votes once per user", func(t *testing.T) {
+		cleanupTestDatabase()
+		pollRepo := NewPollRepo(testDbService.GetDB())
+		author := createTestAuthor(t, "author@example.com")
+		voter := createTestAuthor(t, "voter@example.com")
+		post := createTestPoll(t, author.ID, true, time.Now().Add(time.Hour))
+		options := post.Poll.Options
+
+		poll, err := pollRepo.GetPoll(ctx, voter.ID, post.ID)
+		require.NoError(t, err)
+		assert.False(t, poll.ResultsVisible())
+
+		err = pollRepo.Vote(
+			ctx,
+			post.ID,
+			voter.ID,
+			[]string{options[0].ID, options[2].ID, options[0].ID},
+		)
+		require.NoError(t, err)
+
+		err = pollRepo.Vote(ctx, post.ID, voter.ID, []string{options[1].ID})
+		assert.ErrorIs(t, err, ErrPollAlreadyVoted)
+
+		poll, err = pollRepo.GetPoll(ctx, voter.ID, post.ID)
+		require.NoError(t, err)
+		assert.True(t, poll.ResultsVisible())
+		assert.Equal(t, 1, poll.VotersCount)
+		assert.Equal(t, 1, poll.Options[0].VotesCount)
+		assert.Equal(t, 0, poll.Options[1].VotesCount)
+		assert.Equal(t, 1, poll.Options[2].VotesCount)
+		assert.ElementsMatch(
+			t,
+			[]string{options[0].ID, options[2].ID},
+			poll.MyVotes,
+		)
+
+		poll, err = pollRepo.GetPoll(ctx, author.ID, post.ID)
+		require.NoError(t, err)
+		assert.Empty(t, poll.MyVotes)
+		assert.False(t, poll.ResultsVisible())
+	})
+
+	t.Run("should accept one option of single choice polls", func(t *testing.T) {
+		cleanupTestDatabase()
+		pollRepo := NewPollRepo(testDbService.GetDB())
+		author := createTestAuthor(t, "author@example.com")
+		voter := createTestAuthor(t, "voter@example.com")
+		post := createTestPoll(t, author.ID, false, time.Now().Add(time.Hour))
+		options := post.Poll.Options
+
+		err := pollRepo.Vote(
+			ctx,
+			post.ID,
+			voter.ID,
+			[]string{options[0].ID, options[1].ID},
+		)
+		assert.ErrorIs(t, err, ErrPollInvalidOptions)
+
+		err = pollRepo.Vote(ctx, post.ID, voter.ID, []string{options[1].ID})
+		require.NoError(t, err)
+	})
+
+	t.Run("should reject votes on closed polls", func(t *testing.T) {
+		cleanupTestDatabase()
+		pollRepo := NewPollRepo(testDbService.GetDB())
+		author := createTestAuthor(t, "author@example.com")
+		voter := createTestAuthor(t, "voter@example.com")
+		post := createTestPoll(t, author.ID, false, time.Now().Add(-time.Hour))
+
+		err := pollRepo.Vote(
+			ctx,
+			post.ID,
+			voter.ID,
+			[]string{post.Poll.Options[0].ID},
+		)
+		assert.ErrorIs(t, err, ErrPollClosed)
+
+		poll, err := pollRepo.GetPoll(ctx, voter.ID, post.ID)
+		require.NoError(t, err)
+		assert.True(t, poll.ResultsVisible())
+		assert.Equal(t, 0, poll.VotersCount)
+	})
+
+	t.Run("should report posts without poll", func(t *testing.T) {
+		cleanupTestDatabase()
+		pollRepo := NewPollRepo(testDbService.GetDB())
+		author := createTestAuthor(t, "author@example.com")
+		post := createTestPost(t, author.ID, "no poll")
+
+		_, err := pollRepo.GetPoll(ctx, author.ID, post.ID)
+		assert.ErrorIs(t, err, ErrPollNotFound)
+	})
+}
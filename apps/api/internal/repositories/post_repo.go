@@ -2,16 +2,32 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/huandu/go-sqlbuilder"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"apps/api/internal/models"
 	"apps/api/internal/utils"
 )
 
+var (
+	// ErrPostVersionMismatch is returned when a post is changed with an
+	// expected version that is no longer current.
+	ErrPostVersionMismatch = errors.New("Post version mismatch")
+	// ErrPinLimitReached is returned when a user pins more posts than
+	// allowed.
+	ErrPinLimitReached = errors.New("Pinned posts limit reached")
+	// ErrPostNotFound is returned when the post does not exist or does not
+	// belong to the user changing it.
+	ErrPostNotFound = errors.New("Post not found")
+)
+
 type PostRepo struct {
 	db *pgxpool.Pool
 }
@@ -23,53 +39,156 @@ func NewPostRepo(db *pgxpool.Pool) *PostRepo {
 var postStruct = sqlbuilder.NewStruct(new(models.Post)).
 	For(sqlbuilder.PostgreSQL)
 
+// postReadScope tells filterPosts whether posts are read one at a time,
+// listed, or listed on the profile of their author.
+type postReadScope int
+
+const (
+	postReadSingle postReadScope = iota
+	postReadList
+	postReadProfile
+)
+
 func (r *PostRepo) CreatePost(
 	ctx context.Context,
 	params models.PostCreate,
 ) (*models.Post, error) {
+	visibility := params.Visibility
+	if visibility == "" {
+		visibility = models.PostVisibilityPublic
+	}
+	contentFormat := params.ContentFormat
+	if contentFormat == "" {
+		contentFormat = models.ContentFormatPlain
+	}
+
 	ib := sqlbuilder.PostgreSQL.NewInsertBuilder()
 	ib.InsertInto("posts")
-	ib.Cols("author_id", "content", "title")
+	ib.Cols(
+		"author_id",
+		"content",
+		"content_format",
+		"content_html",
+		"quoted_post_id",
+		"title",
+		"visibility",
+	)
 	ib.Values(
 		params.AuthorId,
 		params.Content,
+		contentFormat,
+		params.ContentHtml,
+		params.QuotedPostId,
 		params.Title,
+		visibility,
 	)
 	ib.Returning(strings.Join(postStruct.Columns(), ","))
 	sql, args := ib.Build()
-	fmt.Printf("SQL: %s, Args: %v\n", sql, args)
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
 
 	var post models.Post
-	err := r.db.QueryRow(ctx, sql, args...).Scan(postStruct.Addr(&post)...)
+	err = tx.QueryRow(ctx, sql, args...).Scan(postStruct.Addr(&post)...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create post: %w", err)
+	}
+
+	if err := insertPostRevision(ctx, tx, &post, params.AuthorId); err != nil {
+		return nil, err
+	}
+
+	if err := setPostTags(ctx, tx, &post, params.Tags); err != nil {
+		return nil, err
+	}
+
+	err = attachMedia(ctx, tx, post.ID, params.AuthorId, params.MediaIds)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := setPostLinks(ctx, tx, &post); err != nil {
+		return nil, err
+	}
+
+	post.Mentions, err = setMentions(
+		ctx,
+		tx,
+		post.AuthorId,
+		post.ID,
+		nil,
+		post.Content,
+	)
 	if err != nil {
+		return nil, err
+	}
+
+	if params.Poll != nil {
+		if err := insertPoll(ctx, tx, post.ID, params.Poll); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
 		return nil, fmt.Errorf("Failed to create post: %w", err)
 	}
+
+	err = r.loadPostRelations(ctx, params.AuthorId, []*models.Post{&post})
+	if err != nil {
+		return nil, err
+	}
+
 	return &post, nil
 }
 
+// DeletePost moves the post to the trash and unpins it. When expectedVersion
+// is set the post is only deleted if its version still matches, otherwise
+// ErrPostVersionMismatch is returned.
 func (r *PostRepo) DeletePost(
 	ctx context.Context,
 	id string,
+	expectedVersion *int,
 ) error {
-	db := sqlbuilder.PostgreSQL.NewDeleteBuilder()
-	db.DeleteFrom("posts")
-	db.Where(db.Equal("id", id))
-	sql, args := db.Build()
+	ub := sqlbuilder.PostgreSQL.NewUpdateBuilder()
+	ub.Update("posts")
+	ub.Set(
+		"deleted_at = NOW()",
+		"pinned_position = NULL",
+		"updated_at = NOW()",
+	)
+	ub.Where(ub.Equal("id", id), ub.IsNull("deleted_at"))
+	if expectedVersion != nil {
+		ub.Where(ub.Equal("version", *expectedVersion))
+	}
+	sql, args := ub.Build()
 
-	_, err := r.db.Exec(ctx, sql, args...)
+	tag, err := r.db.Exec(ctx, sql, args...)
 	if err != nil {
 		return fmt.Errorf("Failed to delete post: %w", err)
 	}
+	if expectedVersion != nil && tag.RowsAffected() == 0 {
+		return fmt.Errorf(
+			"Failed to delete post: %w",
+			ErrPostVersionMismatch,
+		)
+	}
 
 	return nil
 }
 
+// GetPostById returns the post as seen by viewerId, including the reactions
+// the viewer left on it.
 func (r *PostRepo) GetPostById(
 	ctx context.Context,
+	viewerId string,
 	id string,
 ) (*models.Post, error) {
 	sb := postStruct.SelectFrom("posts")
-	sb.Where(sb.Equal("id", id))
+	sb.Where(sb.Equal("posts.id", id))
+	filterPosts(sb, viewerId, postReadSingle)
 	sql, args := sb.Build()
 
 	var post models.Post
@@ -78,67 +197,1397 @@ func (r *PostRepo) GetPostById(
 		return nil, fmt.Errorf("Failed to get post by id: %w", err)
 	}
 
+	err = r.loadPostRelations(ctx, viewerId, []*models.Post{&post})
+	if err != nil {
+		return nil, err
+	}
+
 	return &post, nil
 }
 
+// GetPostModifiedAt returns when the post as seen by viewerId last changed:
+// the latest updated_at of the post, of the post it quotes and of the viewer,
+// whose follows, blocks and mutes decide whether the quoted post is shown.
+// The quoted post is included even when it is no longer shown, as deleting
+// or hiding it touches it. The zero time is returned when the post does not
+// exist.
+func (r *PostRepo) GetPostModifiedAt(
+	ctx context.Context,
+	viewerId string,
+	id string,
+) (time.Time, error) {
+	var modifiedAt time.Time
+	err := r.db.QueryRow(
+		ctx,
+		`SELECT GREATEST(
+			posts.updated_at,
+			quoted.updated_at,
+			(SELECT updated_at FROM users WHERE id = $1)
+		)
+		FROM posts
+		LEFT JOIN posts AS quoted ON quoted.id = posts.quoted_post_id
+		WHERE posts.id = $2`,
+		viewerId,
+		id,
+	).Scan(&modifiedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf(
+			"Failed to get post modification time: %w",
+			err,
+		)
+	}
+
+	return modifiedAt, nil
+}
+
+// GetBookmarkedPosts returns a page of the posts bookmarked by userId, most
+// recently bookmarked first. Bookmarks of posts in the trash are skipped.
+func (r *PostRepo) GetBookmarkedPosts(
+	ctx context.Context,
+	userId string,
+	cursor *models.BookmarkCursor,
+	limit int,
+) ([]*models.Post, error) {
+	sb := postStruct.SelectFrom("posts")
+	sb.SelectMore("bookmarks.created_at")
+	sb.Join("bookmarks", "bookmarks.post_id = posts.id")
+	sb.Where(sb.Equal("bookmarks.user_id", userId))
+	filterPosts(sb, userId, postReadSingle)
+	if cursor != nil {
+		sb.Where(fmt.Sprintf(
+			"(bookmarks.created_at, bookmarks.post_id) < (%s, %s)",
+			sb.Var(cursor.CreatedAt),
+			sb.Var(cursor.PostId),
+		))
+	}
+	sb.OrderBy("bookmarks.created_at DESC", "bookmarks.post_id DESC")
+	sb.Limit(limit)
+	sql, args := sb.Build()
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query bookmarked posts: %w", err)
+	}
+	defer rows.Close()
+
+	var posts []*models.Post
+	var bookmarkedAt []time.Time
+	for rows.Next() {
+		var post models.Post
+		var createdAt time.Time
+		err := rows.Scan(append(postStruct.Addr(&post), &createdAt)...)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to scan bookmarked post: %w", err)
+		}
+		posts = append(posts, &post)
+		bookmarkedAt = append(bookmarkedAt, createdAt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Failed to read bookmarked posts: %w", err)
+	}
+
+	if err := r.loadPostRelations(ctx, userId, posts); err != nil {
+		return nil, err
+	}
+	// The page is ordered by the bookmark times it was read with, which are
+	// kept even if a bookmark was removed in the meantime.
+	for i, post := range posts {
+		post.BookmarkedAt = &bookmarkedAt[i]
+	}
+
+	return posts, nil
+}
+
 func (r *PostRepo) GetPosts(
 	ctx context.Context,
+	viewerId string,
 	limit int,
 	offset int,
 ) ([]*models.Post, int, error) {
 	sb := postStruct.SelectFrom("posts")
-	sb.OrderBy("created_at").Desc()
+	filterPosts(sb, viewerId, postReadList)
+	sb.OrderBy("posts.created_at").Desc()
 	sb.Limit(limit)
 	sb.Offset(offset)
 	sql, args := sb.Build()
 
+	posts, err := r.queryPosts(ctx, sql, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := r.loadPostRelations(ctx, viewerId, posts); err != nil {
+		return nil, 0, err
+	}
+
+	cb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	cb.Select("COUNT(*)").From("posts")
+	filterPosts(cb, viewerId, postReadList)
+	sql, args = cb.Build()
+
+	var total int
+	err = r.db.QueryRow(ctx, sql, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("Failed to count posts: %w", err)
+	}
+
+	return posts, total, nil
+}
+
+// GetPostsModifiedAt returns when the list of posts seen by viewerId last
+// changed: the latest updated_at of all posts, including the ones in the
+// trash or hidden since, and of the viewer, whose follows, blocks and mutes
+// filter the list.
+func (r *PostRepo) GetPostsModifiedAt(
+	ctx context.Context,
+	viewerId string,
+) (time.Time, error) {
+	var modifiedAt time.Time
+	err := r.db.QueryRow(
+		ctx,
+		`SELECT GREATEST(
+			(SELECT MAX(updated_at) FROM posts),
+			(SELECT updated_at FROM users WHERE id = $1)
+		)`,
+		viewerId,
+	).Scan(&modifiedAt)
+	if err != nil {
+		return time.Time{}, fmt.Errorf(
+			"Failed to get posts modification time: %w",
+			err,
+		)
+	}
+
+	return modifiedAt, nil
+}
+
+// GetFeed returns a page of the home timeline of viewerId, made of the posts
+// of the users they follow and their own posts, and of the posts reposted by
+// them, newest activity first. The timeline is assembled on read: the latest
+// posts and reposts of every author are read from the author indexes and
+// merged, so the cost grows with the number of followed users rather than
+// with the total number of posts.
+//
+// A post shows up once in the timeline, at its latest activity: reposts of
+// a post shown on the page or on an earlier page are left out, and so is the
+// post itself when it was reposted after it was written.
+func (r *PostRepo) GetFeed(
+	ctx context.Context,
+	viewerId string,
+	cursor *models.PostCursor,
+	limit int,
+) ([]*models.Post, error) {
+	pb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	pb.Select(
+		"posts.id AS post_id",
+		"NULL::uuid AS reposted_by",
+		"posts.created_at AS activity_at",
+	)
+	pb.From("posts")
+	pb.Where("posts.author_id = authors.author_id")
+	filterPosts(pb, viewerId, postReadList)
+	if cursor != nil {
+		pb.Where(
+			fmt.Sprintf(
+				"(posts.created_at, posts.id) < (%s, %s)",
+				pb.Var(cursor.CreatedAt),
+				pb.Var(cursor.Id),
+			),
+			feedUnseenCondition(pb, viewerId, cursor),
+		)
+	}
+	pb.OrderBy("posts.created_at DESC", "posts.id DESC")
+	pb.Limit(limit)
+
+	rb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	rb.Select("reposts.post_id", "reposts.user_id", "reposts.created_at")
+	rb.From("reposts")
+	rb.Join("posts", "posts.id = reposts.post_id")
+	rb.Where("reposts.user_id = authors.author_id")
+	filterPosts(rb, viewerId, postReadList)
+	rb.Where(notMutedCondition(rb.Var(viewerId), "reposts.user_id"))
+	if cursor != nil {
+		rb.Where(
+			fmt.Sprintf(
+				"(reposts.created_at, reposts.post_id) < (%s, %s)",
+				rb.Var(cursor.CreatedAt),
+				rb.Var(cursor.Id),
+			),
+			feedUnseenCondition(rb, viewerId, cursor),
+		)
+	}
+	rb.OrderBy("reposts.created_at DESC", "reposts.post_id DESC")
+	rb.Limit(limit)
+
+	sb := postStruct.SelectFrom("posts")
+	sb.SelectMore("feed.reposted_by", "feed.activity_at")
+	sb.Join(
+		fmt.Sprintf(
+			`(
+				SELECT DISTINCT ON (activity.post_id) activity.*
+				FROM (
+					SELECT followee_id AS author_id FROM follows
+					WHERE follower_id = %[1]s
+					UNION ALL
+					SELECT %[1]s::uuid
+				) AS authors
+				JOIN LATERAL (
+					(%[2]s) UNION ALL (%[3]s)
+				) AS activity ON TRUE
+				ORDER BY activity.post_id, activity.activity_at DESC
+			) AS feed`,
+			sb.Var(viewerId),
+			sb.Var(pb),
+			sb.Var(rb),
+		),
+		"feed.post_id = posts.id",
+	)
+	sb.OrderBy("feed.activity_at DESC", "feed.post_id DESC")
+	sb.Limit(limit)
+	sql, args := sb.Build()
+
 	rows, err := r.db.Query(ctx, sql, args...)
 	if err != nil {
-		return nil, 0, fmt.Errorf("Failed to query posts by author_id: %w", err)
+		return nil, fmt.Errorf("Failed to query feed: %w", err)
 	}
 	defer rows.Close()
 
 	var posts []*models.Post
 	for rows.Next() {
 		var post models.Post
-		err := rows.Scan(postStruct.Addr(&post)...)
+		var repostedBy *string
+		var activityAt time.Time
+		err := rows.Scan(
+			append(postStruct.Addr(&post), &repostedBy, &activityAt)...,
+		)
 		if err != nil {
-			return nil, 0, fmt.Errorf("Failed to scan post: %w", err)
+			return nil, fmt.Errorf("Failed to scan feed post: %w", err)
 		}
 		posts = append(posts, &post)
+		if repostedBy != nil {
+			post.Repost = &models.PostRepost{
+				UserId:    *repostedBy,
+				CreatedAt: activityAt,
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Failed to read feed: %w", err)
+	}
+
+	if err := r.loadPostRelations(ctx, viewerId, posts); err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
+
+// GetUserPosts returns a page of the posts of userId, the posts pinned to
+// their profile first in pinned order, then the other posts newest first.
+func (r *PostRepo) GetUserPosts(
+	ctx context.Context,
+	viewerId string,
+	userId string,
+	cursor *models.UserPostCursor,
+	limit int,
+) ([]*models.Post, error) {
+	sb := postStruct.SelectFrom("posts")
+	sb.Where(sb.Equal("posts.author_id", userId))
+	filterPosts(sb, viewerId, postReadProfile)
+	if cursor != nil && cursor.PinnedPosition != nil {
+		sb.Where(fmt.Sprintf(
+			`(
+				(posts.pinned_position, posts.id) > (%s, %s)
+				OR posts.pinned_position IS NULL
+			)`,
+			sb.Var(*cursor.PinnedPosition),
+			sb.Var(cursor.Id),
+		))
+	} else if cursor != nil {
+		sb.Where(
+			sb.IsNull("posts.pinned_position"),
+			fmt.Sprintf(
+				"(posts.created_at, posts.id) < (%s, %s)",
+				sb.Var(cursor.CreatedAt),
+				sb.Var(cursor.Id),
+			),
+		)
+	}
+	sb.OrderBy(
+		"posts.pinned_position ASC NULLS LAST",
+		"posts.created_at DESC",
+		"posts.id DESC",
+	)
+	sb.Limit(limit)
+	sql, args := sb.Build()
+
+	posts, err := r.queryPosts(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadPostRelations(ctx, viewerId, posts); err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
+
+// GetPostsByTag returns a page of the posts tagged with the tag, newest
+// first.
+func (r *PostRepo) GetPostsByTag(
+	ctx context.Context,
+	viewerId string,
+	tag string,
+	cursor *models.PostCursor,
+	limit int,
+) ([]*models.Post, error) {
+	sb := postStruct.SelectFrom("posts")
+	sb.Join("post_tags", "post_tags.post_id = posts.id")
+	sb.Join("tags", "tags.id = post_tags.tag_id")
+	sb.Where(sb.Equal("tags.name", tag))
+	filterPosts(sb, viewerId, postReadList)
+	if cursor != nil {
+		sb.Where(fmt.Sprintf(
+			"(posts.created_at, posts.id) < (%s, %s)",
+			sb.Var(cursor.CreatedAt),
+			sb.Var(cursor.Id),
+		))
+	}
+	sb.OrderBy("posts.created_at DESC", "posts.id DESC")
+	sb.Limit(limit)
+	sql, args := sb.Build()
+
+	posts, err := r.queryPosts(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadPostRelations(ctx, viewerId, posts); err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
+
+// PinPost pins the post of userId to their profile. The post is moved to
+// position, counted from 1, among the pinned posts, or after the last one
+// when position is nil. Pinning a pinned post only moves it.
+// ErrPinLimitReached is returned when maxPinned posts are pinned already.
+func (r *PostRepo) PinPost(
+	ctx context.Context,
+	userId string,
+	id string,
+	position *int,
+	maxPinned int,
+) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Pins of a user are changed with their row locked, so that concurrent
+	// pins cannot exceed the limit.
+	if err := lockUsers(ctx, tx, userId, userId); err != nil {
+		return err
+	}
+
+	pinned, err := getPinnedPostIds(ctx, tx, userId)
+	if err != nil {
+		return err
+	}
+
+	index := slices.Index(pinned, id)
+	if index >= 0 {
+		pinned = slices.Delete(pinned, index, index+1)
+	} else if len(pinned) >= maxPinned {
+		return fmt.Errorf("Failed to pin post: %w", ErrPinLimitReached)
+	}
+
+	index = len(pinned)
+	if position != nil && *position-1 < index {
+		index = max(*position-1, 0)
+	}
+	pinned = slices.Insert(pinned, index, id)
+
+	tag, err := tx.Exec(
+		ctx,
+		`UPDATE posts
+		SET pinned_position = pins.position, updated_at = NOW()
+		FROM unnest($2::uuid[]) WITH ORDINALITY AS pins(id, position)
+		WHERE posts.id = pins.id
+			AND posts.author_id = $1
+			AND posts.deleted_at IS NULL`,
+		userId,
+		pinned,
+	)
+	if err != nil {
+		return fmt.Errorf("Failed to pin post: %w", err)
+	}
+	if tag.RowsAffected() != int64(len(pinned)) {
+		return fmt.Errorf("Failed to pin post: %w", pgx.ErrNoRows)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("Failed to pin post: %w", err)
+	}
+
+	return nil
+}
+
+// UnpinPost removes the post of userId from the pinned posts on their
+// profile, unpinning a post that is not pinned has no effect.
+func (r *PostRepo) UnpinPost(
+	ctx context.Context,
+	userId string,
+	id string,
+) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockUsers(ctx, tx, userId, userId); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`UPDATE posts SET pinned_position = NULL, updated_at = NOW()
+		WHERE id = $1 AND author_id = $2`,
+		id,
+		userId,
+	)
+	if err != nil {
+		return fmt.Errorf("Failed to unpin post: %w", err)
+	}
+
+	pinned, err := getPinnedPostIds(ctx, tx, userId)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(
+		ctx,
+		`UPDATE posts
+		SET pinned_position = pins.position, updated_at = NOW()
+		FROM unnest($1::uuid[]) WITH ORDINALITY AS pins(id, position)
+		WHERE posts.id = pins.id`,
+		pinned,
+	)
+	if err != nil {
+		return fmt.Errorf("Failed to unpin post: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("Failed to unpin post: %w", err)
+	}
+
+	return nil
+}
+
+// getPinnedPostIds returns the ids of the posts pinned by userId in pinned
+// order. Posts in the trash or hidden by moderators are left out.
+func getPinnedPostIds(
+	ctx context.Context,
+	tx pgx.Tx,
+	userId string,
+) ([]string, error) {
+	rows, err := tx.Query(
+		ctx,
+		`SELECT id FROM posts
+		WHERE author_id = $1
+			AND pinned_position IS NOT NULL
+			AND deleted_at IS NULL
+			AND hidden_at IS NULL
+		ORDER BY pinned_position, id`,
+		userId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query pinned posts: %w", err)
+	}
+
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read pinned posts: %w", err)
+	}
+
+	return ids, nil
+}
+
+// GetTrashedPostById returns a post in the trash. Posts removed by
+// moderators are not in the trash of their author.
+func (r *PostRepo) GetTrashedPostById(
+	ctx context.Context,
+	id string,
+) (*models.Post, error) {
+	sb := postStruct.SelectFrom("posts")
+	sb.Where(
+		sb.Equal("id", id),
+		sb.IsNotNull("deleted_at"),
+		sb.IsNull("hidden_at"),
+	)
+	sql, args := sb.Build()
+
+	var post models.Post
+	err := r.db.QueryRow(ctx, sql, args...).Scan(postStruct.Addr(&post)...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get trashed post by id: %w", err)
+	}
+
+	return &post, nil
+}
+
+// GetTrashedPosts returns a page of the posts of the author in the trash,
+// most recently deleted first.
+func (r *PostRepo) GetTrashedPosts(
+	ctx context.Context,
+	authorId string,
+	limit int,
+	offset int,
+) ([]*models.Post, int, error) {
+	sb := postStruct.SelectFrom("posts")
+	sb.Where(
+		sb.Equal("author_id", authorId),
+		sb.IsNotNull("deleted_at"),
+		sb.IsNull("hidden_at"),
+	)
+	sb.OrderBy("deleted_at").Desc()
+	sb.Limit(limit)
+	sb.Offset(offset)
+	sql, args := sb.Build()
+
+	posts, err := r.queryPosts(ctx, sql, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := r.loadPostRelations(ctx, authorId, posts); err != nil {
+		return nil, 0, err
 	}
 
+	cb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	cb.Select("COUNT(*)").From("posts")
+	cb.Where(
+		cb.Equal("author_id", authorId),
+		cb.IsNotNull("deleted_at"),
+		cb.IsNull("hidden_at"),
+	)
+	sql, args = cb.Build()
+
 	var total int
-	sql = `SELECT COUNT(*) FROM posts`
-	err = r.db.QueryRow(ctx, sql).Scan(&total)
+	err = r.db.QueryRow(ctx, sql, args...).Scan(&total)
 	if err != nil {
-		return nil, 0, fmt.Errorf("Failed to count posts: %w", err)
+		return nil, 0, fmt.Errorf("Failed to count trashed posts: %w", err)
 	}
 
 	return posts, total, nil
 }
 
-func (r *PostRepo) UpdatePost(
+// PurgeTrashedPosts permanently removes posts that were moved to the trash
+// before the given time and returns the number of removed rows. Posts
+// removed by moderators are kept together with their reports for the audit
+// log.
+func (r *PostRepo) PurgeTrashedPosts(
+	ctx context.Context,
+	deletedBefore time.Time,
+) (int64, error) {
+	db := sqlbuilder.PostgreSQL.NewDeleteBuilder()
+	db.DeleteFrom("posts")
+	db.Where(db.LessThan("deleted_at", deletedBefore), db.IsNull("hidden_at"))
+	sql, args := db.Build()
+
+	tag, err := r.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, fmt.Errorf("Failed to purge trashed posts: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// RestorePost moves the post of the author out of the trash. Posts removed
+// by moderators cannot be restored. ErrPostNotFound is returned when the
+// author has no such post in the trash.
+func (r *PostRepo) RestorePost(
 	ctx context.Context,
+	authorId string,
 	id string,
-	params models.PostUpdate,
 ) (*models.Post, error) {
-	ub := postStruct.WithoutTag("pk").Update("posts", models.Post{})
-	assignments := utils.GetNotNilAssignments(params, ub)
-	if len(assignments) == 0 {
-		return nil, fmt.Errorf("No fields to update")
-	}
-	ub.Set(assignments...)
-	ub.Where(ub.Equal("id", id))
+	ub := sqlbuilder.PostgreSQL.NewUpdateBuilder()
+	ub.Update("posts")
+	ub.Set("deleted_at = NULL", "updated_at = NOW()")
+	ub.Where(
+		ub.Equal("id", id),
+		ub.Equal("author_id", authorId),
+		ub.IsNotNull("deleted_at"),
+		ub.IsNull("hidden_at"),
+	)
 	ub.SQL("RETURNING " + strings.Join(postStruct.Columns(), ","))
 	sql, args := ub.Build()
 
 	var post models.Post
-	postRow := r.db.QueryRow(ctx, sql, args...)
-	err := postRow.Scan(postStruct.Addr(&post)...)
+	err := r.db.QueryRow(ctx, sql, args...).Scan(postStruct.Addr(&post)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("Failed to restore post: %w", ErrPostNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to restore post: %w", err)
+	}
+
+	err = r.loadPostRelations(ctx, authorId, []*models.Post{&post})
+	if err != nil {
+		return nil, err
+	}
+
+	return &post, nil
+}
+
+// UpdatePost applies the non-nil fields of params. The post version is only
+// bumped when a field actually changes, and only changes of the title,
+// content or content format mark the post as edited and are recorded as a
+// new revision of the post. Posts in the trash or hidden by moderators are
+// not found and ErrPostNotFound is returned. When expectedVersion is set the
+// post is only updated if its version still matches, otherwise
+// ErrPostVersionMismatch is returned.
+func (r *PostRepo) UpdatePost(
+	ctx context.Context,
+	id string,
+	editorId string,
+	params models.PostUpdate,
+	expectedVersion *int,
+) (*models.Post, error) {
+	ub := postStruct.WithoutTag("pk").Update("posts", models.Post{})
+	assignments := utils.GetNotNilAssignments(params, ub)
+	if len(assignments) == 0 && params.Tags == nil {
+		return nil, fmt.Errorf("No fields to update")
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// The row lock serializes updates of the post, so the version check and
+	// the numbering of revisions below cannot race.
+	sb := postStruct.SelectFrom("posts")
+	sb.Where(sb.Equal("id", id), sb.IsNull("deleted_at"), sb.IsNull("hidden_at"))
+	sb.ForUpdate()
+	sql, args := sb.Build()
+
+	var current models.Post
+	err = tx.QueryRow(ctx, sql, args...).Scan(postStruct.Addr(&current)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("Failed to update post: %w", ErrPostNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get post: %w", err)
+	}
+	if expectedVersion != nil && *expectedVersion != current.Version {
+		return nil, fmt.Errorf(
+			"Failed to update post: %w",
+			ErrPostVersionMismatch,
+		)
+	}
+
+	edited := (params.Title != nil && *params.Title != current.Title) ||
+		(params.Content != nil && *params.Content != current.Content) ||
+		(params.ContentFormat != nil &&
+			*params.ContentFormat != current.ContentFormat)
+	changed := edited ||
+		(params.AuthorId != nil && *params.AuthorId != current.AuthorId) ||
+		(params.ContentHtml != nil &&
+			*params.ContentHtml != current.ContentHtml) ||
+		(params.Visibility != nil && *params.Visibility != current.Visibility)
+	if !changed && params.Tags != nil {
+		explicitTags, err := getExplicitPostTags(ctx, tx, id)
+		if err != nil {
+			return nil, err
+		}
+		tags := utils.NormalizeTags(slices.Clone(*params.Tags))
+		slices.Sort(tags)
+		slices.Sort(explicitTags)
+		changed = !slices.Equal(tags, explicitTags)
+	}
+	if !changed {
+		err = r.loadPostRelations(ctx, editorId, []*models.Post{&current})
+		if err != nil {
+			return nil, err
+		}
+		return &current, nil
+	}
+
+	if edited {
+		assignments = append(assignments, "edited_at = NOW()")
+	}
+	assignments = append(
+		assignments,
+		"updated_at = NOW()",
+		"version = version + 1",
+	)
+	ub.Set(assignments...)
+	ub.Where(ub.Equal("id", id))
+	ub.SQL("RETURNING " + strings.Join(postStruct.Columns(), ","))
+	sql, args = ub.Build()
+
+	var post models.Post
+	err = tx.QueryRow(ctx, sql, args...).Scan(postStruct.Addr(&post)...)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to update post: %w", err)
 	}
 
+	if edited {
+		if err := insertPostRevision(ctx, tx, &post, editorId); err != nil {
+			return nil, err
+		}
+	}
+
+	if params.Tags != nil || params.Content != nil {
+		var explicitTags []string
+		if params.Tags != nil {
+			explicitTags = *params.Tags
+		} else {
+			explicitTags, err = getExplicitPostTags(ctx, tx, post.ID)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if err := setPostTags(ctx, tx, &post, explicitTags); err != nil {
+			return nil, err
+		}
+	}
+
+	if params.Content != nil {
+		if err := setPostLinks(ctx, tx, &post); err != nil {
+			return nil, err
+		}
+		_, err := setMentions(
+			ctx,
+			tx,
+			post.AuthorId,
+			post.ID,
+			nil,
+			post.Content,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("Failed to update post: %w", err)
+	}
+
+	err = r.loadPostRelations(ctx, editorId, []*models.Post{&post})
+	if err != nil {
+		return nil, err
+	}
+
 	return &post, nil
 }
+
+func (r *PostRepo) queryPosts(
+	ctx context.Context,
+	sql string,
+	args ...any,
+) ([]*models.Post, error) {
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query posts: %w", err)
+	}
+	defer rows.Close()
+
+	var posts []*models.Post
+	for rows.Next() {
+		var post models.Post
+		err := rows.Scan(postStruct.Addr(&post)...)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to scan post: %w", err)
+		}
+		posts = append(posts, &post)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Failed to read posts: %w", err)
+	}
+
+	return posts, nil
+}
+
+// HasDuplicatePost reports whether the author saved a post other than
+// excludePostId since the given time with the same value in field, which is
+// either title or content.
+func (r *PostRepo) HasDuplicatePost(
+	ctx context.Context,
+	authorId string,
+	excludePostId string,
+	field string,
+	value string,
+	since time.Time,
+) (bool, error) {
+	if field != "title" && field != "content" {
+		return false, fmt.Errorf("Failed to find duplicate posts: %q", field)
+	}
+
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select("1").From("posts")
+	sb.Where(
+		sb.Equal("author_id", authorId),
+		sb.IsNull("deleted_at"),
+		sb.GreaterEqualThan("created_at", since),
+		sb.Equal(field, value),
+	)
+	if excludePostId != "" {
+		sb.Where(sb.NotEqual("id", excludePostId))
+	}
+	sb.Limit(1)
+	sql, args := sb.Build()
+
+	var exists bool
+	err := r.db.QueryRow(
+		ctx,
+		"SELECT EXISTS ("+sql+")",
+		args...,
+	).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("Failed to find duplicate posts: %w", err)
+	}
+
+	return exists, nil
+}
+
+// filterPosts restricts sb to the posts viewerId may read. Posts in the
+// trash, posts hidden by moderators, posts of suspended users and posts of
+// users that blocked the viewer or were blocked by them are never readable,
+// unlisted posts are left out of lists and posts of users muted by the viewer
+// are left out of lists other than the profile of the user. Followers-only
+// posts are readable by their author and the followers of the author,
+// private posts by their author only.
+func filterPosts(
+	sb *sqlbuilder.SelectBuilder,
+	viewerId string,
+	scope postReadScope,
+) {
+	sb.Where(
+		sb.IsNull("posts.deleted_at"),
+		sb.IsNull("posts.hidden_at"),
+		`NOT EXISTS (
+			SELECT 1 FROM users
+			WHERE users.id = posts.author_id
+				AND users.suspended_at IS NOT NULL
+		)`,
+	)
+	if scope != postReadSingle {
+		sb.Where(sb.NotEqual("posts.visibility", models.PostVisibilityUnlisted))
+	}
+
+	if viewerId == "" {
+		sb.Where(sb.In(
+			"posts.visibility",
+			models.PostVisibilityPublic,
+			models.PostVisibilityUnlisted,
+		))
+		return
+	}
+
+	viewer := sb.Var(viewerId)
+	sb.Where(
+		fmt.Sprintf(
+			`(
+				posts.author_id = %[1]s
+				OR posts.visibility IN (%[2]s, %[3]s)
+				OR (
+					posts.visibility = %[4]s
+					AND EXISTS (
+						SELECT 1 FROM follows
+						WHERE follower_id = %[1]s
+							AND followee_id = posts.author_id
+					)
+				)
+			)`,
+			viewer,
+			sb.Var(models.PostVisibilityPublic),
+			sb.Var(models.PostVisibilityUnlisted),
+			sb.Var(models.PostVisibilityFollowers),
+		),
+		notBlockedCondition(viewer, "posts.author_id"),
+	)
+	if scope == postReadList {
+		sb.Where(notMutedCondition(viewer, "posts.author_id"))
+	}
+}
+
+// feedUnseenCondition restricts the posts of a feed page to posts without
+// activity of the authors of the feed at or after the cursor, which were
+// shown on an earlier page.
+func feedUnseenCondition(
+	sb *sqlbuilder.SelectBuilder,
+	viewerId string,
+	cursor *models.PostCursor,
+) string {
+	viewer := sb.Var(viewerId)
+	return fmt.Sprintf(
+		`NOT EXISTS (
+			SELECT 1 FROM posts AS seen
+			WHERE seen.id = posts.id
+				AND (seen.created_at, seen.id) >= (%[2]s, %[3]s)
+				AND (seen.author_id = %[1]s OR EXISTS (
+					SELECT 1 FROM follows
+					WHERE follower_id = %[1]s
+						AND followee_id = seen.author_id
+				))
+		)
+		AND NOT EXISTS (
+			SELECT 1 FROM reposts AS seen
+			WHERE seen.post_id = posts.id
+				AND (seen.created_at, seen.post_id) >= (%[2]s, %[3]s)
+				AND (seen.user_id = %[1]s OR EXISTS (
+					SELECT 1 FROM follows
+					WHERE follower_id = %[1]s
+						AND followee_id = seen.user_id
+				))
+				AND %[4]s
+		)`,
+		viewer,
+		sb.Var(cursor.CreatedAt),
+		sb.Var(cursor.Id),
+		notMutedCondition(viewer, "seen.user_id"),
+	)
+}
+
+// loadPostRelations fills the fields of the posts that are stored outside of
+// the posts table.
+func (r *PostRepo) loadPostRelations(
+	ctx context.Context,
+	viewerId string,
+	posts []*models.Post,
+) error {
+	if err := r.loadPostTags(ctx, posts); err != nil {
+		return err
+	}
+	if err := r.loadViewerState(ctx, viewerId, posts); err != nil {
+		return err
+	}
+	if err := r.loadPostMedia(ctx, posts); err != nil {
+		return err
+	}
+	if err := r.loadLinkPreviews(ctx, posts); err != nil {
+		return err
+	}
+	if err := r.loadMentions(ctx, posts); err != nil {
+		return err
+	}
+	if err := r.loadPolls(ctx, viewerId, posts); err != nil {
+		return err
+	}
+	return r.loadQuotedPosts(ctx, viewerId, posts)
+}
+
+func (r *PostRepo) loadPostMedia(
+	ctx context.Context,
+	posts []*models.Post,
+) error {
+	ids := make([]string, 0, len(posts))
+	for _, post := range posts {
+		ids = append(ids, post.ID)
+	}
+
+	media, err := getPostMedia(ctx, r.db, ids)
+	if err != nil {
+		return err
+	}
+	for _, post := range posts {
+		post.Media = media[post.ID]
+		if post.Media == nil {
+			post.Media = []*models.Media{}
+		}
+	}
+
+	return nil
+}
+
+func (r *PostRepo) loadLinkPreviews(
+	ctx context.Context,
+	posts []*models.Post,
+) error {
+	ids := make([]string, 0, len(posts))
+	for _, post := range posts {
+		ids = append(ids, post.ID)
+	}
+
+	previews, err := getPostLinkPreviews(ctx, r.db, ids)
+	if err != nil {
+		return err
+	}
+	for _, post := range posts {
+		post.LinkPreviews = previews[post.ID]
+		if post.LinkPreviews == nil {
+			post.LinkPreviews = []*models.LinkPreview{}
+		}
+	}
+
+	return nil
+}
+
+func (r *PostRepo) loadMentions(
+	ctx context.Context,
+	posts []*models.Post,
+) error {
+	ids := make([]string, 0, len(posts))
+	for _, post := range posts {
+		ids = append(ids, post.ID)
+	}
+
+	mentions, err := getPostMentions(ctx, r.db, ids)
+	if err != nil {
+		return err
+	}
+	for _, post := range posts {
+		post.Mentions = mentions[post.ID]
+		if post.Mentions == nil {
+			post.Mentions = []*models.Mention{}
+		}
+	}
+
+	return nil
+}
+
+func (r *PostRepo) loadPolls(
+	ctx context.Context,
+	viewerId string,
+	posts []*models.Post,
+) error {
+	ids := make([]string, 0, len(posts))
+	for _, post := range posts {
+		ids = append(ids, post.ID)
+	}
+
+	polls, err := getPolls(ctx, r.db, viewerId, ids)
+	if err != nil {
+		return err
+	}
+	for _, post := range posts {
+		post.Poll = polls[post.ID]
+	}
+
+	return nil
+}
+
+// loadQuotedPosts fills the quoted posts the viewer may read. Posts quoted
+// by quoted posts are not loaded.
+func (r *PostRepo) loadQuotedPosts(
+	ctx context.Context,
+	viewerId string,
+	posts []*models.Post,
+) error {
+	var ids []any
+	for _, post := range posts {
+		post.QuotedPost = nil
+		if post.QuotedPostId != nil {
+			ids = append(ids, *post.QuotedPostId)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	sb := postStruct.SelectFrom("posts")
+	sb.Where(sb.In("posts.id", ids...))
+	filterPosts(sb, viewerId, postReadSingle)
+	sql, args := sb.Build()
+
+	quoted, err := r.queryPosts(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	if err := r.loadPostTags(ctx, quoted); err != nil {
+		return err
+	}
+	if err := r.loadViewerState(ctx, viewerId, quoted); err != nil {
+		return err
+	}
+	if err := r.loadPostMedia(ctx, quoted); err != nil {
+		return err
+	}
+	if err := r.loadLinkPreviews(ctx, quoted); err != nil {
+		return err
+	}
+	if err := r.loadMentions(ctx, quoted); err != nil {
+		return err
+	}
+	if err := r.loadPolls(ctx, viewerId, quoted); err != nil {
+		return err
+	}
+
+	byId := make(map[string]*models.Post, len(quoted))
+	for _, post := range quoted {
+		byId[post.ID] = post
+	}
+	for _, post := range posts {
+		if post.QuotedPostId != nil {
+			post.QuotedPost = byId[*post.QuotedPostId]
+		}
+	}
+
+	return nil
+}
+
+func (r *PostRepo) loadPostTags(
+	ctx context.Context,
+	posts []*models.Post,
+) error {
+	ids := make([]string, 0, len(posts))
+	byId := make(map[string]*models.Post, len(posts))
+	for _, post := range posts {
+		post.Tags = []string{}
+		ids = append(ids, post.ID)
+		byId[post.ID] = post
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	rows, err := r.db.Query(
+		ctx,
+		`SELECT post_tags.post_id, array_agg(tags.name ORDER BY tags.name)
+		FROM post_tags
+		JOIN tags ON tags.id = post_tags.tag_id
+		WHERE post_tags.post_id = ANY($1::uuid[])
+		GROUP BY post_tags.post_id`,
+		ids,
+	)
+	if err != nil {
+		return fmt.Errorf("Failed to query post tags: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var postId string
+		var tags []string
+		if err := rows.Scan(&postId, &tags); err != nil {
+			return fmt.Errorf("Failed to scan post tags: %w", err)
+		}
+		byId[postId].Tags = tags
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("Failed to read post tags: %w", err)
+	}
+
+	return nil
+}
+
+// loadViewerState fills the fields of the posts that depend on the user
+// reading them.
+func (r *PostRepo) loadViewerState(
+	ctx context.Context,
+	viewerId string,
+	posts []*models.Post,
+) error {
+	ids := make([]string, 0, len(posts))
+	byId := make(map[string]*models.Post, len(posts))
+	for _, post := range posts {
+		post.BookmarkedAt = nil
+		post.MyReactions = []string{}
+		post.RepostedAt = nil
+		ids = append(ids, post.ID)
+		byId[post.ID] = post
+	}
+	if len(ids) == 0 || viewerId == "" {
+		return nil
+	}
+
+	rows, err := r.db.Query(
+		ctx,
+		`SELECT post_id, array_agg(emoji ORDER BY emoji)
+		FROM post_reactions
+		WHERE user_id = $1 AND post_id = ANY($2::uuid[])
+		GROUP BY post_id`,
+		viewerId,
+		ids,
+	)
+	if err != nil {
+		return fmt.Errorf("Failed to query viewer reactions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var postId string
+		var emojis []string
+		if err := rows.Scan(&postId, &emojis); err != nil {
+			return fmt.Errorf("Failed to scan viewer reactions: %w", err)
+		}
+		byId[postId].MyReactions = emojis
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("Failed to read viewer reactions: %w", err)
+	}
+
+	rows, err = r.db.Query(
+		ctx,
+		`SELECT post_id, created_at
+		FROM bookmarks
+		WHERE user_id = $1 AND post_id = ANY($2::uuid[])`,
+		viewerId,
+		ids,
+	)
+	if err != nil {
+		return fmt.Errorf("Failed to query viewer bookmarks: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var postId string
+		var bookmarkedAt time.Time
+		if err := rows.Scan(&postId, &bookmarkedAt); err != nil {
+			return fmt.Errorf("Failed to scan viewer bookmarks: %w", err)
+		}
+		byId[postId].BookmarkedAt = &bookmarkedAt
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("Failed to read viewer bookmarks: %w", err)
+	}
+
+	rows, err = r.db.Query(
+		ctx,
+		`SELECT post_id, created_at
+		FROM reposts
+		WHERE user_id = $1 AND post_id = ANY($2::uuid[])`,
+		viewerId,
+		ids,
+	)
+	if err != nil {
+		return fmt.Errorf("Failed to query viewer reposts: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var postId string
+		var repostedAt time.Time
+		if err := rows.Scan(&postId, &repostedAt); err != nil {
+			return fmt.Errorf("Failed to scan viewer reposts: %w", err)
+		}
+		byId[postId].RepostedAt = &repostedAt
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("Failed to read viewer reposts: %w", err)
+	}
+
+	return nil
+}
+
+// insertPostRevision records the current state of the post as its next
+// revision. Revisions are numbered with MAX(revision) + 1, so the caller must
+// hold a lock on the post row, or create the post, to keep concurrent edits
+// from picking the same number.
+func insertPostRevision(
+	ctx context.Context,
+	tx pgx.Tx,
+	post *models.Post,
+	editorId string,
+) error {
+	sql := `
+		INSERT INTO post_revisions (
+			post_id,
+			revision,
+			title,
+			content,
+			content_format,
+			content_html,
+			editor_id
+		)
+		SELECT $1, COALESCE(MAX(revision), 0) + 1, $2, $3, $4, $5, $6
+		FROM post_revisions
+		WHERE post_id = $1`
+
+	_, err := tx.Exec(
+		ctx,
+		sql,
+		post.ID,
+		post.Title,
+		post.Content,
+		post.ContentFormat,
+		post.ContentHtml,
+		editorId,
+	)
+	if err != nil {
+		return fmt.Errorf("Failed to record post revision: %w", err)
+	}
+
+	return nil
+}
+
+func getExplicitPostTags(
+	ctx context.Context,
+	tx pgx.Tx,
+	postId string,
+) ([]string, error) {
+	rows, err := tx.Query(
+		ctx,
+		`SELECT tags.name
+		FROM post_tags
+		JOIN tags ON tags.id = post_tags.tag_id
+		WHERE post_tags.post_id = $1 AND post_tags.explicit`,
+		postId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query post tags: %w", err)
+	}
+
+	tags, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read post tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+// setPostTags replaces the tags of the post with the explicit tags and the
+// hashtags found in its content.
+func setPostTags(
+	ctx context.Context,
+	tx pgx.Tx,
+	post *models.Post,
+	explicitTags []string,
+) error {
+	explicitTags = utils.NormalizeTags(explicitTags)
+	tags := utils.NormalizeTags(
+		append(explicitTags, utils.ExtractHashtags(post.Content)...),
+	)
+	explicit := make([]bool, len(tags))
+	for i, tag := range tags {
+		explicit[i] = slices.Contains(explicitTags, tag)
+	}
+
+	_, err := tx.Exec(ctx, `DELETE FROM post_tags WHERE post_id = $1`, post.ID)
+	if err != nil {
+		return fmt.Errorf("Failed to clear post tags: %w", err)
+	}
+
+	if len(tags) > 0 {
+		_, err = tx.Exec(
+			ctx,
+			`WITH names AS (
+				SELECT * FROM unnest($2::text[], $3::bool[]) AS t(name, explicit)
+			), upserted AS (
+				INSERT INTO tags (name)
+				SELECT name FROM names
+				ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+				RETURNING id, name
+			)
+			INSERT INTO post_tags (tag_id, post_id, explicit, created_at)
+			SELECT upserted.id, $1, names.explicit, $4
+			FROM upserted
+			JOIN names USING (name)`,
+			post.ID,
+			tags,
+			explicit,
+			post.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("Failed to set post tags: %w", err)
+		}
+	}
+
+	slices.Sort(tags)
+	post.Tags = tags
+	return nil
+}
@@ -0,0 +1,799 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"apps/api/internal/models"
+	"apps/api/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getTestPostRepo() *PostRepo {
+	return NewPostRepo(testDbService.GetDB())
+}
+
+func createTestAuthor(t *testing.T, email string) *models.User {
+	user, err := getTestUserRepo().CreateUser(
+		context.Background(),
+		models.UserCreate{Email: email, PasswordHash: "hash"},
+	)
+	require.NoError(t, err)
+	return user
+}
+
+func createTestPost(t *testing.T, authorId string, title string) *models.Post {
+	post, err := getTestPostRepo().CreatePost(
+		context.Background(),
+		models.PostCreate{
+			AuthorId: authorId,
+			Content:  "content of " + title,
+			Title:    title,
+		},
+	)
+	require.NoError(t, err)
+	return post
+}
+
+func TestPostRepo_SoftDelete(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("deleted post should be hidden from reads", func(t *testing.T) {
+		cleanupTestDatabase()
+		postRepo := getTestPostRepo()
+		author := createTestAuthor(t, "author@example.com")
+
+		kept := createTestPost(t, author.ID, "kept")
+		deleted := createTestPost(t, author.ID, "deleted")
+
+		require.NoError(t, postRepo.DeletePost(ctx, deleted.ID, nil))
+
+		_, err := postRepo.GetPostById(ctx, "", deleted.ID)
+		assert.Error(t, err)
+
+		posts, total, err := postRepo.GetPosts(ctx, "", 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, posts, 1)
+		assert.Equal(t, kept.ID, posts[0].ID)
+
+		_, err = postRepo.UpdatePost(
+			ctx,
+			deleted.ID,
+			author.ID,
+			models.PostUpdate{Title: &kept.Title},
+			nil,
+		)
+		assert.ErrorIs(t, err, ErrPostNotFound)
+
+		// An expected version does not hide that the post is in the trash.
+		_, err = postRepo.UpdatePost(
+			ctx,
+			deleted.ID,
+			author.ID,
+			models.PostUpdate{Title: &kept.Title},
+			&deleted.Version,
+		)
+		assert.ErrorIs(t, err, ErrPostNotFound)
+	})
+
+	t.Run(
+		"deleted post should be listed in trash and restorable",
+		func(t *testing.T) {
+			cleanupTestDatabase()
+			postRepo := getTestPostRepo()
+			author := createTestAuthor(t, "trash@example.com")
+			post := createTestPost(t, author.ID, "trashed")
+
+			require.NoError(t, postRepo.DeletePost(ctx, post.ID, nil))
+
+			trashed, total, err := postRepo.GetTrashedPosts(
+				ctx,
+				author.ID,
+				10,
+				0,
+			)
+			require.NoError(t, err)
+			assert.Equal(t, 1, total)
+			require.Len(t, trashed, 1)
+			assert.NotNil(t, trashed[0].DeletedAt)
+			assert.NotNil(t, trashed[0].Media)
+
+			restored, err := postRepo.RestorePost(ctx, author.ID, post.ID)
+			require.NoError(t, err)
+			assert.Nil(t, restored.DeletedAt)
+
+			_, err = postRepo.GetPostById(ctx, "", post.ID)
+			assert.NoError(t, err)
+
+			_, err = postRepo.RestorePost(ctx, author.ID, post.ID)
+			assert.ErrorIs(t, err, ErrPostNotFound)
+
+			other := createTestAuthor(t, "other@example.com")
+			require.NoError(t, postRepo.DeletePost(ctx, post.ID, nil))
+			_, err = postRepo.RestorePost(ctx, other.ID, post.ID)
+			assert.ErrorIs(t, err, ErrPostNotFound)
+		},
+	)
+
+	t.Run(
+		"purge should only remove posts trashed before cutoff",
+		func(t *testing.T) {
+			cleanupTestDatabase()
+			postRepo := getTestPostRepo()
+			author := createTestAuthor(t, "purge@example.com")
+			old := createTestPost(t, author.ID, "old")
+			recent := createTestPost(t, author.ID, "recent")
+			live := createTestPost(t, author.ID, "live")
+
+			require.NoError(t, postRepo.DeletePost(ctx, old.ID, nil))
+			require.NoError(t, postRepo.DeletePost(ctx, recent.ID, nil))
+			_, err := testDbService.GetDB().Exec(
+				ctx,
+				`UPDATE posts SET deleted_at = NOW() - INTERVAL '40 days'
+				WHERE id = $1`,
+				old.ID,
+			)
+			require.NoError(t, err)
+
+			purged, err := postRepo.PurgeTrashedPosts(
+				ctx,
+				time.Now().Add(-30*24*time.Hour),
+			)
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), purged)
+
+			_, err = postRepo.GetTrashedPostById(ctx, old.ID)
+			assert.Error(t, err)
+			_, err = postRepo.GetTrashedPostById(ctx, recent.ID)
+			assert.NoError(t, err)
+			_, err = postRepo.GetPostById(ctx, "", live.ID)
+			assert.NoError(t, err)
+		},
+	)
+
+	t.Run(
+		"posts removed by moderators should stay out of the trash",
+		func(t *testing.T) {
+			cleanupTestDatabase()
+			postRepo := getTestPostRepo()
+			author := createTestAuthor(t, "moderated@example.com")
+			post := createTestPost(t, author.ID, "moderated")
+
+			_, err := testDbService.GetDB().Exec(
+				ctx,
+				`UPDATE posts SET
+					deleted_at = NOW() - INTERVAL '40 days',
+					hidden_at = NOW() - INTERVAL '40 days'
+				WHERE id = $1`,
+				post.ID,
+			)
+			require.NoError(t, err)
+
+			trashed, total, err := postRepo.GetTrashedPosts(
+				ctx,
+				author.ID,
+				10,
+				0,
+			)
+			require.NoError(t, err)
+			assert.Equal(t, 0, total)
+			assert.Empty(t, trashed)
+
+			_, err = postRepo.GetTrashedPostById(ctx, post.ID)
+			assert.Error(t, err)
+			_, err = postRepo.RestorePost(ctx, author.ID, post.ID)
+			assert.Error(t, err)
+
+			purged, err := postRepo.PurgeTrashedPosts(ctx, time.Now())
+			require.NoError(t, err)
+			assert.Equal(t, int64(0), purged)
+		},
+	)
+}
+
+func TestPostRepo_Revisions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run(
+		"should record revision for create and every update",
+		func(t *testing.T) {
+			cleanupTestDatabase()
+			postRepo := getTestPostRepo()
+			postRevisionRepo := NewPostRevisionRepo(testDbService.GetDB())
+			author := createTestAuthor(t, "revisions@example.com")
+			post := createTestPost(t, author.ID, "first")
+			assert.Nil(t, post.EditedAt)
+
+			title := "second"
+			updated, err := postRepo.UpdatePost(
+				ctx,
+				post.ID,
+				author.ID,
+				models.PostUpdate{Title: &title},
+				nil,
+			)
+			require.NoError(t, err)
+			assert.NotNil(t, updated.EditedAt)
+
+			revisions, total, err := postRevisionRepo.GetRevisions(
+				ctx,
+				post.ID,
+				10,
+				0,
+			)
+			require.NoError(t, err)
+			assert.Equal(t, 2, total)
+			require.Len(t, revisions, 2)
+			assert.Equal(t, 2, revisions[0].Revision)
+			assert.Equal(t, "second", revisions[0].Title)
+			assert.Equal(t, 1, revisions[1].Revision)
+			assert.Equal(t, "first", revisions[1].Title)
+			assert.Equal(t, post.Content, revisions[1].Content)
+
+			revision, err := postRevisionRepo.GetRevision(ctx, post.ID, 1)
+			require.NoError(t, err)
+			assert.Equal(t, "first", revision.Title)
+		},
+	)
+
+	t.Run(
+		"should not record revision when only metadata changes",
+		func(t *testing.T) {
+			cleanupTestDatabase()
+			postRepo := getTestPostRepo()
+			postRevisionRepo := NewPostRevisionRepo(testDbService.GetDB())
+			author := createTestAuthor(t, "revisions@example.com")
+			post := createTestPost(t, author.ID, "first")
+
+			tags := []string{"news"}
+			visibility := models.PostVisibilityFollowers
+			updated, err := postRepo.UpdatePost(
+				ctx,
+				post.ID,
+				author.ID,
+				models.PostUpdate{
+					Tags:       &tags,
+					Title:      &post.Title,
+					Visibility: &visibility,
+				},
+				nil,
+			)
+			require.NoError(t, err)
+			assert.Nil(t, updated.EditedAt)
+			assert.Equal(t, post.Version+1, updated.Version)
+
+			_, total, err := postRevisionRepo.GetRevisions(
+				ctx,
+				post.ID,
+				10,
+				0,
+			)
+			require.NoError(t, err)
+			assert.Equal(t, 1, total)
+		},
+	)
+
+	t.Run("should keep the rendered content of revisions", func(t *testing.T) {
+		cleanupTestDatabase()
+		postRepo := getTestPostRepo()
+		postRevisionRepo := NewPostRevisionRepo(testDbService.GetDB())
+		author := createTestAuthor(t, "revisions@example.com")
+		post, err := postRepo.CreatePost(ctx, models.PostCreate{
+			AuthorId:      author.ID,
+			Content:       "**bold**",
+			ContentFormat: models.ContentFormatMarkdown,
+			ContentHtml:   "<p><strong>bold</strong></p>",
+			Title:         "markdown",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, models.ContentFormatMarkdown, post.ContentFormat)
+
+		format := models.ContentFormatPlain
+		html := "<p>**bold**</p>"
+		updated, err := postRepo.UpdatePost(
+			ctx,
+			post.ID,
+			author.ID,
+			models.PostUpdate{ContentFormat: &format, ContentHtml: &html},
+			nil,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, models.ContentFormatPlain, updated.ContentFormat)
+		assert.Equal(t, html, updated.ContentHtml)
+
+		revision, err := postRevisionRepo.GetRevision(ctx, post.ID, 1)
+		require.NoError(t, err)
+		assert.Equal(t, models.ContentFormatMarkdown, revision.ContentFormat)
+		assert.Equal(t, "<p><strong>bold</strong></p>", revision.ContentHtml)
+	})
+}
+
+func TestPostRepo_Versions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should reject update with stale version", func(t *testing.T) {
+		cleanupTestDatabase()
+		postRepo := getTestPostRepo()
+		author := createTestAuthor(t, "versions@example.com")
+		post := createTestPost(t, author.ID, "v1")
+		assert.Equal(t, 1, post.Version)
+
+		title := "v2"
+		updated, err := postRepo.UpdatePost(
+			ctx,
+			post.ID,
+			author.ID,
+			models.PostUpdate{Title: &title},
+			&post.Version,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, 2, updated.Version)
+
+		title = "stale"
+		_, err = postRepo.UpdatePost(
+			ctx,
+			post.ID,
+			author.ID,
+			models.PostUpdate{Title: &title},
+			&post.Version,
+		)
+		assert.ErrorIs(t, err, ErrPostVersionMismatch)
+
+		err = postRepo.DeletePost(ctx, post.ID, &post.Version)
+		assert.ErrorIs(t, err, ErrPostVersionMismatch)
+
+		err = postRepo.DeletePost(ctx, post.ID, &updated.Version)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should keep version when nothing changes", func(t *testing.T) {
+		cleanupTestDatabase()
+		postRepo := getTestPostRepo()
+		author := createTestAuthor(t, "versions@example.com")
+		post := createTestPost(t, author.ID, "v1")
+
+		tags := []string{}
+		unchanged, err := postRepo.UpdatePost(
+			ctx,
+			post.ID,
+			author.ID,
+			models.PostUpdate{
+				Content:    &post.Content,
+				Tags:       &tags,
+				Title:      &post.Title,
+				Visibility: &post.Visibility,
+			},
+			&post.Version,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, post.Version, unchanged.Version)
+		assert.Equal(t, post.UpdatedAt, unchanged.UpdatedAt)
+
+		stale := post.Version - 1
+		_, err = postRepo.UpdatePost(
+			ctx,
+			post.ID,
+			author.ID,
+			models.PostUpdate{Title: &post.Title},
+			&stale,
+		)
+		assert.ErrorIs(t, err, ErrPostVersionMismatch)
+	})
+}
+
+func TestPostRepo_ModifiedAt(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should move when rows shown with posts change", func(t *testing.T) {
+		cleanupTestDatabase()
+		postRepo := getTestPostRepo()
+		author := createTestAuthor(t, "modified@example.com")
+		viewer := createTestAuthor(t, "viewer@example.com")
+		post := createTestPost(t, author.ID, "modified")
+
+		before, err := postRepo.GetPostModifiedAt(ctx, viewer.ID, post.ID)
+		require.NoError(t, err)
+		listBefore, err := postRepo.GetPostsModifiedAt(ctx, viewer.ID)
+		require.NoError(t, err)
+
+		err = getTestBookmarkRepo().AddBookmark(ctx, viewer.ID, post.ID)
+		require.NoError(t, err)
+
+		after, err := postRepo.GetPostModifiedAt(ctx, viewer.ID, post.ID)
+		require.NoError(t, err)
+		assert.True(t, after.After(before))
+		listAfter, err := postRepo.GetPostsModifiedAt(ctx, viewer.ID)
+		require.NoError(t, err)
+		assert.True(t, listAfter.After(listBefore))
+	})
+
+	t.Run("should move when the quoted post is deleted", func(t *testing.T) {
+		cleanupTestDatabase()
+		postRepo := getTestPostRepo()
+		author := createTestAuthor(t, "modified@example.com")
+		quoted := createTestPost(t, author.ID, "quoted")
+		post, err := postRepo.CreatePost(ctx, models.PostCreate{
+			AuthorId:     author.ID,
+			Content:      "quoting",
+			QuotedPostId: &quoted.ID,
+			Title:        "quoting",
+		})
+		require.NoError(t, err)
+
+		before, err := postRepo.GetPostModifiedAt(ctx, author.ID, post.ID)
+		require.NoError(t, err)
+		require.NoError(t, postRepo.DeletePost(ctx, quoted.ID, nil))
+
+		after, err := postRepo.GetPostModifiedAt(ctx, author.ID, post.ID)
+		require.NoError(t, err)
+		assert.True(t, after.After(before))
+	})
+
+	t.Run("should move when the viewer mutes a user", func(t *testing.T) {
+		cleanupTestDatabase()
+		postRepo := getTestPostRepo()
+		author := createTestAuthor(t, "modified@example.com")
+		viewer := createTestAuthor(t, "viewer@example.com")
+		createTestPost(t, author.ID, "muted")
+
+		before, err := postRepo.GetPostsModifiedAt(ctx, viewer.ID)
+		require.NoError(t, err)
+		muteRepo := NewMuteRepo(testDbService.GetDB())
+		require.NoError(t, muteRepo.Mute(ctx, viewer.ID, author.ID))
+
+		after, err := postRepo.GetPostsModifiedAt(ctx, viewer.ID)
+		require.NoError(t, err)
+		assert.True(t, after.After(before))
+	})
+
+	t.Run("should be zero for missing posts", func(t *testing.T) {
+		cleanupTestDatabase()
+		viewer := createTestAuthor(t, "viewer@example.com")
+
+		modifiedAt, err := getTestPostRepo().GetPostModifiedAt(
+			ctx,
+			viewer.ID,
+			"00000000-0000-0000-0000-000000000000",
+		)
+		require.NoError(t, err)
+		assert.True(t, modifiedAt.IsZero())
+	})
+}
+
+func TestPostRepo_HasDuplicatePost(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should find recent posts with the same title", func(t *testing.T) {
+		cleanupTestDatabase()
+		postRepo := getTestPostRepo()
+		author := createTestAuthor(t, "duplicates@example.com")
+		post := createTestPost(t, author.ID, "same")
+		since := time.Now().Add(-time.Hour)
+
+		found, err := postRepo.HasDuplicatePost(
+			ctx,
+			author.ID,
+			"",
+			"title",
+			"same",
+			since,
+		)
+		require.NoError(t, err)
+		assert.True(t, found)
+
+		found, err = postRepo.HasDuplicatePost(
+			ctx,
+			author.ID,
+			post.ID,
+			"title",
+			"same",
+			since,
+		)
+		require.NoError(t, err)
+		assert.False(t, found)
+
+		_, err = postRepo.HasDuplicatePost(
+			ctx,
+			author.ID,
+			"",
+			"id",
+			"same",
+			since,
+		)
+		assert.Error(t, err)
+	})
+}
+
+func TestPostRepo_Visibility(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should only show posts to allowed viewers", func(t *testing.T) {
+		cleanupTestDatabase()
+		postRepo := getTestPostRepo()
+		author := createTestAuthor(t, "visible@example.com")
+		follower := createTestAuthor(t, "follower@example.com")
+		stranger := createTestAuthor(t, "stranger@example.com")
+		err := getTestFollowRepo().Follow(ctx, follower.ID, author.ID)
+		require.NoError(t, err)
+
+		posts := map[models.PostVisibility]*models.Post{}
+		for _, visibility := range []models.PostVisibility{
+			models.PostVisibilityPublic,
+			models.PostVisibilityFollowers,
+			models.PostVisibilityPrivate,
+			models.PostVisibilityUnlisted,
+		} {
+			post, err := postRepo.CreatePost(ctx, models.PostCreate{
+				AuthorId:   author.ID,
+				Content:    string(visibility),
+				Title:      string(visibility),
+				Visibility: visibility,
+			})
+			require.NoError(t, err)
+			posts[visibility] = post
+		}
+
+		readable := map[string][]models.PostVisibility{
+			author.ID: {
+				models.PostVisibilityPublic,
+				models.PostVisibilityFollowers,
+				models.PostVisibilityPrivate,
+				models.PostVisibilityUnlisted,
+			},
+			follower.ID: {
+				models.PostVisibilityPublic,
+				models.PostVisibilityFollowers,
+				models.PostVisibilityUnlisted,
+			},
+			stranger.ID: {
+				models.PostVisibilityPublic,
+				models.PostVisibilityUnlisted,
+			},
+		}
+		for viewerId, visibilities := range readable {
+			var listed []models.PostVisibility
+			for visibility, post := range posts {
+				_, err := postRepo.GetPostById(ctx, viewerId, post.ID)
+				assert.Equal(
+					t,
+					slices.Contains(visibilities, visibility),
+					err == nil,
+					visibility,
+				)
+				if visibility != models.PostVisibilityUnlisted &&
+					slices.Contains(visibilities, visibility) {
+					listed = append(listed, visibility)
+				}
+			}
+
+			page, total, err := postRepo.GetPosts(ctx, viewerId, 10, 0)
+			require.NoError(t, err)
+			assert.Equal(t, len(listed), total)
+			assert.ElementsMatch(
+				t,
+				listed,
+				utils.MapSlice(
+					page,
+					func(post *models.Post) models.PostVisibility {
+						return post.Visibility
+					},
+				),
+			)
+		}
+
+		private := models.PostVisibilityPrivate
+		post, err := postRepo.UpdatePost(
+			ctx,
+			posts[models.PostVisibilityPublic].ID,
+			author.ID,
+			models.PostUpdate{Visibility: &private},
+			nil,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, private, post.Visibility)
+		_, err = postRepo.GetPostById(ctx, stranger.ID, post.ID)
+		assert.Error(t, err)
+	})
+}
+
+func TestPostRepo_GetFeed(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run(
+		"should list own and followed posts with cursor",
+		func(t *testing.T) {
+			cleanupTestDatabase()
+			postRepo := getTestPostRepo()
+			viewer := createTestAuthor(t, "viewer@example.com")
+			followed := createTestAuthor(t, "followed@example.com")
+			stranger := createTestAuthor(t, "stranger@example.com")
+			require.NoError(
+				t,
+				getTestFollowRepo().Follow(ctx, viewer.ID, followed.ID),
+			)
+
+			own := createTestPost(t, viewer.ID, "own")
+			first := createTestPost(t, followed.ID, "first")
+			createTestPost(t, stranger.ID, "stranger")
+			second := createTestPost(t, followed.ID, "second")
+			trashed := createTestPost(t, followed.ID, "trashed")
+			require.NoError(t, postRepo.DeletePost(ctx, trashed.ID, nil))
+
+			page, err := postRepo.GetFeed(ctx, viewer.ID, nil, 2)
+			require.NoError(t, err)
+			require.Len(t, page, 2)
+			assert.Equal(t, second.ID, page[0].ID)
+			assert.Equal(t, first.ID, page[1].ID)
+
+			page, err = postRepo.GetFeed(
+				ctx,
+				viewer.ID,
+				&models.PostCursor{
+					CreatedAt: page[1].CreatedAt,
+					Id:        page[1].ID,
+				},
+				2,
+			)
+			require.NoError(t, err)
+			require.Len(t, page, 1)
+			assert.Equal(t, own.ID, page[0].ID)
+		},
+	)
+}
+
+// seedFeed creates a viewer following followees users out of users, each of
+// them with postsPerUser posts, and returns the id of the viewer.
+func seedFeed(
+	b *testing.B,
+	users int,
+	followees int,
+	postsPerUser int,
+) string {
+	ctx := context.Background()
+	db := testDbService.GetDB()
+
+	cleanupTestDatabase()
+	viewer, err := getTestUserRepo().CreateUser(
+		ctx,
+		models.UserCreate{
+			Email:        "feed-viewer@example.com",
+			PasswordHash: "hash",
+		},
+	)
+	require.NoError(b, err)
+
+	_, err = db.Exec(
+		ctx,
+		`INSERT INTO users (email, handle, password_hash)
+		SELECT 'feed-' || i || '@example.com', 'feed_' || i, 'hash'
+		FROM generate_series(1, $1) AS i`,
+		users,
+	)
+	require.NoError(b, err)
+
+	_, err = db.Exec(
+		ctx,
+		`INSERT INTO follows (follower_id, followee_id)
+		SELECT $1, id FROM users WHERE id <> $1 ORDER BY email LIMIT $2`,
+		viewer.ID,
+		followees,
+	)
+	require.NoError(b, err)
+
+	_, err = db.Exec(
+		ctx,
+		`INSERT INTO posts (author_id, title, content, created_at, updated_at)
+		SELECT users.id, 'title', 'content', t.created_at, t.created_at
+		FROM users
+		CROSS JOIN LATERAL (
+			SELECT NOW() - random() * INTERVAL '365 days' AS created_at
+			FROM generate_series(1, $1)
+		) AS t`,
+		postsPerUser,
+	)
+	require.NoError(b, err)
+
+	_, err = db.Exec(ctx, `ANALYZE`)
+	require.NoError(b, err)
+
+	return viewer.ID
+}
+
+func BenchmarkPostRepo_GetFeed(b *testing.B) {
+	ctx := context.Background()
+	postRepo := getTestPostRepo()
+
+	for _, followees := range []int{10, 100, 1000} {
+		b.Run(fmt.Sprintf("followees=%d", followees), func(b *testing.B) {
+			viewerId := seedFeed(b, 2000, followees, 50)
+
+			b.Run("first page", func(b *testing.B) {
+				for range b.N {
+					_, err := postRepo.GetFeed(ctx, viewerId, nil, 20)
+					require.NoError(b, err)
+				}
+			})
+
+			page, err := postRepo.GetFeed(ctx, viewerId, nil, 100)
+			require.NoError(b, err)
+			last := page[len(page)-1]
+			cursor := &models.PostCursor{
+				CreatedAt: last.CreatedAt,
+				Id:        last.ID,
+			}
+
+			b.Run("deep page", func(b *testing.B) {
+				for range b.N {
+					_, err := postRepo.GetFeed(ctx, viewerId, cursor, 20)
+					require.NoError(b, err)
+				}
+			})
+		})
+	}
+}
+
+func TestPostRepo_PinPost(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should list pinned posts first", func(t *testing.T) {
+		cleanupTestDatabase()
+		postRepo := getTestPostRepo()
+		author := createTestAuthor(t, "author@example.com")
+		first := createTestPost(t, author.ID, "first")
+		second := createTestPost(t, author.ID, "second")
+		third := createTestPost(t, author.ID, "third")
+
+		require.NoError(t, postRepo.PinPost(ctx, author.ID, first.ID, nil, 2))
+		position := 1
+		require.NoError(
+			t,
+			postRepo.PinPost(ctx, author.ID, second.ID, &position, 2),
+		)
+		err := postRepo.PinPost(ctx, author.ID, third.ID, nil, 2)
+		assert.ErrorIs(t, err, ErrPinLimitReached)
+
+		posts, err := postRepo.GetUserPosts(ctx, "", author.ID, nil, 2)
+		require.NoError(t, err)
+		require.Len(t, posts, 2)
+		assert.Equal(t, second.ID, posts[0].ID)
+		assert.Equal(t, first.ID, posts[1].ID)
+
+		posts, err = postRepo.GetUserPosts(
+			ctx,
+			"",
+			author.ID,
+			&models.UserPostCursor{
+				PinnedPosition: posts[1].PinnedPosition,
+				CreatedAt:      posts[1].CreatedAt,
+				Id:             posts[1].ID,
+			},
+			2,
+		)
+		require.NoError(t, err)
+		require.Len(t, posts, 1)
+		assert.Equal(t, third.ID, posts[0].ID)
+	})
+
+	t.Run("should close gaps when unpinning", func(t *testing.T) {
+		cleanupTestDatabase()
+		postRepo := getTestPostRepo()
+		author := createTestAuthor(t, "author@example.com")
+		first := createTestPost(t, author.ID, "first")
+		second := createTestPost(t, author.ID, "second")
+
+		require.NoError(t, postRepo.PinPost(ctx, author.ID, first.ID, nil, 3))
+		require.NoError(t, postRepo.PinPost(ctx, author.ID, second.ID, nil, 3))
+		require.NoError(t, postRepo.UnpinPost(ctx, author.ID, first.ID))
+
+		post, err := postRepo.GetPostById(ctx, author.ID, second.ID)
+		require.NoError(t, err)
+		require.NotNil(t, post.PinnedPosition)
+		assert.Equal(t, 1, *post.PinnedPosition)
+	})
+}
@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"apps/api/internal/models"
+)
+
+type PostRevisionRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewPostRevisionRepo(db *pgxpool.Pool) *PostRevisionRepo {
+	return &PostRevisionRepo{db: db}
+}
+
+var postRevisionStruct = sqlbuilder.NewStruct(new(models.PostRevision)).
+	For(sqlbuilder.PostgreSQL)
+
+func (r *PostRevisionRepo) GetRevision(
+	ctx context.Context,
+	postId string,
+	revision int,
+) (*models.PostRevision, error) {
+	sb := postRevisionStruct.SelectFrom("post_revisions")
+	sb.Where(sb.Equal("post_id", postId), sb.Equal("revision", revision))
+	sql, args := sb.Build()
+
+	var postRevision models.PostRevision
+	err := r.db.QueryRow(ctx, sql, args...).Scan(
+		postRevisionStruct.Addr(&postRevision)...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get post revision: %w", err)
+	}
+
+	return &postRevision, nil
+}
+
+func (r *PostRevisionRepo) GetRevisions(
+	ctx context.Context,
+	postId string,
+	limit int,
+	offset int,
+) ([]*models.PostRevision, int, error) {
+	sb := postRevisionStruct.SelectFrom("post_revisions")
+	sb.Where(sb.Equal("post_id", postId))
+	sb.OrderBy("revision").Desc()
+	sb.Limit(limit)
+	sb.Offset(offset)
+	sql, args := sb.Build()
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("Failed to query post revisions: %w", err)
+	}
+	defer rows.Close()
+
+	var postRevisions []*models.PostRevision
+	for rows.Next() {
+		var postRevision models.PostRevision
+		err := rows.Scan(postRevisionStruct.Addr(&postRevision)...)
+		if err != nil {
+			return nil, 0, fmt.Errorf("Failed to scan post revision: %w", err)
+		}
+		postRevisions = append(postRevisions, &postRevision)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("Failed to read post revisions: %w", err)
+	}
+
+	cb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	cb.Select("COUNT(*)").From("post_revisions")
+	cb.Where(cb.Equal("post_id", postId))
+	sql, args = cb.Build()
+
+	var total int
+	err = r.db.QueryRow(ctx, sql, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("Failed to count post revisions: %w", err)
+	}
+
+	return postRevisions, total, nil
+}
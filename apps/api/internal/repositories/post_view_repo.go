@@ -0,0 +1,110 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"apps/api/internal/models"
+)
+
+type PostViewRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewPostViewRepo(db *pgxpool.Pool) *PostViewRepo {
+	return &PostViewRepo{db: db}
+}
+
+// AddPostViews stores a batch of views in a single statement. Views already
+// counted for the viewer on that day and views of posts that no longer exist
+// are skipped.
+func (r *PostViewRepo) AddPostViews(
+	ctx context.Context,
+	views []models.PostView,
+) error {
+	if len(views) == 0 {
+		return nil
+	}
+
+	postIds := make([]string, len(views))
+	viewerKeys := make([]string, len(views))
+	days := make([]time.Time, len(views))
+	for i, view := range views {
+		postIds[i] = view.PostId
+		viewerKeys[i] = view.ViewerKey
+		days[i] = view.Day
+	}
+
+	_, err := r.db.Exec(
+		ctx,
+		`INSERT INTO post_views (post_id, viewer_key, day)
+		SELECT views.post_id, views.viewer_key, views.day
+		FROM unnest($1::uuid[], $2::text[], $3::date[])
+			AS views(post_id, viewer_key, day)
+		WHERE EXISTS (SELECT 1 FROM posts WHERE id = views.post_id)
+		ON CONFLICT DO NOTHING`,
+		postIds,
+		viewerKeys,
+		days,
+	)
+	if err != nil {
+		return fmt.Errorf("Failed to add post views: %w", err)
+	}
+
+	return nil
+}
+
+// GetPostViews returns the number of views of the post over all time and per
+// UTC day since the given day, days without views included.
+func (r *PostViewRepo) GetPostViews(
+	ctx context.Context,
+	postId string,
+	since time.Time,
+) (int, []*models.PostViewsDay, error) {
+	var total int
+	err := r.db.QueryRow(
+		ctx,
+		`SELECT COUNT(*) FROM post_views WHERE post_id = $1`,
+		postId,
+	).Scan(&total)
+	if err != nil {
+		return 0, nil, fmt.Errorf("Failed to count post views: %w", err)
+	}
+
+	rows, err := r.db.Query(
+		ctx,
+		`SELECT days.day::date, COUNT(post_views.day)::int
+		FROM generate_series(
+			$2::date::timestamp,
+			(NOW() AT TIME ZONE 'UTC')::date::timestamp,
+			INTERVAL '1 day'
+		) AS days(day)
+		LEFT JOIN post_views
+			ON post_views.post_id = $1 AND post_views.day = days.day::date
+		GROUP BY days.day
+		ORDER BY days.day`,
+		postId,
+		since,
+	)
+	if err != nil {
+		return 0, nil, fmt.Errorf("Failed to query post views: %w", err)
+	}
+	defer rows.Close()
+
+	var days []*models.PostViewsDay
+	for rows.Next() {
+		var day models.PostViewsDay
+		if err := rows.Scan(&day.Day, &day.Views); err != nil {
+			return 0, nil, fmt.Errorf("Failed to scan post views: %w", err)
+		}
+		days = append(days, &day)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, nil, fmt.Errorf("Failed to read post views: %w", err)
+	}
+
+	return total, days, nil
+}
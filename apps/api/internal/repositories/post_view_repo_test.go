@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"apps/api/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostViewRepo_GetPostViews(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should count each viewer once per day", func(t *testing.T) {
+		cleanupTestDatabase()
+		postViewRepo := NewPostViewRepo(testDbService.GetDB())
+		author := createTestAuthor(t, "author@example.com")
+		post := createTestPost(t, author.ID, "viewed")
+		today := time.Now().UTC().Truncate(24 * time.Hour)
+		yesterday := today.AddDate(0, 0, -1)
+
+		require.NoError(t, postViewRepo.AddPostViews(ctx, []models.PostView{
+			{Day: yesterday, PostId: post.ID, ViewerKey: "user:a"},
+			{Day: today, PostId: post.ID, ViewerKey: "user:a"},
+			{Day: today, PostId: post.ID, ViewerKey: "device:b"},
+		}))
+		require.NoError(t, postViewRepo.AddPostViews(ctx, []models.PostView{
+			{Day: today, PostId: post.ID, ViewerKey: "user:a"},
+		}))
+
+		total, days, err := postViewRepo.GetPostViews(
+			ctx,
+			post.ID,
+			today.AddDate(0, 0, -2),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, 3, total)
+		require.Len(t, days, 3)
+		assert.Equal(t, 0, days[0].Views)
+		assert.Equal(t, 1, days[1].Views)
+		assert.Equal(t, 2, days[2].Views)
+	})
+}
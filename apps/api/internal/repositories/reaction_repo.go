@@ -0,0 +1,229 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"apps/api/internal/models"
+)
+
+type ReactionRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewReactionRepo(db *pgxpool.Pool) *ReactionRepo {
+	return &ReactionRepo{db: db}
+}
+
+var reactionStruct = sqlbuilder.NewStruct(new(models.Reaction)).
+	For(sqlbuilder.PostgreSQL)
+
+// AddReaction records the reaction of the user on the post. Reacting twice
+// with the same emoji is a no-op. The aggregated counter on the post is only
+// incremented and the author notified when a reaction was actually
+// inserted, so that concurrent requests cannot skew it.
+func (r *ReactionRepo) AddReaction(
+	ctx context.Context,
+	postId string,
+	userId string,
+	emoji string,
+) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(
+		ctx,
+		`INSERT INTO post_reactions (post_id, user_id, emoji)
+		VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING`,
+		postId,
+		userId,
+		emoji,
+	)
+	if err != nil {
+		return fmt.Errorf("Failed to add reaction: %w", err)
+	}
+
+	if tag.RowsAffected() > 0 {
+		// Posts of deleted users have no author to notify.
+		var authorId *string
+		err = tx.QueryRow(
+			ctx,
+			`UPDATE posts SET
+				reaction_counts = reaction_counts || jsonb_build_object(
+					$2::text,
+					COALESCE((reaction_counts->>$2::text)::int, 0) + 1
+				),
+				updated_at = NOW()
+			WHERE id = $1
+			RETURNING author_id`,
+			postId,
+			emoji,
+		).Scan(&authorId)
+		if err != nil {
+			return fmt.Errorf("Failed to update reaction counts: %w", err)
+		}
+
+		if authorId != nil {
+			err = insertNotification(ctx, tx, models.NotificationCreate{
+				ActorId: &userId,
+				Payload: models.NotificationPayload{Emoji: &emoji},
+				PostId:  &postId,
+				Type:    models.NotificationTypeReaction,
+				UserId:  *authorId,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("Failed to add reaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetReactions returns a page of reactions on the post, newest first,
+// optionally limited to a single emoji. When viewerId is set, reactions of
+// users that blocked the viewer or were blocked by them are left out.
+func (r *ReactionRepo) GetReactions(
+	ctx context.Context,
+	viewerId string,
+	postId string,
+	emoji *string,
+	limit int,
+	offset int,
+) ([]*models.Reaction, int, error) {
+	sb := reactionStruct.SelectFrom("post_reactions")
+	sb.Where(sb.Equal("post_id", postId))
+	if emoji != nil {
+		sb.Where(sb.Equal("emoji", *emoji))
+	}
+	if viewerId != "" {
+		sb.Where(notBlockedCondition(sb.Var(viewerId), "user_id"))
+	}
+	sb.OrderBy("created_at DESC", "user_id", "emoji")
+	sb.Limit(limit)
+	sb.Offset(offset)
+	sql, args := sb.Build()
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("Failed to query reactions: %w", err)
+	}
+	defer rows.Close()
+
+	var reactions []*models.Reaction
+	for rows.Next() {
+		var reaction models.Reaction
+		err := rows.Scan(reactionStruct.Addr(&reaction)...)
+		if err != nil {
+			return nil, 0, fmt.Errorf("Failed to scan reaction: %w", err)
+		}
+		reactions = append(reactions, &reaction)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("Failed to read reactions: %w", err)
+	}
+
+	cb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	cb.Select("COUNT(*)").From("post_reactions")
+	cb.Where(cb.Equal("post_id", postId))
+	if emoji != nil {
+		cb.Where(cb.Equal("emoji", *emoji))
+	}
+	if viewerId != "" {
+		cb.Where(notBlockedCondition(cb.Var(viewerId), "user_id"))
+	}
+	sql, args = cb.Build()
+
+	var total int
+	err = r.db.QueryRow(ctx, sql, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("Failed to count reactions: %w", err)
+	}
+
+	return reactions, total, nil
+}
+
+// RemoveReaction deletes the reaction of the user on the post and decrements
+// the aggregated counter, dropping the emoji once no reactions are left. The
+// notification of the author about the reaction is taken back.
+func (r *ReactionRepo) RemoveReaction(
+	ctx context.Context,
+	postId string,
+	userId string,
+	emoji string,
+) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	db := sqlbuilder.PostgreSQL.NewDeleteBuilder()
+	db.DeleteFrom("post_reactions")
+	db.Where(
+		db.Equal("post_id", postId),
+		db.Equal("user_id", userId),
+		db.Equal("emoji", emoji),
+	)
+	sql, args := db.Build()
+
+	tag, err := tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("Failed to remove reaction: %w", err)
+	}
+
+	if tag.RowsAffected() > 0 {
+		// Posts of deleted users have no author to notify.
+		var authorId *string
+		err = tx.QueryRow(
+			ctx,
+			`UPDATE posts SET
+				reaction_counts = CASE
+					WHEN COALESCE((reaction_counts->>$2::text)::int, 0) <= 1
+					THEN reaction_counts - $2::text
+					ELSE reaction_counts || jsonb_build_object(
+						$2::text,
+						(reaction_counts->>$2::text)::int - 1
+					)
+				END,
+				updated_at = NOW()
+			WHERE id = $1
+			RETURNING author_id`,
+			postId,
+			emoji,
+		).Scan(&authorId)
+		if err != nil {
+			return fmt.Errorf("Failed to update reaction counts: %w", err)
+		}
+
+		if authorId != nil {
+			err = deleteNotification(ctx, tx, models.NotificationCreate{
+				ActorId: &userId,
+				Payload: models.NotificationPayload{Emoji: &emoji},
+				PostId:  &postId,
+				Type:    models.NotificationTypeReaction,
+				UserId:  *authorId,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("Failed to remove reaction: %w", err)
+	}
+
+	return nil
+}
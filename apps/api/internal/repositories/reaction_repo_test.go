@@ -0,0 +1,180 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getTestReactionRepo() *ReactionRepo {
+	return NewReactionRepo(testDbService.GetDB())
+}
+
+func TestReactionRepo_Reactions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should keep one reaction per user and emoji", func(t *testing.T) {
+		cleanupTestDatabase()
+		postRepo := getTestPostRepo()
+		reactionRepo := getTestReactionRepo()
+		author := createTestAuthor(t, "reactions@example.com")
+		post := createTestPost(t, author.ID, "reacted")
+
+		for _, emoji := range []string{"👍", "👍", "🎉"} {
+			err := reactionRepo.AddReaction(ctx, post.ID, author.ID, emoji)
+			require.NoError(t, err)
+		}
+
+		post, err := postRepo.GetPostById(ctx, author.ID, post.ID)
+		require.NoError(t, err)
+		assert.Equal(
+			t,
+			map[string]int{"👍": 1, "🎉": 1},
+			post.ReactionCounts,
+		)
+		assert.ElementsMatch(t, []string{"👍", "🎉"}, post.MyReactions)
+
+		require.NoError(
+			t,
+			reactionRepo.RemoveReaction(ctx, post.ID, author.ID, "🎉"),
+		)
+		require.NoError(
+			t,
+			reactionRepo.RemoveReaction(ctx, post.ID, author.ID, "🎉"),
+		)
+
+		post, err = postRepo.GetPostById(ctx, "", post.ID)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"👍": 1}, post.ReactionCounts)
+		assert.Empty(t, post.MyReactions)
+
+		emoji := "👍"
+		reactions, total, err := reactionRepo.GetReactions(
+			ctx,
+			"",
+			post.ID,
+			&emoji,
+			10,
+			0,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, reactions, 1)
+		assert.Equal(t, author.ID, reactions[0].UserId)
+	})
+
+	t.Run(
+		"should keep counts consistent under concurrent reactions",
+		func(t *testing.T) {
+			cleanupTestDatabase()
+			postRepo := getTestPostRepo()
+			reactionRepo := getTestReactionRepo()
+			author := createTestAuthor(t, "concurrent@example.com")
+			post := createTestPost(t, author.ID, "popular")
+
+			var userIds []string
+			for i := range 10 {
+				user := createTestAuthor(t, fmt.Sprintf("fan%d@example.com", i))
+				userIds = append(userIds, user.ID)
+			}
+
+			var wg sync.WaitGroup
+			for _, userId := range userIds {
+				for range 3 {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						err := reactionRepo.AddReaction(
+							ctx,
+							post.ID,
+							userId,
+							"❤️",
+						)
+						assert.NoError(t, err)
+					}()
+				}
+			}
+			wg.Wait()
+
+			post, err := postRepo.GetPostById(ctx, "", post.ID)
+			require.NoError(t, err)
+			assert.Equal(t, 10, post.ReactionCounts["❤️"])
+
+			for _, userId := range userIds[:4] {
+				wg.Add(2)
+				for range 2 {
+					go func() {
+						defer wg.Done()
+						assert.NoError(
+							t,
+							reactionRepo.RemoveReaction(
+								ctx,
+								post.ID,
+								userId,
+								"❤️",
+							),
+						)
+					}()
+				}
+			}
+			wg.Wait()
+
+			post, err = postRepo.GetPostById(ctx, "", post.ID)
+			require.NoError(t, err)
+			assert.Equal(t, 6, post.ReactionCounts["❤️"])
+		},
+	)
+
+	t.Run("should accept reactions on posts of deleted users", func(t *testing.T) {
+		cleanupTestDatabase()
+		reactionRepo := getTestReactionRepo()
+		author := createTestAuthor(t, "deleted@example.com")
+		fan := createTestAuthor(t, "fan@example.com")
+		post := createTestPost(t, author.ID, "orphaned")
+
+		_, err := testDbService.GetDB().Exec(
+			ctx,
+			"DELETE FROM users WHERE id = $1",
+			author.ID,
+		)
+		require.NoError(t, err)
+
+		require.NoError(t, reactionRepo.AddReaction(ctx, post.ID, fan.ID, "👍"))
+		err = reactionRepo.RemoveReaction(ctx, post.ID, fan.ID, "👍")
+		require.NoError(t, err)
+	})
+
+	t.Run("should leave out reactions of blocked users", func(t *testing.T) {
+		cleanupTestDatabase()
+		reactionRepo := getTestReactionRepo()
+		author := createTestAuthor(t, "author@example.com")
+		blocked := createTestAuthor(t, "blocked@example.com")
+		blocker := createTestAuthor(t, "blocker@example.com")
+		fan := createTestAuthor(t, "fan@example.com")
+		post := createTestPost(t, author.ID, "blocked")
+		for _, user := range []string{blocked.ID, blocker.ID, fan.ID} {
+			require.NoError(t, reactionRepo.AddReaction(ctx, post.ID, user, "👍"))
+		}
+
+		blockRepo := NewBlockRepo(testDbService.GetDB())
+		require.NoError(t, blockRepo.Block(ctx, author.ID, blocked.ID))
+		require.NoError(t, blockRepo.Block(ctx, blocker.ID, author.ID))
+
+		reactions, total, err := reactionRepo.GetReactions(
+			ctx,
+			author.ID,
+			post.ID,
+			nil,
+			10,
+			0,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, reactions, 1)
+		assert.Equal(t, fan.ID, reactions[0].UserId)
+	})
+}
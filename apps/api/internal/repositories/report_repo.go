@@ -0,0 +1,333 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"apps/api/internal/models"
+)
+
+var (
+	ErrReportExists     = errors.New("Report already exists")
+	ErrReportNotOpen    = errors.New("Report is not open")
+	ErrReportNotClaimed = errors.New("Report is not claimed by moderator")
+)
+
+// moderationActionClaim is recorded in the audit log when a moderator
+// claims a report. Resolving a report records the models.ReportAction taken.
+const moderationActionClaim = "claim"
+
+type ReportRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewReportRepo(db *pgxpool.Pool) *ReportRepo {
+	return &ReportRepo{db: db}
+}
+
+var reportStruct = sqlbuilder.NewStruct(new(models.Report)).
+	For(sqlbuilder.PostgreSQL)
+
+// CreateReport inserts an open report. ErrReportExists is returned when the
+// user already reported the post.
+func (r *ReportRepo) CreateReport(
+	ctx context.Context,
+	params models.ReportCreate,
+) (*models.Report, error) {
+	ib := sqlbuilder.PostgreSQL.NewInsertBuilder()
+	ib.InsertInto("reports")
+	ib.Cols("details", "post_id", "reason", "reporter_id")
+	ib.Values(
+		params.Details,
+		params.PostId,
+		params.Reason,
+		params.ReporterId,
+	)
+	ib.SQL("ON CONFLICT (post_id, reporter_id) DO NOTHING")
+	ib.Returning(strings.Join(reportStruct.Columns(), ","))
+	sql, args := ib.Build()
+
+	var report models.Report
+	err := r.db.QueryRow(ctx, sql, args...).Scan(reportStruct.Addr(&report)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("Failed to create report: %w", ErrReportExists)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create report: %w", err)
+	}
+
+	return &report, nil
+}
+
+func (r *ReportRepo) GetReportById(
+	ctx context.Context,
+	id string,
+) (*models.Report, error) {
+	sb := reportStruct.SelectFrom("reports")
+	sb.Where(sb.Equal("id", id))
+	sql, args := sb.Build()
+
+	var report models.Report
+	err := r.db.QueryRow(ctx, sql, args...).Scan(reportStruct.Addr(&report)...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get report by id: %w", err)
+	}
+
+	return &report, nil
+}
+
+// GetReports returns a page of the moderation queue, oldest reports first.
+// Reports of any status are returned when status is nil.
+func (r *ReportRepo) GetReports(
+	ctx context.Context,
+	status *models.ReportStatus,
+	cursor *models.ReportCursor,
+	limit int,
+) ([]*models.Report, error) {
+	sb := reportStruct.SelectFrom("reports")
+	if status != nil {
+		sb.Where(sb.Equal("status", *status))
+	}
+	if cursor != nil {
+		sb.Where(fmt.Sprintf(
+			"(created_at, id) > (%s, %s)",
+			sb.Var(cursor.CreatedAt),
+			sb.Var(cursor.Id),
+		))
+	}
+	sb.OrderBy("created_at", "id")
+	sb.Limit(limit)
+	sql, args := sb.Build()
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query reports: %w", err)
+	}
+	defer rows.Close()
+
+	var reports []*models.Report
+	for rows.Next() {
+		var report models.Report
+		err := rows.Scan(reportStruct.Addr(&report)...)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to scan report: %w", err)
+		}
+		reports = append(reports, &report)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Failed to read reports: %w", err)
+	}
+
+	return reports, nil
+}
+
+// ClaimReport assigns an open report to the moderator. ErrReportNotOpen is
+// returned when the report was claimed or resolved before.
+func (r *ReportRepo) ClaimReport(
+	ctx context.Context,
+	id string,
+	moderatorId string,
+) (*models.Report, error) {
+	ub := sqlbuilder.PostgreSQL.NewUpdateBuilder()
+	ub.Update("reports")
+	ub.Set(
+		ub.Assign("status", models.ReportStatusClaimed),
+		ub.Assign("claimed_by", moderatorId),
+		"claimed_at = NOW()",
+	)
+	ub.Where(
+		ub.Equal("id", id),
+		ub.Equal("status", models.ReportStatusOpen),
+	)
+	ub.SQL("RETURNING " + strings.Join(reportStruct.Columns(), ","))
+	sql, args := ub.Build()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var report models.Report
+	err = tx.QueryRow(ctx, sql, args...).Scan(reportStruct.Addr(&report)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("Failed to claim report: %w", ErrReportNotOpen)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to claim report: %w", err)
+	}
+
+	err = recordModerationAction(
+		ctx,
+		tx,
+		moderatorId,
+		&report,
+		moderationActionClaim,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("Failed to claim report: %w", err)
+	}
+
+	return &report, nil
+}
+
+// ResolveReport resolves a report claimed by the moderator and applies the
+// action to the reported post or its author. Deleted posts are hidden as
+// well, so that their author cannot restore them from the trash. The
+// reporter is notified of the outcome. ErrReportNotClaimed is returned when
+// the report is not claimed by the moderator.
+func (r *ReportRepo) ResolveReport(
+	ctx context.Context,
+	id string,
+	moderatorId string,
+	action models.ReportAction,
+) (*models.Report, error) {
+	ub := sqlbuilder.PostgreSQL.NewUpdateBuilder()
+	ub.Update("reports")
+	ub.Set(
+		ub.Assign("status", models.ReportStatusResolved),
+		ub.Assign("resolved_by", moderatorId),
+		"resolved_at = NOW()",
+		ub.Assign("action", action),
+	)
+	ub.Where(
+		ub.Equal("id", id),
+		ub.Equal("status", models.ReportStatusClaimed),
+		ub.Equal("claimed_by", moderatorId),
+	)
+	ub.SQL("RETURNING " + strings.Join(reportStruct.Columns(), ","))
+	sql, args := ub.Build()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var report models.Report
+	err = tx.QueryRow(ctx, sql, args...).Scan(reportStruct.Addr(&report)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf(
+			"Failed to resolve report: %w",
+			ErrReportNotClaimed,
+		)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to resolve report: %w", err)
+	}
+
+	var authorId string
+	err = tx.QueryRow(
+		ctx,
+		`SELECT author_id FROM posts WHERE id = $1`,
+		report.PostId,
+	).Scan(&authorId)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get reported post: %w", err)
+	}
+
+	switch action {
+	case models.ReportActionHidePost:
+		_, err = tx.Exec(
+			ctx,
+			`UPDATE posts SET
+				hidden_at = COALESCE(hidden_at, NOW()),
+				updated_at = NOW()
+			WHERE id = $1`,
+			report.PostId,
+		)
+	case models.ReportActionDeletePost:
+		_, err = tx.Exec(
+			ctx,
+			`UPDATE posts SET
+				deleted_at = COALESCE(deleted_at, NOW()),
+				hidden_at = COALESCE(hidden_at, NOW()),
+				updated_at = NOW()
+			WHERE id = $1`,
+			report.PostId,
+		)
+	case models.ReportActionSuspendAuthor:
+		_, err = tx.Exec(
+			ctx,
+			`UPDATE users SET
+				suspended_at = COALESCE(suspended_at, NOW()),
+				updated_at = NOW()
+			WHERE id = $1`,
+			authorId,
+		)
+		if err == nil {
+			err = touchPosts(ctx, tx, "author_id = $1", authorId)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to apply moderation action: %w", err)
+	}
+
+	err = recordModerationAction(
+		ctx,
+		tx,
+		moderatorId,
+		&report,
+		string(action),
+		&authorId,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reports raised by the content filter have no reporter to tell.
+	if report.ReporterId != nil {
+		err = insertNotification(ctx, tx, models.NotificationCreate{
+			Payload: models.NotificationPayload{
+				Action:   &action,
+				ReportId: &report.ID,
+			},
+			PostId: &report.PostId,
+			Type:   models.NotificationTypeModeration,
+			UserId: *report.ReporterId,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("Failed to resolve report: %w", err)
+	}
+
+	return &report, nil
+}
+
+// recordModerationAction appends the action of the moderator on the report
+// to the audit log.
+func recordModerationAction(
+	ctx context.Context,
+	tx pgx.Tx,
+	moderatorId string,
+	report *models.Report,
+	action string,
+	userId *string,
+) error {
+	ib := sqlbuilder.PostgreSQL.NewInsertBuilder()
+	ib.InsertInto("moderation_actions")
+	ib.Cols("action", "moderator_id", "post_id", "report_id", "user_id")
+	ib.Values(action, moderatorId, report.PostId, report.ID, userId)
+	sql, args := ib.Build()
+
+	if _, err := tx.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("Failed to record moderation action: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"apps/api/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getTestReportRepo() *ReportRepo {
+	return NewReportRepo(testDbService.GetDB())
+}
+
+func TestReportRepo_Moderation(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should claim and resolve reports once", func(t *testing.T) {
+		cleanupTestDatabase()
+		postRepo := getTestPostRepo()
+		reportRepo := getTestReportRepo()
+		author := createTestAuthor(t, "author@example.com")
+		reporter := createTestAuthor(t, "reporter@example.com")
+		moderator := createTestAuthor(t, "moderator@example.com")
+		post := createTestPost(t, author.ID, "reported")
+
+		params := models.ReportCreate{
+			PostId:     post.ID,
+			Reason:     models.ReportReasonSpam,
+			ReporterId: &reporter.ID,
+		}
+		report, err := reportRepo.CreateReport(ctx, params)
+		require.NoError(t, err)
+		assert.Equal(t, models.ReportStatusOpen, report.Status)
+		_, err = reportRepo.CreateReport(ctx, params)
+		assert.ErrorIs(t, err, ErrReportExists)
+
+		_, err = reportRepo.ResolveReport(
+			ctx,
+			report.ID,
+			moderator.ID,
+			models.ReportActionHidePost,
+		)
+		assert.ErrorIs(t, err, ErrReportNotClaimed)
+
+		report, err = reportRepo.ClaimReport(ctx, report.ID, moderator.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.ReportStatusClaimed, report.Status)
+		_, err = reportRepo.ClaimReport(ctx, report.ID, reporter.ID)
+		assert.ErrorIs(t, err, ErrReportNotOpen)
+
+		report, err = reportRepo.ResolveReport(
+			ctx,
+			report.ID,
+			moderator.ID,
+			models.ReportActionHidePost,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, models.ReportStatusResolved, report.Status)
+		assert.Equal(t, models.ReportActionHidePost, *report.Action)
+
+		_, err = postRepo.GetPostById(ctx, reporter.ID, post.ID)
+		assert.Error(t, err)
+
+		status := models.ReportStatusOpen
+		open, err := reportRepo.GetReports(ctx, &status, nil, 10)
+		require.NoError(t, err)
+		assert.Empty(t, open)
+
+		var actions []string
+		rows, err := testDbService.GetDB().Query(
+			ctx,
+			`SELECT action FROM moderation_actions
+			WHERE report_id = $1 ORDER BY created_at`,
+			report.ID,
+		)
+		require.NoError(t, err)
+		defer rows.Close()
+		for rows.Next() {
+			var action string
+			require.NoError(t, rows.Scan(&action))
+			actions = append(actions, action)
+		}
+		assert.Equal(t, []string{"claim", "hide_post"}, actions)
+	})
+
+	t.Run("should hide posts of suspended authors", func(t *testing.T) {
+		cleanupTestDatabase()
+		postRepo := getTestPostRepo()
+		reportRepo := getTestReportRepo()
+		author := createTestAuthor(t, "suspended@example.com")
+		moderator := createTestAuthor(t, "moderator@example.com")
+		post := createTestPost(t, author.ID, "abusive")
+		createTestPost(t, author.ID, "another")
+
+		report, err := reportRepo.CreateReport(ctx, models.ReportCreate{
+			PostId:     post.ID,
+			Reason:     models.ReportReasonHarassment,
+			ReporterId: &moderator.ID,
+		})
+		require.NoError(t, err)
+		_, err = reportRepo.ClaimReport(ctx, report.ID, moderator.ID)
+		require.NoError(t, err)
+		_, err = reportRepo.ResolveReport(
+			ctx,
+			report.ID,
+			moderator.ID,
+			models.ReportActionSuspendAuthor,
+		)
+		require.NoError(t, err)
+
+		author, err = getTestUserRepo().GetUserById(ctx, author.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, author.SuspendedAt)
+
+		posts, total, err := postRepo.GetPosts(ctx, moderator.ID, 10, 0)
+		require.NoError(t, err)
+		assert.Empty(t, posts)
+		assert.Equal(t, 0, total)
+	})
+}
@@ -0,0 +1,107 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type RepostRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewRepostRepo(db *pgxpool.Pool) *RepostRepo {
+	return &RepostRepo{db: db}
+}
+
+// AddRepost shares the post with the followers of the user. Reposting a post
+// twice keeps the original repost time, the repost counter of the post is
+// only incremented when a repost was actually inserted.
+func (r *RepostRepo) AddRepost(
+	ctx context.Context,
+	userId string,
+	postId string,
+) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(
+		ctx,
+		`INSERT INTO reposts (user_id, post_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING`,
+		userId,
+		postId,
+	)
+	if err != nil {
+		return fmt.Errorf("Failed to add repost: %w", err)
+	}
+
+	if tag.RowsAffected() > 0 {
+		_, err = tx.Exec(
+			ctx,
+			`UPDATE posts SET
+				reposts_count = reposts_count + 1,
+				updated_at = NOW()
+			WHERE id = $1`,
+			postId,
+		)
+		if err != nil {
+			return fmt.Errorf("Failed to update reposts count: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("Failed to add repost: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveRepost removes the repost of the post by the user, removing a
+// repost that does not exist has no effect.
+func (r *RepostRepo) RemoveRepost(
+	ctx context.Context,
+	userId string,
+	postId string,
+) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(
+		ctx,
+		`DELETE FROM reposts WHERE user_id = $1 AND post_id = $2`,
+		userId,
+		postId,
+	)
+	if err != nil {
+		return fmt.Errorf("Failed to remove repost: %w", err)
+	}
+
+	if tag.RowsAffected() > 0 {
+		_, err = tx.Exec(
+			ctx,
+			`UPDATE posts SET
+				reposts_count = GREATEST(reposts_count - 1, 0),
+				updated_at = NOW()
+			WHERE id = $1`,
+			postId,
+		)
+		if err != nil {
+			return fmt.Errorf("Failed to update reposts count: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("Failed to remove repost: %w", err)
+	}
+
+	return nil
+}
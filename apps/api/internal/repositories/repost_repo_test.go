@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"apps/api/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepostRepo_Reposts(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should count reposts once per user", func(t *testing.T) {
+		cleanupTestDatabase()
+		repostRepo := NewRepostRepo(testDbService.GetDB())
+		author := createTestAuthor(t, "author@example.com")
+		reposter := createTestAuthor(t, "reposter@example.com")
+		post := createTestPost(t, author.ID, "original")
+
+		require.NoError(t, repostRepo.AddRepost(ctx, reposter.ID, post.ID))
+		require.NoError(t, repostRepo.AddRepost(ctx, reposter.ID, post.ID))
+
+		reposted, err := getTestPostRepo().GetPostById(ctx, reposter.ID, post.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, reposted.RepostsCount)
+		assert.NotNil(t, reposted.RepostedAt)
+
+		require.NoError(t, repostRepo.RemoveRepost(ctx, reposter.ID, post.ID))
+		require.NoError(t, repostRepo.RemoveRepost(ctx, reposter.ID, post.ID))
+
+		reposted, err = getTestPostRepo().GetPostById(ctx, reposter.ID, post.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, reposted.RepostsCount)
+		assert.Nil(t, reposted.RepostedAt)
+	})
+
+	t.Run("should show reposted posts once in the feed", func(t *testing.T) {
+		cleanupTestDatabase()
+		postRepo := getTestPostRepo()
+		repostRepo := NewRepostRepo(testDbService.GetDB())
+		followRepo := getTestFollowRepo()
+		viewer := createTestAuthor(t, "viewer@example.com")
+		author := createTestAuthor(t, "author@example.com")
+		first := createTestAuthor(t, "first@example.com")
+		second := createTestAuthor(t, "second@example.com")
+		require.NoError(t, followRepo.Follow(ctx, viewer.ID, author.ID))
+		require.NoError(t, followRepo.Follow(ctx, viewer.ID, first.ID))
+		require.NoError(t, followRepo.Follow(ctx, viewer.ID, second.ID))
+
+		original := createTestPost(t, author.ID, "original")
+		newer := createTestPost(t, author.ID, "newer")
+		require.NoError(t, repostRepo.AddRepost(ctx, first.ID, original.ID))
+		require.NoError(t, repostRepo.AddRepost(ctx, second.ID, original.ID))
+
+		page, err := postRepo.GetFeed(ctx, viewer.ID, nil, 1)
+		require.NoError(t, err)
+		require.Len(t, page, 1)
+		assert.Equal(t, original.ID, page[0].ID)
+		require.NotNil(t, page[0].Repost)
+		assert.Equal(t, second.ID, page[0].Repost.UserId)
+
+		page, err = postRepo.GetFeed(
+			ctx,
+			viewer.ID,
+			&models.PostCursor{
+				CreatedAt: page[0].Repost.CreatedAt,
+				Id:        page[0].ID,
+			},
+			10,
+		)
+		require.NoError(t, err)
+		require.Len(t, page, 1)
+		assert.Equal(t, newer.ID, page[0].ID)
+		assert.Nil(t, page[0].Repost)
+	})
+
+	t.Run("should hide deleted quoted posts", func(t *testing.T) {
+		cleanupTestDatabase()
+		postRepo := getTestPostRepo()
+		author := createTestAuthor(t, "author@example.com")
+		quoter := createTestAuthor(t, "quoter@example.com")
+		original := createTestPost(t, author.ID, "original")
+		quote, err := postRepo.CreatePost(ctx, models.PostCreate{
+			AuthorId:     quoter.ID,
+			Content:      "quote",
+			QuotedPostId: &original.ID,
+			Title:        "quote",
+		})
+		require.NoError(t, err)
+		require.NotNil(t, quote.QuotedPost)
+		assert.Equal(t, original.ID, quote.QuotedPost.ID)
+
+		require.NoError(t, postRepo.DeletePost(ctx, original.ID, nil))
+
+		quote, err = postRepo.GetPostById(ctx, quoter.ID, quote.ID)
+		require.NoError(t, err)
+		assert.Equal(t, &original.ID, quote.QuotedPostId)
+		assert.Nil(t, quote.QuotedPost)
+	})
+}
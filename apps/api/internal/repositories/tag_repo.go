@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"apps/api/internal/models"
+)
+
+type TagRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewTagRepo(db *pgxpool.Pool) *TagRepo {
+	return &TagRepo{db: db}
+}
+
+var popularTagStruct = sqlbuilder.NewStruct(new(models.PopularTag)).
+	For(sqlbuilder.PostgreSQL)
+
+// GetPopularTags returns the tags used by the most posts created since the
+// given time. Only public posts that anyone can read are counted.
+func (r *TagRepo) GetPopularTags(
+	ctx context.Context,
+	since time.Time,
+	limit int,
+) ([]*models.PopularTag, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+	sb.Select("tags.name", "COUNT(*) AS posts_count")
+	sb.From("post_tags")
+	sb.Join("tags", "tags.id = post_tags.tag_id")
+	sb.Join("posts", "posts.id = post_tags.post_id")
+	sb.Where(sb.GreaterEqualThan("post_tags.created_at", since))
+	filterPosts(sb, "", postReadList)
+	sb.GroupBy("tags.name")
+	sb.OrderBy("posts_count DESC", "tags.name")
+	sb.Limit(limit)
+	sql, args := sb.Build()
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query popular tags: %w", err)
+	}
+	defer rows.Close()
+
+	var tags []*models.PopularTag
+	for rows.Next() {
+		var tag models.PopularTag
+		err := rows.Scan(popularTagStruct.Addr(&tag)...)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to scan popular tag: %w", err)
+		}
+		tags = append(tags, &tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Failed to read popular tags: %w", err)
+	}
+
+	return tags, nil
+}
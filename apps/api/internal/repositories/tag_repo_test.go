@@ -0,0 +1,140 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"apps/api/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagRepo_Tags(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run(
+		"should merge explicit tags with hashtags of content",
+		func(t *testing.T) {
+			cleanupTestDatabase()
+			postRepo := getTestPostRepo()
+			author := createTestAuthor(t, "tags@example.com")
+
+			post, err := postRepo.CreatePost(ctx, models.PostCreate{
+				AuthorId: author.ID,
+				Content:  "Learning #Go today",
+				Tags:     []string{"#Programming", "go"},
+				Title:    "tagged",
+			})
+			require.NoError(t, err)
+			assert.Equal(t, []string{"go", "programming"}, post.Tags)
+
+			content := "Now about #rust"
+			post, err = postRepo.UpdatePost(
+				ctx,
+				post.ID,
+				author.ID,
+				models.PostUpdate{Content: &content},
+				nil,
+			)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"go", "programming", "rust"}, post.Tags)
+
+			tags := []string{"news"}
+			post, err = postRepo.UpdatePost(
+				ctx,
+				post.ID,
+				author.ID,
+				models.PostUpdate{Tags: &tags},
+				nil,
+			)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"news", "rust"}, post.Tags)
+
+			post, err = postRepo.GetPostById(ctx, author.ID, post.ID)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"news", "rust"}, post.Tags)
+		},
+	)
+
+	t.Run("should list posts by tag and popular tags", func(t *testing.T) {
+		cleanupTestDatabase()
+		postRepo := getTestPostRepo()
+		tagRepo := NewTagRepo(testDbService.GetDB())
+		author := createTestAuthor(t, "popular@example.com")
+
+		var tagged []string
+		for _, content := range []string{"#a #b", "#a", "#a #c"} {
+			post, err := postRepo.CreatePost(ctx, models.PostCreate{
+				AuthorId: author.ID,
+				Content:  content,
+				Title:    content,
+			})
+			require.NoError(t, err)
+			tagged = append(tagged, post.ID)
+		}
+		require.NoError(t, postRepo.DeletePost(ctx, tagged[2], nil))
+
+		posts, err := postRepo.GetPostsByTag(ctx, author.ID, "a", nil, 1)
+		require.NoError(t, err)
+		require.Len(t, posts, 1)
+		assert.Equal(t, tagged[1], posts[0].ID)
+
+		posts, err = postRepo.GetPostsByTag(
+			ctx,
+			author.ID,
+			"a",
+			&models.PostCursor{
+				CreatedAt: posts[0].CreatedAt,
+				Id:        posts[0].ID,
+			},
+			10,
+		)
+		require.NoError(t, err)
+		require.Len(t, posts, 1)
+		assert.Equal(t, tagged[0], posts[0].ID)
+
+		popular, err := tagRepo.GetPopularTags(
+			ctx,
+			time.Now().Add(-time.Hour),
+			10,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, []*models.PopularTag{
+			{Name: "a", PostsCount: 2},
+			{Name: "b", PostsCount: 1},
+		}, popular)
+	})
+
+	t.Run("should only count public posts as popular", func(t *testing.T) {
+		cleanupTestDatabase()
+		postRepo := getTestPostRepo()
+		tagRepo := NewTagRepo(testDbService.GetDB())
+		author := createTestAuthor(t, "author@example.com")
+		for _, visibility := range []models.PostVisibility{
+			models.PostVisibilityPublic,
+			models.PostVisibilityUnlisted,
+			models.PostVisibilityFollowers,
+			models.PostVisibilityPrivate,
+		} {
+			_, err := postRepo.CreatePost(ctx, models.PostCreate{
+				AuthorId:   author.ID,
+				Content:    "#a",
+				Title:      string(visibility),
+				Visibility: visibility,
+			})
+			require.NoError(t, err)
+		}
+
+		popular, err := tagRepo.GetPopularTags(
+			ctx,
+			time.Now().Add(-time.Hour),
+			10,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, []*models.PopularTag{
+			{Name: "a", PostsCount: 1},
+		}, popular)
+	})
+}
@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// execer runs statements on the pool or in a transaction.
+type execer interface {
+	Exec(
+		ctx context.Context,
+		sql string,
+		args ...any,
+	) (pgconn.CommandTag, error)
+}
+
+// touchPosts sets the updated_at of the posts matching the SQL condition to
+// now. Posts are shown with rows of other tables, such as bookmarks, votes,
+// media and link previews, and their Last-Modified validator is derived from
+// updated_at, so changes of those rows touch the posts they are shown with.
+func touchPosts(
+	ctx context.Context,
+	db execer,
+	condition string,
+	args ...any,
+) error {
+	_, err := db.Exec(
+		ctx,
+		`UPDATE posts SET updated_at = NOW() WHERE `+condition,
+		args...,
+	)
+	if err != nil {
+		return fmt.Errorf("Failed to touch posts: %w", err)
+	}
+
+	return nil
+}
+
+// touchUsers sets the updated_at of the users to now. The posts a user reads
+// depend on whom they follow, block and mute, and the Last-Modified validator
+// of their reads includes their updated_at.
+func touchUsers(ctx context.Context, db execer, ids ...string) error {
+	_, err := db.Exec(
+		ctx,
+		`UPDATE users SET updated_at = NOW() WHERE id = ANY($1::uuid[])`,
+		ids,
+	)
+	if err != nil {
+		return fmt.Errorf("Failed to touch users: %w", err)
+	}
+
+	return nil
+}
@@ -2,15 +2,25 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand/v2"
 	"strings"
 
 	"github.com/huandu/go-sqlbuilder"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"apps/api/internal/models"
+	"apps/api/internal/utils"
 )
 
+// generatedHandleAttempts is how often a handle derived from the email
+// address is suffixed with random digits before giving up.
+const generatedHandleAttempts = 5
+
+var ErrHandleTaken = errors.New("Handle is already taken")
+
 type UserRepo struct {
 	db *pgxpool.Pool
 }
@@ -22,19 +32,51 @@ func NewUserRepo(db *pgxpool.Pool) *UserRepo {
 var userStruct = sqlbuilder.NewStruct(new(models.User)).
 	For(sqlbuilder.PostgreSQL)
 
+// CreateUser inserts the user. Users registering without a handle get one
+// derived from their email address, suffixed with random digits when it is
+// taken.
 func (r *UserRepo) CreateUser(
 	ctx context.Context,
 	userCreate models.UserCreate,
+) (*models.User, error) {
+	if userCreate.Handle != "" {
+		return r.insertUser(ctx, userCreate)
+	}
+
+	base := utils.HandleFromEmail(userCreate.Email)
+	userCreate.Handle = base
+	for range generatedHandleAttempts {
+		user, err := r.insertUser(ctx, userCreate)
+		if !errors.Is(err, ErrHandleTaken) {
+			return user, err
+		}
+		userCreate.Handle = fmt.Sprintf(
+			"%s_%04d",
+			base[:min(len(base), utils.MaxHandleLength-5)],
+			rand.IntN(10000),
+		)
+	}
+	return r.insertUser(ctx, userCreate)
+}
+
+func (r *UserRepo) insertUser(
+	ctx context.Context,
+	userCreate models.UserCreate,
 ) (*models.User, error) {
 	ib := sqlbuilder.PostgreSQL.NewInsertBuilder()
 	ib.InsertInto("users")
-	ib.Cols("email", "password_hash")
-	ib.Values(userCreate.Email, userCreate.PasswordHash)
+	ib.Cols("email", "handle", "password_hash")
+	ib.Values(userCreate.Email, userCreate.Handle, userCreate.PasswordHash)
 	ib.Returning(strings.Join(userStruct.Columns(), ","))
 	sql, args := ib.Build()
 
 	var user models.User
 	err := r.db.QueryRow(ctx, sql, args...).Scan(userStruct.Addr(&user)...)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" &&
+		pgErr.ConstraintName == "users_handle_key" {
+		return nil, fmt.Errorf("Failed to create user: %w", ErrHandleTaken)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("Failed to create user: %w", err)
 	}
@@ -48,6 +90,13 @@ func (r *UserRepo) GetUserByEmail(
 	return r.getUserByUniqField(ctx, "email", email)
 }
 
+func (r *UserRepo) GetUserByHandle(
+	ctx context.Context,
+	handle string,
+) (*models.User, error) {
+	return r.getUserByUniqField(ctx, "handle", handle)
+}
+
 func (r *UserRepo) GetUserById(
 	ctx context.Context,
 	id string,
@@ -55,6 +104,41 @@ func (r *UserRepo) GetUserById(
 	return r.getUserByUniqField(ctx, "id", id)
 }
 
+// GetUsersByIds returns the users with the given ids that exist, in no
+// particular order.
+func (r *UserRepo) GetUsersByIds(
+	ctx context.Context,
+	ids []string,
+) ([]*models.User, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	sb := userStruct.SelectFrom("users")
+	sb.Where(fmt.Sprintf("id = ANY(%s::uuid[])", sb.Var(ids)))
+	query, args := sb.Build()
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*models.User
+	for rows.Next() {
+		var user models.User
+		if err := rows.Scan(userStruct.Addr(&user)...); err != nil {
+			return nil, fmt.Errorf("Failed to scan user: %w", err)
+		}
+		users = append(users, &user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Failed to read users: %w", err)
+	}
+
+	return users, nil
+}
+
 func (r *UserRepo) getUserByUniqField(
 	ctx context.Context,
 	fieldName string,
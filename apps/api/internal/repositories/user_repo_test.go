@@ -61,6 +61,44 @@ func TestUserRepo_CreateUser(t *testing.T) {
 			assert.Contains(t, err.Error(), "Failed to create user")
 		},
 	)
+
+	t.Run("should derive handles from email addresses", func(t *testing.T) {
+		cleanupTestDatabase()
+		userRepo := getTestUserRepo()
+
+		first, err := userRepo.CreateUser(ctx, models.UserCreate{
+			Email:        "Jane.Doe@example.com",
+			PasswordHash: "hash",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "jane_doe", first.Handle)
+
+		second, err := userRepo.CreateUser(ctx, models.UserCreate{
+			Email:        "jane.doe@example.org",
+			PasswordHash: "hash",
+		})
+		require.NoError(t, err)
+		assert.Regexp(t, `^jane_doe_\d{4}$`, second.Handle)
+	})
+
+	t.Run("should fail when the handle is taken", func(t *testing.T) {
+		cleanupTestDatabase()
+		userRepo := getTestUserRepo()
+
+		_, err := userRepo.CreateUser(ctx, models.UserCreate{
+			Email:        "first@example.com",
+			Handle:       "taken",
+			PasswordHash: "hash",
+		})
+		require.NoError(t, err)
+
+		_, err = userRepo.CreateUser(ctx, models.UserCreate{
+			Email:        "second@example.com",
+			Handle:       "taken",
+			PasswordHash: "hash",
+		})
+		assert.ErrorIs(t, err, ErrHandleTaken)
+	})
 }
 
 func TestUserRepo_GetUserByEmail(t *testing.T) {
@@ -0,0 +1,36 @@
+package schemas
+
+import (
+	z "github.com/Oudwins/zog"
+
+	"apps/api/internal/api"
+)
+
+var commentContent = z.String().
+	Trim().
+	Min(1, z.Message("Should not be empty")).
+	Max(2000, z.Message("Should be less than 2000 characters"))
+
+var CreateCommentRequestSchema = z.Struct(z.Shape{
+	"content": commentContent.Required(z.Message("Content is required")),
+	"parentId": z.Ptr(
+		z.String().UUID(z.Message("Must be a valid comment id")).Optional(),
+	),
+})
+
+var GetPostsPostIdCommentsParamsSchema = z.Struct(z.Shape{
+	"limit": limitParam,
+	"parentId": z.Ptr(
+		z.String().UUID(z.Message("Must be a valid comment id")).Optional(),
+	),
+	"sort": z.Ptr(
+		z.StringLike[api.GetPostsPostIdCommentsParamsSort]().OneOf(
+			[]api.GetPostsPostIdCommentsParamsSort{api.Newest, api.Top},
+			z.Message("Sort must be one of: newest, top"),
+		).Optional(),
+	),
+})
+
+var UpdateCommentRequestSchema = z.Struct(z.Shape{
+	"content": commentContent.Required(z.Message("Content is required")),
+})
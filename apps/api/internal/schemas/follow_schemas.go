@@ -0,0 +1,11 @@
+package schemas
+
+import z "github.com/Oudwins/zog"
+
+var GetUsersUserIdFollowersParamsSchema = z.Struct(z.Shape{
+	"limit": limitParam,
+})
+
+var GetUsersUserIdFollowingParamsSchema = z.Struct(z.Shape{
+	"limit": limitParam,
+})
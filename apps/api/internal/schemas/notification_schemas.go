@@ -0,0 +1,7 @@
+package schemas
+
+import z "github.com/Oudwins/zog"
+
+var GetNotificationsParamsSchema = z.Struct(z.Shape{
+	"limit": limitParam,
+})
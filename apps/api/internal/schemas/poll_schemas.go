@@ -0,0 +1,10 @@
+package schemas
+
+import z "github.com/Oudwins/zog"
+
+var CreatePollVoteRequestSchema = z.Struct(z.Shape{
+	"optionIds": z.Slice(z.String()).
+		Min(1, z.Message("Should have at least 1 option")).
+		Max(10, z.Message("Should have at most 10 options")).
+		Required(z.Message("Options are required")),
+})
@@ -1,29 +1,143 @@
 package schemas
 
-import z "github.com/Oudwins/zog"
+import (
+	"regexp"
+	"time"
+
+	z "github.com/Oudwins/zog"
+
+	"apps/api/internal/api"
+)
 
 var postContent = z.String().
 	Max(5000, z.Message("Should be less than 5000 characters"))
 
+var postContentFormat = z.Ptr(
+	z.StringLike[api.ContentFormat]().OneOf(
+		[]api.ContentFormat{api.Plain, api.Markdown},
+		z.Message("Content format must be one of: plain, markdown"),
+	).Optional(),
+)
+
 var postTitle = z.String().
 	Max(100, z.Message("Should be less than 100 characters"))
 
+var postTag = z.String().
+	Trim().
+	Match(
+		regexp.MustCompile(`^#?[\p{L}_][\p{L}\p{N}_]{0,49}$`),
+		z.Message(
+			"Should be up to 50 letters, digits or underscores "+
+				"starting with a letter",
+		),
+	)
+
+var postTags = z.Ptr(
+	z.Slice(postTag).
+		Max(10, z.Message("Should have at most 10 tags")).
+		Optional(),
+)
+
+var postVisibility = z.Ptr(
+	z.StringLike[api.PostVisibility]().OneOf(
+		[]api.PostVisibility{
+			api.Public,
+			api.Followers,
+			api.Private,
+			api.Unlisted,
+		},
+		z.Message(
+			"Visibility must be one of: public, followers, private, unlisted",
+		),
+	).Optional(),
+)
+
+var postMediaIds = z.Ptr(
+	z.Slice(z.String().UUID(z.Message("Must be a valid media id"))).
+		Max(4, z.Message("Should have at most 4 media")).
+		Optional(),
+)
+
+var postPoll = z.Ptr(
+	z.Struct(z.Shape{
+		"closesAt": z.Time().
+			Required(z.Message("Closing time is required")).
+			TestFunc(
+				func(closesAt *time.Time, _ z.Ctx) bool {
+					return closesAt.After(time.Now())
+				},
+				z.Message("Should be in the future"),
+			),
+		"options": z.Slice(
+			z.String().
+				Trim().
+				Min(1, z.Message("Should not be empty")).
+				Max(100, z.Message("Should be less than 100 characters")).
+				Required(z.Message("Should not be empty")),
+		).
+			Min(2, z.Message("Should have at least 2 options")).
+			Max(10, z.Message("Should have at most 10 options")).
+			Required(z.Message("Options are required")),
+	}).Optional(),
+)
+
 var CreatePostRequestSchema = z.Struct(z.Shape{
-	"content": postContent.Required(z.Message("Content is required")),
-	"title":   postTitle.Required(z.Message("Title is required")),
+	"content":       postContent.Required(z.Message("Content is required")),
+	"contentFormat": postContentFormat,
+	"mediaIds":      postMediaIds,
+	"poll":          postPoll,
+	"tags":          postTags,
+	"title":         postTitle.Required(z.Message("Title is required")),
+	"visibility":    postVisibility,
 })
 
+var limitParam = z.Ptr(
+	z.Int().GTE(
+		1, z.Message("Limit must be 1 or greater"),
+	).LTE(100, z.Message("Limit must be less or equal 100")).Optional())
+
+var offsetParam = z.Ptr(
+	z.Int().GTE(0, z.Message("Offset must be 0 or greater")).Optional(),
+)
+
 var GetPostsParamsSchema = z.Struct(z.Shape{
-	"limit": z.Ptr(
-		z.Int().GTE(
-			1, z.Message("Limit must be 1 or greater"),
-		).LTE(100, z.Message("Limit must be less or equal 100")).Optional()),
-	"offset": z.Ptr(
-		z.Int().GTE(0, z.Message("Offset must be 0 or greater")).Optional(),
+	"limit":  limitParam,
+	"offset": offsetParam,
+})
+
+var GetPostsPostIdRevisionsParamsSchema = z.Struct(z.Shape{
+	"limit":  limitParam,
+	"offset": offsetParam,
+})
+
+var GetPostsPostIdStatsParamsSchema = z.Struct(z.Shape{
+	"days": z.Ptr(
+		z.Int().GTE(1, z.Message("Days must be 1 or greater")).
+			LTE(365, z.Message("Days must be less or equal 365")).
+			Optional(),
+	),
+})
+
+var GetUsersMeTrashParamsSchema = z.Struct(z.Shape{
+	"limit":  limitParam,
+	"offset": offsetParam,
+})
+
+var GetUsersUserIdPostsParamsSchema = z.Struct(z.Shape{
+	"limit": limitParam,
+})
+
+var PutPostsPostIdPinParamsSchema = z.Struct(z.Shape{
+	"position": z.Ptr(
+		z.Int().GTE(1, z.Message("Position must be 1 or greater")).
+			Optional(),
 	),
 })
 
 var UpdatePostRequestSchema = z.Struct(z.Shape{
-	"content": z.Ptr(postContent.Optional()),
-	"title":   z.Ptr(postTitle.Optional()),
+	"content":       z.Ptr(postContent.Optional()),
+	"contentFormat": postContentFormat,
+	"tags":          postTags,
+	"title":         z.Ptr(postTitle.Optional()),
+	"visibility":    postVisibility,
 })
@@ -0,0 +1,25 @@
+package schemas
+
+import (
+	z "github.com/Oudwins/zog"
+
+	"apps/api/internal/utils"
+)
+
+var reactionEmoji = z.String().
+	TestFunc(
+		func(emoji *string, _ z.Ctx) bool {
+			return utils.IsEmoji(*emoji)
+		},
+		z.Message("Must be a single emoji"),
+	)
+
+var GetPostsPostIdReactionsParamsSchema = z.Struct(z.Shape{
+	"emoji":  z.Ptr(reactionEmoji.Optional()),
+	"limit":  limitParam,
+	"offset": offsetParam,
+})
+
+var ReactionEmojiSchema = z.Struct(z.Shape{
+	"emoji": reactionEmoji.Required(z.Message("Emoji is required")),
+})
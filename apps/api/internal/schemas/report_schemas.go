@@ -0,0 +1,56 @@
+package schemas
+
+import (
+	z "github.com/Oudwins/zog"
+
+	"apps/api/internal/api"
+)
+
+var CreateReportRequestSchema = z.Struct(z.Shape{
+	"reason": z.StringLike[api.ReportReason]().OneOf(
+		[]api.ReportReason{
+			api.Spam,
+			api.Harassment,
+			api.Hate,
+			api.Violence,
+			api.Sexual,
+			api.Misinformation,
+			api.Other,
+		},
+		z.Message(
+			"Reason must be one of: spam, harassment, hate, violence, "+
+				"sexual, misinformation, other",
+		),
+	).Required(z.Message("Reason is required")),
+	"details": z.Ptr(
+		z.String().
+			Trim().
+			Max(1000, z.Message("Should be less than 1000 characters")).
+			Optional(),
+	),
+})
+
+var GetModerationReportsParamsSchema = z.Struct(z.Shape{
+	"limit": limitParam,
+	"status": z.Ptr(
+		z.StringLike[api.ReportStatus]().OneOf(
+			[]api.ReportStatus{api.Open, api.Claimed, api.Resolved},
+			z.Message("Status must be one of: open, claimed, resolved"),
+		).Optional(),
+	),
+})
+
+var ResolveReportRequestSchema = z.Struct(z.Shape{
+	"action": z.StringLike[api.ReportAction]().OneOf(
+		[]api.ReportAction{
+			api.Dismiss,
+			api.HidePost,
+			api.DeletePost,
+			api.SuspendAuthor,
+		},
+		z.Message(
+			"Action must be one of: dismiss, hide_post, delete_post, "+
+				"suspend_author",
+		),
+	).Required(z.Message("Action is required")),
+})
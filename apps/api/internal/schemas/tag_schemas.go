@@ -0,0 +1,20 @@
+package schemas
+
+import z "github.com/Oudwins/zog"
+
+var GetTagsPopularParamsSchema = z.Struct(z.Shape{
+	"days": z.Ptr(
+		z.Int().GTE(1, z.Message("Days must be 1 or greater")).
+			LTE(30, z.Message("Days must be less or equal 30")).
+			Optional(),
+	),
+	"limit": limitParam,
+})
+
+var GetTagsTagPostsParamsSchema = z.Struct(z.Shape{
+	"limit": limitParam,
+})
+
+var TagSchema = z.Struct(z.Shape{
+	"tag": postTag.Required(z.Message("Tag is required")),
+})
@@ -0,0 +1,37 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// cacheControlPolicies maps "METHOD route" to the Cache-Control header sent
+// with successful responses of the route. Responses are private because they
+// depend on the authenticated user.
+var cacheControlPolicies = map[string]string{
+	"GET /api/v1/posts":         "private, no-cache",
+	"GET /api/v1/posts/:postId": "private, max-age=60, must-revalidate",
+	"GET /api/v1/users/me":      "private, max-age=300, must-revalidate",
+}
+
+func cacheControlMiddleware(
+	policies map[string]string,
+) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			policy, ok := policies[c.Request().Method+" "+c.Path()]
+			if !ok {
+				return next(c)
+			}
+
+			c.Response().Before(func() {
+				status := c.Response().Status
+				if status == http.StatusOK || status == http.StatusNotModified {
+					c.Response().Header().Set("Cache-Control", policy)
+				}
+			})
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheControlMiddleware(t *testing.T) {
+	e := echo.New()
+	e.Use(cacheControlMiddleware(map[string]string{
+		"GET /cached": "private, no-cache",
+	}))
+	e.GET("/cached", func(c echo.Context) error {
+		return c.String(http.StatusOK, "ok")
+	})
+	e.GET("/uncached", func(c echo.Context) error {
+		return c.String(http.StatusOK, "ok")
+	})
+	e.GET("/cached-missing", func(c echo.Context) error {
+		return c.String(http.StatusNotFound, "missing")
+	})
+
+	for _, tc := range []struct {
+		path     string
+		expected string
+	}{
+		{path: "/cached", expected: "private, no-cache"},
+		{path: "/uncached", expected: ""},
+		{path: "/cached-missing", expected: ""},
+	} {
+		req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, tc.expected, rec.Header().Get("Cache-Control"), tc.path)
+	}
+}
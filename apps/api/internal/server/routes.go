@@ -48,7 +48,15 @@ func (s *Server) registerMiddleware(e *echo.Echo) {
 			"Accept",
 			"Authorization",
 			"Content-Type",
+			"If-Match",
+			"If-Modified-Since",
+			"If-None-Match",
 			"X-CSRF-Token",
+			"X-Device-Id",
+		},
+		ExposeHeaders: []string{
+			"ETag",
+			"Last-Modified",
 		},
 		AllowCredentials: true,
 		MaxAge:           300,
@@ -63,6 +71,7 @@ func (s *Server) registerMiddleware(e *echo.Echo) {
 				"/api/v1/auth/login",
 				"/api/v1/auth/refresh",
 				"/api/v1/auth/register",
+				"/api/v1/media/:mediaId",
 				"/api/v1/ping",
 				"/docs",
 			}
@@ -90,6 +99,10 @@ func (s *Server) registerMiddleware(e *echo.Echo) {
 			return next(c)
 		}
 	})
+	e.Use(suspensionMiddleware(
+		newSuspensionCache(repositories.NewUserRepo(s.db.GetDB())),
+	))
+	e.Use(cacheControlMiddleware(cacheControlPolicies))
 }
 
 func (s *Server) registerRoutes(e *echo.Echo) {
@@ -97,24 +110,174 @@ func (s *Server) registerRoutes(e *echo.Echo) {
 
 	db := s.db.GetDB()
 
+	blockRepo := repositories.NewBlockRepo(db)
+	bookmarkRepo := repositories.NewBookmarkRepo(db)
+	commentRepo := repositories.NewCommentRepo(db)
+	followRepo := repositories.NewFollowRepo(db)
+	linkPreviewRepo := repositories.NewLinkPreviewRepo(db)
+	mediaRepo := repositories.NewMediaRepo(db)
+	muteRepo := repositories.NewMuteRepo(db)
+	notificationRepo := repositories.NewNotificationRepo(db)
+	pollRepo := repositories.NewPollRepo(db)
 	postRepo := repositories.NewPostRepo(db)
+	postRevisionRepo := repositories.NewPostRevisionRepo(db)
+	postViewRepo := repositories.NewPostViewRepo(db)
+	reactionRepo := repositories.NewReactionRepo(db)
+	reportRepo := repositories.NewReportRepo(db)
+	repostRepo := repositories.NewRepostRepo(db)
+	tagRepo := repositories.NewTagRepo(db)
 	userRepo := repositories.NewUserRepo(db)
 
+	blobStore, err := services.NewBlobStore(s.config.Media)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+	contentFilter, err := services.LoadContentFilter(
+		s.config.Posts.ContentFilterRulesPath,
+		postRepo,
+	)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+	contentRenderer := services.NewContentRenderer()
+	feedService := services.NewFeedService(postRepo)
 	jwtService := services.NewJWTService(s.config.Jwt)
 
+	linkPreviewService := services.NewLinkPreviewService(
+		services.NewLinkPreviewFetcher(s.config.LinkPreviews),
+		linkPreviewRepo,
+		s.config.LinkPreviews,
+	)
+	s.runWorker(linkPreviewService.Run)
+
+	mediaURLSigner, err := services.NewMediaURLSigner(s.config.Media)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+	mediaProcessor := services.NewMediaProcessor(
+		blobStore,
+		mediaRepo,
+		s.config.Media,
+	)
+	s.runWorker(mediaProcessor.Run)
+
+	mediaService := services.NewMediaService(
+		blobStore,
+		mediaProcessor,
+		mediaRepo,
+		s.config.Media,
+	)
+	s.runWorker(mediaService.Run)
+
+	postRetentionService := services.NewPostRetentionService(
+		postRepo,
+		s.config.Posts,
+	)
+	s.runWorker(postRetentionService.Run)
+
+	postViewRecorder := services.NewPostViewRecorder(
+		postViewRepo,
+		s.config.Posts,
+	)
+	s.runWorker(postViewRecorder.Run)
+	s.flushOnShutdown(postViewRecorder.Flush)
+
 	authHandler := handlers.NewAuthHandler(userRepo, jwtService)
+	blockHandler := handlers.NewBlockHandler(blockRepo, userRepo)
+	bookmarkHandler := handlers.NewBookmarkHandler(
+		bookmarkRepo,
+		mediaURLSigner,
+		postRepo,
+	)
+	commentHandler := handlers.NewCommentHandler(commentRepo, postRepo)
+	feedHandler := handlers.NewFeedHandler(feedService, mediaURLSigner)
+	followHandler := handlers.NewFollowHandler(followRepo, userRepo)
+	mediaHandler := handlers.NewMediaHandler(
+		s.config.Media,
+		mediaService,
+		mediaURLSigner,
+	)
+	muteHandler := handlers.NewMuteHandler(muteRepo, userRepo)
+	notificationHandler := handlers.NewNotificationHandler(notificationRepo)
+	pinHandler := handlers.NewPinHandler(s.config.Posts, postRepo)
 	pingHandler := handlers.NewPingHandler()
-	postHandler := handlers.NewPostHandler(postRepo, userRepo)
-	userHandler := handlers.NewUserHandler(userRepo)
+	pollHandler := handlers.NewPollHandler(pollRepo, postRepo)
+	postHandler := handlers.NewPostHandler(
+		contentFilter,
+		contentRenderer,
+		linkPreviewService,
+		mediaRepo,
+		mediaURLSigner,
+		postRepo,
+		postViewRecorder,
+		reportRepo,
+		userRepo,
+	)
+	postRevisionHandler := handlers.NewPostRevisionHandler(
+		linkPreviewService,
+		mediaURLSigner,
+		postRepo,
+		postRevisionRepo,
+	)
+	postStatsHandler := handlers.NewPostStatsHandler(
+		postRepo,
+		postViewRepo,
+		userRepo,
+	)
+	reactionHandler := handlers.NewReactionHandler(
+		mediaURLSigner,
+		postRepo,
+		reactionRepo,
+	)
+	reportHandler := handlers.NewReportHandler(postRepo, reportRepo, userRepo)
+	repostHandler := handlers.NewRepostHandler(
+		mediaURLSigner,
+		postRepo,
+		repostRepo,
+	)
+	tagHandler := handlers.NewTagHandler(mediaURLSigner, postRepo, tagRepo)
+	userHandler := handlers.NewUserHandler(followRepo, userRepo)
 	combinedHandler := struct {
 		*handlers.AuthHandler
+		*handlers.BlockHandler
+		*handlers.BookmarkHandler
+		*handlers.CommentHandler
+		*handlers.FeedHandler
+		*handlers.FollowHandler
+		*handlers.MediaHandler
+		*handlers.MuteHandler
+		*handlers.NotificationHandler
+		*handlers.PinHandler
 		*handlers.PingHandler
+		*handlers.PollHandler
 		*handlers.PostHandler
+		*handlers.PostRevisionHandler
+		*handlers.PostStatsHandler
+		*handlers.ReactionHandler
+		*handlers.ReportHandler
+		*handlers.RepostHandler
+		*handlers.TagHandler
 		*handlers.UserHandler
 	}{
 		authHandler,
+		blockHandler,
+		bookmarkHandler,
+		commentHandler,
+		feedHandler,
+		followHandler,
+		mediaHandler,
+		muteHandler,
+		notificationHandler,
+		pinHandler,
 		pingHandler,
+		pollHandler,
 		postHandler,
+		postRevisionHandler,
+		postStatsHandler,
+		reactionHandler,
+		reportHandler,
+		repostHandler,
+		tagHandler,
 		userHandler,
 	}
 
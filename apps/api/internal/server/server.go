@@ -1,9 +1,12 @@
 package server
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
+	"sync"
 	"time"
 
 	_ "github.com/joho/godotenv/autoload"
@@ -13,23 +16,34 @@ import (
 )
 
 type Server struct {
+	*http.Server
+
 	config *config.Config
 	db     database.Service
+	// Background workers run with workersCtx until the server shuts down,
+	// flushers are run once they stopped.
+	flushers    []func(ctx context.Context)
+	stopWorkers context.CancelFunc
+	workers     sync.WaitGroup
+	workersCtx  context.Context
 }
 
-func NewServer() *http.Server {
+func NewServer() *Server {
 	config, err := config.LoadConfig()
 	if err != nil {
 		fmt.Println("Error loading config:", err)
 		os.Exit(1)
 	}
 
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
 	NewServer := &Server{
-		config: config,
-		db:     database.New(config.Db),
+		config:      config,
+		db:          database.New(config.Db),
+		stopWorkers: stopWorkers,
+		workersCtx:  workersCtx,
 	}
 
-	server := &http.Server{
+	NewServer.Server = &http.Server{
 		Addr:         fmt.Sprintf(":%d", NewServer.config.App.Port),
 		Handler:      NewServer.RegisterRoutes(),
 		IdleTimeout:  time.Minute,
@@ -37,5 +51,46 @@ func NewServer() *http.Server {
 		WriteTimeout: 30 * time.Second,
 	}
 
-	return server
+	return NewServer
+}
+
+// Shutdown stops the HTTP server first, so that no request is left to hand
+// work to the background workers, then stops the workers and waits until
+// they finished and runs the flushers, e.g. writing buffered post views.
+// Flushers run with ctx, so they are bounded by the shutdown deadline too.
+func (s *Server) Shutdown(ctx context.Context) error {
+	err := s.Server.Shutdown(ctx)
+
+	s.stopWorkers()
+	stopped := make(chan struct{})
+	go func() {
+		s.workers.Wait()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		return errors.Join(err, ctx.Err())
+	}
+
+	for _, flush := range s.flushers {
+		flush(ctx)
+	}
+	return errors.Join(err, ctx.Err())
+}
+
+// flushOnShutdown registers flush to run once the background workers
+// stopped.
+func (s *Server) flushOnShutdown(flush func(ctx context.Context)) {
+	s.flushers = append(s.flushers, flush)
+}
+
+// runWorker runs the background worker until the server shuts down.
+func (s *Server) runWorker(run func(ctx context.Context)) {
+	s.workers.Add(1)
+	go func() {
+		defer s.workers.Done()
+		run(s.workersCtx)
+	}()
 }
@@ -0,0 +1,120 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"apps/api/internal/models"
+)
+
+// suspensionCacheTTL is how long the suspension state of a user is reused,
+// and so how long a suspended user with a valid access token can keep
+// writing.
+const suspensionCacheTTL = time.Minute
+
+// userStore looks up users, nil is returned for users that do not exist.
+type userStore interface {
+	GetUserById(ctx context.Context, id string) (*models.User, error)
+}
+
+type userState struct {
+	checkedAt time.Time
+	exists    bool
+	suspended bool
+}
+
+// suspensionCache remembers for suspensionCacheTTL whether users exist and
+// are suspended.
+type suspensionCache struct {
+	mu     sync.Mutex
+	now    func() time.Time
+	states map[string]userState
+	store  userStore
+}
+
+func newSuspensionCache(store userStore) *suspensionCache {
+	return &suspensionCache{
+		now:    time.Now,
+		states: map[string]userState{},
+		store:  store,
+	}
+}
+
+func (c *suspensionCache) get(
+	ctx context.Context,
+	userId string,
+) (userState, error) {
+	now := c.now()
+
+	c.mu.Lock()
+	state, ok := c.states[userId]
+	c.mu.Unlock()
+	if ok && now.Sub(state.checkedAt) < suspensionCacheTTL {
+		return state, nil
+	}
+
+	user, err := c.store.GetUserById(ctx, userId)
+	if err != nil {
+		return userState{}, err
+	}
+	state = userState{
+		checkedAt: now,
+		exists:    user != nil,
+		suspended: user != nil && user.SuspendedAt != nil,
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for id, cached := range c.states {
+		if now.Sub(cached.checkedAt) >= suspensionCacheTTL {
+			delete(c.states, id)
+		}
+	}
+	c.states[userId] = state
+	return state, nil
+}
+
+// suspensionMiddleware rejects writes of users that were suspended or
+// deleted after their access token was issued. Reads are left to the short
+// lifetime of access tokens, as refreshing them is refused to suspended
+// users, so that they do not cost a lookup.
+func suspensionMiddleware(cache *suspensionCache) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			userId, ok := c.Get("userId").(string)
+			if !ok {
+				return next(c)
+			}
+			switch c.Request().Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				return next(c)
+			}
+
+			state, err := cache.get(c.Request().Context(), userId)
+			if err != nil {
+				return echo.NewHTTPError(
+					http.StatusInternalServerError,
+					"Failed to retrieve user",
+				)
+			}
+			if !state.exists {
+				return echo.NewHTTPError(
+					http.StatusUnauthorized,
+					"User not found",
+				)
+			}
+			if state.suspended {
+				return echo.NewHTTPError(
+					http.StatusForbidden,
+					"Account suspended",
+				)
+			}
+
+			return next(c)
+		}
+	}
+}
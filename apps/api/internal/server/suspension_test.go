@@ -0,0 +1,113 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"apps/api/internal/models"
+)
+
+type fakeUserStore struct {
+	err     error
+	lookups int
+	users   map[string]*time.Time
+}
+
+func (s *fakeUserStore) GetUserById(
+	_ context.Context,
+	id string,
+) (*models.User, error) {
+	s.lookups++
+	if s.err != nil {
+		return nil, s.err
+	}
+	suspendedAt, ok := s.users[id]
+	if !ok {
+		return nil, nil
+	}
+	return &models.User{ID: id, SuspendedAt: suspendedAt}, nil
+}
+
+func TestSuspensionMiddleware(t *testing.T) {
+	suspendedAt := time.Now()
+	store := &fakeUserStore{users: map[string]*time.Time{
+		"active":    nil,
+		"suspended": &suspendedAt,
+	}}
+	cache := newSuspensionCache(store)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	e := echo.New()
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if userId := c.Request().Header.Get("X-User-Id"); userId != "" {
+				c.Set("userId", userId)
+			}
+			return next(c)
+		}
+	})
+	e.Use(suspensionMiddleware(cache))
+	handler := func(c echo.Context) error {
+		return c.String(http.StatusOK, "ok")
+	}
+	e.GET("/posts", handler)
+	e.POST("/posts", handler)
+
+	request := func(method string, userId string) int {
+		req := httptest.NewRequest(method, "/posts", nil)
+		if userId != "" {
+			req.Header.Set("X-User-Id", userId)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	t.Run("should reject writes of suspended users", func(t *testing.T) {
+		for userId, expected := range map[string]int{
+			"":          http.StatusOK,
+			"active":    http.StatusOK,
+			"deleted":   http.StatusUnauthorized,
+			"suspended": http.StatusForbidden,
+		} {
+			assert.Equal(t, expected, request(http.MethodPost, userId), userId)
+		}
+	})
+
+	t.Run("should not look up users on reads", func(t *testing.T) {
+		store.lookups = 0
+		assert.Equal(t, http.StatusOK, request(http.MethodGet, "suspended"))
+		assert.Equal(t, 0, store.lookups)
+	})
+
+	t.Run("should reuse the state until it expires", func(t *testing.T) {
+		store.lookups = 0
+		store.users["active"] = &suspendedAt
+		assert.Equal(t, http.StatusOK, request(http.MethodPost, "active"))
+		assert.Equal(t, 0, store.lookups)
+
+		now = now.Add(suspensionCacheTTL)
+		code := request(http.MethodPost, "active")
+		assert.Equal(t, http.StatusForbidden, code)
+		assert.Equal(t, 1, store.lookups)
+	})
+
+	t.Run("should fail writes when the lookup fails", func(t *testing.T) {
+		now = now.Add(suspensionCacheTTL)
+		store.err = errors.New("unavailable")
+		assert.Equal(
+			t,
+			http.StatusInternalServerError,
+			request(http.MethodPost, "active"),
+		)
+		assert.Equal(t, http.StatusOK, request(http.MethodGet, "active"))
+	})
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"apps/api/internal/config"
+)
+
+var ErrBlobNotFound = errors.New("Blob not found")
+
+// BlobStore keeps the contents of uploads by key. Keys are slash separated
+// paths made of letters, digits, dashes, dots and underscores.
+type BlobStore interface {
+	// Put stores size bytes read from body under key, replacing any blob
+	// stored under key before.
+	Put(
+		ctx context.Context,
+		key string,
+		body io.Reader,
+		size int64,
+		contentType string,
+	) error
+	// Get opens the blob stored under key. ErrBlobNotFound is returned when
+	// there is none.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// GetFrom opens the blob stored under key like Get, skipping its first
+	// offset bytes.
+	GetFrom(
+		ctx context.Context,
+		key string,
+		offset int64,
+	) (io.ReadCloser, error)
+	// Delete removes the blob stored under key. Deleting a missing blob is
+	// not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// NewBlobStore returns the blob store selected by the media config.
+func NewBlobStore(config *config.MediaConfig) (BlobStore, error) {
+	switch config.Storage {
+	case "local":
+		return NewLocalBlobStore(config.LocalPath)
+	case "s3":
+		return NewS3BlobStore(config)
+	default:
+		return nil, fmt.Errorf("Unknown media storage %q", config.Storage)
+	}
+}
+
+// blobReader reads a blob of known size from the blob store. After seeking,
+// the blob is opened again from the new offset, so that ranges of a blob can
+// be served without downloading it whole.
+type blobReader struct {
+	body   io.ReadCloser
+	ctx    context.Context
+	key    string
+	offset int64
+	size   int64
+	store  BlobStore
+}
+
+func newBlobReader(
+	ctx context.Context,
+	store BlobStore,
+	key string,
+	size int64,
+) *blobReader {
+	return &blobReader{ctx: ctx, key: key, size: size, store: store}
+}
+
+func (r *blobReader) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+	if r.body == nil {
+		body, err := r.store.GetFrom(r.ctx, r.key, r.offset)
+		if err != nil {
+			return 0, err
+		}
+		r.body = body
+	}
+
+	n, err := r.body.Read(p)
+	r.offset += int64(n)
+	return n, err
+}
+
+func (r *blobReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.size
+	}
+	if offset < 0 {
+		return 0, errors.New("Negative blob offset")
+	}
+
+	if offset != r.offset && r.body != nil {
+		r.body.Close()
+		r.body = nil
+	}
+	r.offset = offset
+	return offset, nil
+}
+
+func (r *blobReader) Close() error {
+	if r.body == nil {
+		return nil
+	}
+	err := r.body.Close()
+	r.body = nil
+	return err
+}
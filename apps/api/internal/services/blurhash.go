@@ -0,0 +1,118 @@
+package services
+
+import (
+	"image"
+	"math"
+	"strings"
+)
+
+const blurhashCharacters = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ" +
+	"abcdefghijklmnopqrstuvwxyz#$%*+,-.:;=?@[]^_{|}~"
+
+// encodeBlurhash encodes the image as a BlurHash placeholder made of
+// xComponents by yComponents cosine components, each between 1 and 9. See
+// https://github.com/woltapp/blurhash for the format. Small images should be
+// passed, as every pixel is visited for every component.
+func encodeBlurhash(img image.Image, xComponents, yComponents int) string {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	factors := make([][3]float64, 0, xComponents*yComponents)
+	for j := 0; j < yComponents; j++ {
+		for i := 0; i < xComponents; i++ {
+			normalisation := 2.0
+			if i == 0 && j == 0 {
+				normalisation = 1
+			}
+
+			var factor [3]float64
+			for y := 0; y < height; y++ {
+				for x := 0; x < width; x++ {
+					basis := normalisation *
+						math.Cos(math.Pi*float64(i*x)/float64(width)) *
+						math.Cos(math.Pi*float64(j*y)/float64(height))
+					r, g, b, _ := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+					factor[0] += basis * srgbToLinear(r>>8)
+					factor[1] += basis * srgbToLinear(g>>8)
+					factor[2] += basis * srgbToLinear(b>>8)
+				}
+			}
+			scale := 1 / float64(width*height)
+			for c := range factor {
+				factor[c] *= scale
+			}
+			factors = append(factors, factor)
+		}
+	}
+
+	var hash strings.Builder
+	writeBase83(&hash, (xComponents-1)+(yComponents-1)*9, 1)
+
+	maximumValue := 1.0
+	if len(factors) > 1 {
+		actualMaximumValue := 0.0
+		for _, factor := range factors[1:] {
+			for _, value := range factor {
+				actualMaximumValue = math.Max(
+					actualMaximumValue,
+					math.Abs(value),
+				)
+			}
+		}
+		quantisedMaximumValue := int(math.Max(0, math.Min(
+			82,
+			math.Floor(actualMaximumValue*166-0.5),
+		)))
+		maximumValue = float64(quantisedMaximumValue+1) / 166
+		writeBase83(&hash, quantisedMaximumValue, 1)
+	} else {
+		writeBase83(&hash, 0, 1)
+	}
+
+	dc := factors[0]
+	writeBase83(
+		&hash,
+		linearToSrgb(dc[0])<<16+linearToSrgb(dc[1])<<8+linearToSrgb(dc[2]),
+		4,
+	)
+	for _, factor := range factors[1:] {
+		value := 0
+		for _, component := range factor {
+			quantised := int(math.Max(0, math.Min(
+				18,
+				math.Floor(signPow(component/maximumValue, 0.5)*9+9.5),
+			)))
+			value = value*19 + quantised
+		}
+		writeBase83(&hash, value, 2)
+	}
+
+	return hash.String()
+}
+
+func writeBase83(hash *strings.Builder, value int, length int) {
+	for i := 1; i <= length; i++ {
+		digit := (value / int(math.Pow(83, float64(length-i)))) % 83
+		hash.WriteByte(blurhashCharacters[digit])
+	}
+}
+
+func srgbToLinear(value uint32) float64 {
+	v := float64(value) / 255
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+func linearToSrgb(value float64) int {
+	v := math.Max(0, math.Min(1, value))
+	if v <= 0.0031308 {
+		return int(v*12.92*255 + 0.5)
+	}
+	return int((1.055*math.Pow(v, 1/2.4)-0.055)*255 + 0.5)
+}
+
+func signPow(value float64, exp float64) float64 {
+	return math.Copysign(math.Pow(math.Abs(value), exp), value)
+}
@@ -0,0 +1,338 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ContentFilterAction is what happens to a post matched by a rule.
+type ContentFilterAction string
+
+const (
+	// ContentFilterReject refuses to save the post.
+	ContentFilterReject ContentFilterAction = "reject"
+	// ContentFilterFlag saves the post and queues it for moderator review.
+	ContentFilterFlag ContentFilterAction = "flag"
+)
+
+// contentFilterFields are the post fields rules can check.
+var contentFilterFields = []string{"title", "content"}
+
+// DuplicatePostFinder looks up earlier posts of an author with the same value
+// in field.
+type DuplicatePostFinder interface {
+	HasDuplicatePost(
+		ctx context.Context,
+		authorId string,
+		excludePostId string,
+		field string,
+		value string,
+		since time.Time,
+	) (bool, error)
+}
+
+// ContentFilterRuleConfig describes a rule of the content filter. Type is one
+// of words, regex, links or duplicate, and selects which of the remaining
+// options are used.
+type ContentFilterRuleConfig struct {
+	Action  ContentFilterAction `json:"action"`
+	Fields  []string            `json:"fields"`
+	Message string              `json:"message"`
+	Type    string              `json:"type"`
+
+	// Words rejects or flags text containing any of the words, ignoring case.
+	Words []string `json:"words"`
+	// Pattern is a regular expression matched against the text.
+	Pattern string `json:"pattern"`
+	// MaxLinks is the number of links text may contain.
+	MaxLinks int `json:"maxLinks"`
+	// Window is how far back duplicates are looked up, e.g. "24h".
+	Window string `json:"window"`
+}
+
+// ContentFilterInput is a post about to be saved. Nil fields are left
+// unchanged by an update and are not checked.
+type ContentFilterInput struct {
+	AuthorId string
+	Content  *string
+	// PostId is empty for new posts.
+	PostId string
+	Title  *string
+}
+
+// ContentFilterResult tells why a post was rejected or flagged.
+type ContentFilterResult struct {
+	// FieldErrors holds a message per rejected field.
+	FieldErrors map[string]string
+	// Flags holds the messages of the rules that flagged the post.
+	Flags []string
+}
+
+func (r *ContentFilterResult) Rejected() bool {
+	return len(r.FieldErrors) > 0
+}
+
+func (r *ContentFilterResult) Flagged() bool {
+	return len(r.Flags) > 0
+}
+
+// contentMatcher decides whether the value of a post field breaks a rule.
+type contentMatcher interface {
+	matches(
+		ctx context.Context,
+		input ContentFilterInput,
+		field string,
+		value string,
+	) (bool, error)
+}
+
+type contentRule struct {
+	action  ContentFilterAction
+	fields  []string
+	matcher contentMatcher
+	message string
+}
+
+// ContentFilter runs the title and content of posts through its rules before
+// they are saved. A filter without rules accepts every post.
+type ContentFilter struct {
+	rules []contentRule
+}
+
+// LoadContentFilter reads the rules from the JSON file at path, an object
+// with a "rules" array of ContentFilterRuleConfig. An empty path gives a
+// filter without rules.
+func LoadContentFilter(
+	path string,
+	duplicates DuplicatePostFinder,
+) (*ContentFilter, error) {
+	if path == "" {
+		return NewContentFilter(nil, duplicates)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read content filter rules: %w", err)
+	}
+
+	var file struct {
+		Rules []ContentFilterRuleConfig `json:"rules"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("Failed to parse content filter rules: %w", err)
+	}
+
+	return NewContentFilter(file.Rules, duplicates)
+}
+
+func NewContentFilter(
+	configs []ContentFilterRuleConfig,
+	duplicates DuplicatePostFinder,
+) (*ContentFilter, error) {
+	rules := make([]contentRule, 0, len(configs))
+	for i, config := range configs {
+		rule, err := newContentRule(config, duplicates)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid content filter rule %d: %w", i, err)
+		}
+		rules = append(rules, rule)
+	}
+
+	return &ContentFilter{rules: rules}, nil
+}
+
+// Check runs every rule on the fields of the post. Only the first rejecting
+// rule of a field is reported.
+func (f *ContentFilter) Check(
+	ctx context.Context,
+	input ContentFilterInput,
+) (*ContentFilterResult, error) {
+	result := &ContentFilterResult{FieldErrors: map[string]string{}}
+	values := map[string]*string{
+		"content": input.Content,
+		"title":   input.Title,
+	}
+
+	for _, rule := range f.rules {
+		for _, field := range rule.fields {
+			value := values[field]
+			if value == nil || *value == "" {
+				continue
+			}
+			if _, ok := result.FieldErrors[field]; ok {
+				continue
+			}
+
+			matched, err := rule.matcher.matches(ctx, input, field, *value)
+			if err != nil {
+				return nil, err
+			}
+			if !matched {
+				continue
+			}
+
+			switch rule.action {
+			case ContentFilterReject:
+				result.FieldErrors[field] = rule.message
+			case ContentFilterFlag:
+				if !slices.Contains(result.Flags, rule.message) {
+					result.Flags = append(result.Flags, rule.message)
+				}
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func newContentRule(
+	config ContentFilterRuleConfig,
+	duplicates DuplicatePostFinder,
+) (contentRule, error) {
+	rule := contentRule{
+		action:  config.Action,
+		fields:  config.Fields,
+		message: config.Message,
+	}
+
+	switch rule.action {
+	case ContentFilterReject, ContentFilterFlag:
+	default:
+		return rule, fmt.Errorf("unknown action %q", config.Action)
+	}
+
+	if len(rule.fields) == 0 {
+		rule.fields = contentFilterFields
+	}
+	for _, field := range rule.fields {
+		if !slices.Contains(contentFilterFields, field) {
+			return rule, fmt.Errorf("unknown field %q", field)
+		}
+	}
+
+	var defaultMessage string
+	switch config.Type {
+	case "words":
+		if len(config.Words) == 0 {
+			return rule, fmt.Errorf("words are required")
+		}
+		words := make(map[string]bool, len(config.Words))
+		for _, word := range config.Words {
+			words[strings.ToLower(word)] = true
+		}
+		rule.matcher = wordListMatcher{words: words}
+		defaultMessage = "Contains words that are not allowed"
+	case "regex":
+		if config.Pattern == "" {
+			return rule, fmt.Errorf("pattern is required")
+		}
+		pattern, err := regexp.Compile(config.Pattern)
+		if err != nil {
+			return rule, fmt.Errorf("invalid pattern: %w", err)
+		}
+		rule.matcher = regexMatcher{pattern: pattern}
+		defaultMessage = "Contains text that is not allowed"
+	case "links":
+		if config.MaxLinks < 0 {
+			return rule, fmt.Errorf("maxLinks should not be negative")
+		}
+		rule.matcher = linkCountMatcher{max: config.MaxLinks}
+		defaultMessage = fmt.Sprintf(
+			"Should contain at most %d links",
+			config.MaxLinks,
+		)
+	case "duplicate":
+		window, err := time.ParseDuration(config.Window)
+		if err != nil {
+			return rule, fmt.Errorf("invalid window: %w", err)
+		}
+		if duplicates == nil {
+			return rule, fmt.Errorf("duplicate detection is not available")
+		}
+		rule.matcher = duplicateMatcher{finder: duplicates, window: window}
+		defaultMessage = "Duplicates one of your recent posts"
+	default:
+		return rule, fmt.Errorf("unknown type %q", config.Type)
+	}
+
+	if rule.message == "" {
+		rule.message = defaultMessage
+	}
+
+	return rule, nil
+}
+
+var wordPattern = regexp.MustCompile(`[\p{L}\p{N}_']+`)
+
+type wordListMatcher struct {
+	words map[string]bool
+}
+
+func (m wordListMatcher) matches(
+	_ context.Context,
+	_ ContentFilterInput,
+	_ string,
+	value string,
+) (bool, error) {
+	for _, word := range wordPattern.FindAllString(value, -1) {
+		if m.words[strings.ToLower(word)] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type regexMatcher struct {
+	pattern *regexp.Regexp
+}
+
+func (m regexMatcher) matches(
+	_ context.Context,
+	_ ContentFilterInput,
+	_ string,
+	value string,
+) (bool, error) {
+	return m.pattern.MatchString(value), nil
+}
+
+var linkPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+`)
+
+type linkCountMatcher struct {
+	max int
+}
+
+func (m linkCountMatcher) matches(
+	_ context.Context,
+	_ ContentFilterInput,
+	_ string,
+	value string,
+) (bool, error) {
+	return len(linkPattern.FindAllStringIndex(value, m.max+1)) > m.max, nil
+}
+
+type duplicateMatcher struct {
+	finder DuplicatePostFinder
+	window time.Duration
+}
+
+func (m duplicateMatcher) matches(
+	ctx context.Context,
+	input ContentFilterInput,
+	field string,
+	value string,
+) (bool, error) {
+	return m.finder.HasDuplicatePost(
+		ctx,
+		input.AuthorId,
+		input.PostId,
+		field,
+		value,
+		time.Now().Add(-m.window),
+	)
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDuplicatePostFinder struct {
+	values map[string]bool
+}
+
+func (f fakeDuplicatePostFinder) HasDuplicatePost(
+	_ context.Context,
+	_ string,
+	_ string,
+	_ string,
+	value string,
+	_ time.Time,
+) (bool, error) {
+	return f.values[value], nil
+}
+
+func TestContentFilter_Check(t *testing.T) {
+	filter, err := NewContentFilter(
+		[]ContentFilterRuleConfig{
+			{Type: "words", Action: ContentFilterReject, Words: []string{"Bad"}},
+			{
+				Type:    "regex",
+				Action:  ContentFilterFlag,
+				Fields:  []string{"content"},
+				Pattern: `(?i)free money`,
+				Message: "Looks like spam",
+			},
+			{
+				Type:     "links",
+				Action:   ContentFilterReject,
+				Fields:   []string{"content"},
+				MaxLinks: 1,
+			},
+			{
+				Type:   "duplicate",
+				Action: ContentFilterReject,
+				Fields: []string{"content"},
+				Window: "24h",
+			},
+		},
+		fakeDuplicatePostFinder{values: map[string]bool{"again": true}},
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		title       string
+		content     string
+		fieldErrors map[string]string
+		flags       []string
+	}{
+		{
+			name:        "accepts clean posts",
+			title:       "Hello",
+			content:     "badger https://example.com",
+			fieldErrors: map[string]string{},
+		},
+		{
+			name:    "rejects listed words in any field",
+			title:   "So BAD",
+			content: "bad!",
+			fieldErrors: map[string]string{
+				"content": "Contains words that are not allowed",
+				"title":   "Contains words that are not allowed",
+			},
+		},
+		{
+			name:    "rejects too many links",
+			title:   "Links",
+			content: "https://a.example www.b.example",
+			fieldErrors: map[string]string{
+				"content": "Should contain at most 1 links",
+			},
+		},
+		{
+			name:    "rejects duplicates",
+			title:   "Again",
+			content: "again",
+			fieldErrors: map[string]string{
+				"content": "Duplicates one of your recent posts",
+			},
+		},
+		{
+			name:        "flags matching posts",
+			title:       "Offer",
+			content:     "Free money inside",
+			fieldErrors: map[string]string{},
+			flags:       []string{"Looks like spam"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := filter.Check(
+				context.Background(),
+				ContentFilterInput{Content: &tt.content, Title: &tt.title},
+			)
+			require.NoError(t, err)
+			assert.Equal(t, tt.fieldErrors, result.FieldErrors)
+			assert.Equal(t, tt.flags, result.Flags)
+		})
+	}
+}
+
+func TestNewContentFilter(t *testing.T) {
+	filter, err := NewContentFilter(nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, filter.rules)
+
+	for _, config := range []ContentFilterRuleConfig{
+		{Type: "words", Action: "delete", Words: []string{"a"}},
+		{Type: "words", Action: ContentFilterReject},
+		{Type: "regex", Action: ContentFilterFlag},
+		{Type: "links", Action: ContentFilterReject, MaxLinks: -1},
+		{
+			Type:   "words",
+			Action: ContentFilterReject,
+			Fields: []string{"x"},
+			Words:  []string{"a"},
+		},
+		{Type: "regex", Action: ContentFilterFlag, Pattern: "("},
+		{Type: "duplicate", Action: ContentFilterReject, Window: "24h"},
+		{Type: "unknown", Action: ContentFilterReject},
+	} {
+		_, err := NewContentFilter([]ContentFilterRuleConfig{config}, nil)
+		assert.Error(t, err, config.Type)
+	}
+}
+
+func TestLoadContentFilter(t *testing.T) {
+	filter, err := LoadContentFilter(
+		"../../content_filter.example.json",
+		fakeDuplicatePostFinder{},
+	)
+	require.NoError(t, err)
+	assert.Len(t, filter.rules, 4)
+}
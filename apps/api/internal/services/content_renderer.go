@@ -0,0 +1,89 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	goldmarkhtml "github.com/yuin/goldmark/renderer/html"
+
+	"apps/api/internal/models"
+)
+
+// ContentRenderer renders the content of posts to HTML that is safe to embed
+// in pages. Markdown is rendered without raw HTML and then sanitized with an
+// allowlist of elements and attributes, and links are marked rel="nofollow".
+type ContentRenderer struct {
+	markdown goldmark.Markdown
+	policy   *bluemonday.Policy
+}
+
+func NewContentRenderer() *ContentRenderer {
+	policy := bluemonday.NewPolicy()
+	policy.AllowElements(
+		"blockquote",
+		"br",
+		"code",
+		"del",
+		"em",
+		"h1",
+		"h2",
+		"h3",
+		"h4",
+		"h5",
+		"h6",
+		"hr",
+		"li",
+		"p",
+		"pre",
+		"strong",
+		"ul",
+	)
+	policy.AllowAttrs("start").
+		Matching(bluemonday.Integer).
+		OnElements("ol")
+	policy.AllowElements("ol")
+	policy.AllowAttrs("class").
+		Matching(regexp.MustCompile(`^language-[\w+-]+$`)).
+		OnElements("code")
+	policy.AllowAttrs("href").OnElements("a")
+	policy.AllowURLSchemes("http", "https", "mailto")
+	policy.RequireParseableURLs(true)
+	policy.RequireNoFollowOnLinks(true)
+
+	return &ContentRenderer{
+		markdown: goldmark.New(
+			goldmark.WithExtensions(
+				extension.Linkify,
+				extension.Strikethrough,
+			),
+			goldmark.WithRendererOptions(goldmarkhtml.WithHardWraps()),
+		),
+		policy: policy,
+	}
+}
+
+// Render returns the content as sanitized HTML. Plain content is escaped and
+// keeps its line breaks.
+func (r *ContentRenderer) Render(
+	content string,
+	format models.ContentFormat,
+) (string, error) {
+	if format != models.ContentFormatMarkdown {
+		escaped := html.EscapeString(content)
+		return "<p>" + strings.ReplaceAll(escaped, "\n", "<br>\n") + "</p>",
+			nil
+	}
+
+	var rendered bytes.Buffer
+	if err := r.markdown.Convert([]byte(content), &rendered); err != nil {
+		return "", fmt.Errorf("Failed to render markdown: %w", err)
+	}
+
+	return strings.TrimSpace(r.policy.Sanitize(rendered.String())), nil
+}
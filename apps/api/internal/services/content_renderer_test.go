@@ -0,0 +1,72 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"apps/api/internal/models"
+)
+
+func TestContentRenderer(t *testing.T) {
+	renderer := NewContentRenderer()
+
+	tests := []struct {
+		name     string
+		content  string
+		format   models.ContentFormat
+		expected string
+	}{
+		{
+			name:     "should escape plain content",
+			content:  "<b>bold</b> & *not emphasis*\nnext line",
+			format:   models.ContentFormatPlain,
+			expected: "<p>&lt;b&gt;bold&lt;/b&gt; &amp; *not emphasis*<br>\nnext line</p>",
+		},
+		{
+			name:     "should render markdown",
+			content:  "# Title\n\n**bold** and ~~gone~~\n\n- one\n- two",
+			format:   models.ContentFormatMarkdown,
+			expected: "<h1>Title</h1>\n<p><strong>bold</strong> and <del>gone</del></p>\n<ul>\n<li>one</li>\n<li>two</li>\n</ul>",
+		},
+		{
+			name:     "should add rel nofollow to links",
+			content:  "[site](https://example.com) and https://example.org",
+			format:   models.ContentFormatMarkdown,
+			expected: `<p><a href="https://example.com" rel="nofollow">site</a> and <a href="https://example.org" rel="nofollow">https://example.org</a></p>`,
+		},
+		{
+			name:     "should drop raw HTML tags",
+			content:  "hello <script>alert(1)</script><img src=x onerror=alert(1)>",
+			format:   models.ContentFormatMarkdown,
+			expected: "<p>hello alert(1)</p>",
+		},
+		{
+			name:     "should drop unsafe links",
+			content:  "[click](javascript:alert(1))",
+			format:   models.ContentFormatMarkdown,
+			expected: "<p>click</p>",
+		},
+		{
+			name:     "should drop images",
+			content:  "![tracker](https://example.com/pixel.png)",
+			format:   models.ContentFormatMarkdown,
+			expected: "<p></p>",
+		},
+		{
+			name:     "should keep the language of code blocks",
+			content:  "```go\nfmt.Println(\"<hi>\")\n```",
+			format:   models.ContentFormatMarkdown,
+			expected: "<pre><code class=\"language-go\">fmt.Println(&#34;&lt;hi&gt;&#34;)\n</code></pre>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html, err := renderer.Render(tt.content, tt.format)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, html)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+
+	"apps/api/internal/models"
+)
+
+// FeedSource produces pages of the home timeline of a user, newest first.
+type FeedSource interface {
+	GetFeed(
+		ctx context.Context,
+		userId string,
+		cursor *models.PostCursor,
+		limit int,
+	) ([]*models.Post, error)
+}
+
+// FeedService serves home timelines. All timelines are currently assembled
+// on read from the posts of the followed users. Users following many
+// accounts can later be served from a materialized per-user timeline by
+// returning another FeedSource from sourceFor, as long as it pages with the
+// same cursor.
+type FeedService struct {
+	fanOutOnRead FeedSource
+}
+
+func NewFeedService(fanOutOnRead FeedSource) *FeedService {
+	return &FeedService{fanOutOnRead: fanOutOnRead}
+}
+
+func (s *FeedService) GetFeed(
+	ctx context.Context,
+	userId string,
+	cursor *models.PostCursor,
+	limit int,
+) ([]*models.Post, error) {
+	return s.sourceFor(userId).GetFeed(ctx, userId, cursor, limit)
+}
+
+func (s *FeedService) sourceFor(userId string) FeedSource {
+	return s.fanOutOnRead
+}
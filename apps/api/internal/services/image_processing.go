@@ -0,0 +1,336 @@
+package services
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/webp"
+)
+
+// maxImagePixels bounds the images that are decoded, so that a small file
+// cannot claim gigabytes of memory once decoded.
+const maxImagePixels = 50_000_000
+
+// blurhashSize is the size the image is scaled down to before its
+// BlurHash is computed, which is plenty for a blurred placeholder.
+const blurhashSize = 32
+
+var errImageTooLarge = errors.New("Image is too large")
+
+// imageVariant describes a resized copy of uploaded images. Crop fills the
+// exact size, otherwise the image is scaled down to the width. Images are
+// never scaled up, so variants wider than the image are skipped, except for
+// cropped ones.
+type imageVariant struct {
+	crop   bool
+	height int
+	name   string
+	width  int
+}
+
+var imageVariants = []imageVariant{
+	{name: "thumbnail", width: 200, height: 200, crop: true},
+	{name: "small", width: 320},
+	{name: "medium", width: 640},
+	{name: "large", width: 1280},
+}
+
+// processedImage is an image without metadata along with its variants.
+type processedImage struct {
+	blurhash string
+	data     []byte
+	height   int
+	variants []processedImageVariant
+	width    int
+}
+
+type processedImageVariant struct {
+	contentType string
+	data        []byte
+	height      int
+	name        string
+	width       int
+}
+
+// isProcessableImage reports whether processImage can decode the content
+// type.
+func isProcessableImage(contentType string) bool {
+	switch contentType {
+	case "image/gif", "image/jpeg", "image/png", "image/webp":
+		return true
+	}
+	return false
+}
+
+// processImage strips the metadata, such as EXIF and GPS data, from the
+// image and generates its variants and BlurHash. JPEG images are rotated as
+// their EXIF orientation says, since the orientation is stripped with the
+// rest of the metadata. Animated GIFs keep their animation, their variants
+// show the first frame.
+func processImage(data []byte, contentType string) (*processedImage, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("Failed to decode image: %w", err)
+	}
+	if config.Width*config.Height > maxImagePixels {
+		return nil, errImageTooLarge
+	}
+
+	var img image.Image
+	var stripped bytes.Buffer
+	switch contentType {
+	case "image/jpeg":
+		img, err = jpeg.Decode(bytes.NewReader(data))
+		if err == nil {
+			img = orientImage(img, jpegOrientation(data))
+			err = jpeg.Encode(&stripped, img, &jpeg.Options{Quality: 90})
+		}
+	case "image/png":
+		img, err = png.Decode(bytes.NewReader(data))
+		if err == nil {
+			err = png.Encode(&stripped, img)
+		}
+	case "image/gif":
+		var animation *gif.GIF
+		animation, err = gif.DecodeAll(bytes.NewReader(data))
+		if err == nil {
+			img = animation.Image[0]
+			err = gif.EncodeAll(&stripped, animation)
+		}
+	case "image/webp":
+		img, err = webp.Decode(bytes.NewReader(data))
+		if err == nil {
+			var webpData []byte
+			webpData, err = stripWebpMetadata(data)
+			stripped.Write(webpData)
+		}
+	default:
+		err = fmt.Errorf("unsupported content type %s", contentType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to process image: %w", err)
+	}
+
+	bounds := img.Bounds()
+	processed := &processedImage{
+		data:   stripped.Bytes(),
+		height: bounds.Dy(),
+		width:  bounds.Dx(),
+	}
+
+	for _, variant := range imageVariants {
+		if !variant.crop && variant.width >= processed.width {
+			continue
+		}
+		resized := resizeImage(img, variant)
+		encoded, variantType, err := encodeImageVariant(resized)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to encode %s: %w", variant.name, err)
+		}
+		processed.variants = append(processed.variants, processedImageVariant{
+			contentType: variantType,
+			data:        encoded,
+			height:      resized.Bounds().Dy(),
+			name:        variant.name,
+			width:       resized.Bounds().Dx(),
+		})
+	}
+
+	small := resizeImage(img, imageVariant{width: blurhashSize})
+	processed.blurhash = encodeBlurhash(small, 4, 3)
+
+	return processed, nil
+}
+
+// resizeImage scales the image to the variant. Cropped variants are cut
+// from the center of the image.
+func resizeImage(img image.Image, variant imageVariant) *image.RGBA {
+	bounds := img.Bounds()
+	source := bounds
+	width := min(variant.width, bounds.Dx())
+	height := max(1, bounds.Dy()*width/bounds.Dx())
+
+	if variant.crop {
+		width, height = variant.width, variant.height
+		// Cut the largest part of the image with the aspect ratio of the
+		// variant.
+		cropWidth := min(bounds.Dx(), bounds.Dy()*width/height)
+		cropHeight := min(bounds.Dy(), bounds.Dx()*height/width)
+		offset := image.Pt(
+			(bounds.Dx()-cropWidth)/2,
+			(bounds.Dy()-cropHeight)/2,
+		)
+		source = image.Rectangle{
+			Min: bounds.Min.Add(offset),
+			Max: bounds.Min.Add(offset).Add(image.Pt(cropWidth, cropHeight)),
+		}
+	}
+
+	resized := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(
+		resized,
+		resized.Bounds(),
+		img,
+		source,
+		draw.Src,
+		nil,
+	)
+	return resized
+}
+
+// encodeImageVariant encodes opaque images as JPEG and others as PNG, which
+// keeps their transparency.
+func encodeImageVariant(img *image.RGBA) ([]byte, string, error) {
+	var encoded bytes.Buffer
+	if img.Opaque() {
+		err := jpeg.Encode(&encoded, img, &jpeg.Options{Quality: 85})
+		return encoded.Bytes(), "image/jpeg", err
+	}
+	err := png.Encode(&encoded, img)
+	return encoded.Bytes(), "image/png", err
+}
+
+// jpegOrientation reads the orientation tag of the EXIF data of the JPEG
+// image, from 1 to 8. 1, the upright orientation, is returned when there is
+// none.
+func jpegOrientation(data []byte) int {
+	// Segments follow the start of image marker, each starting with a marker
+	// and its length, until the image data starts.
+	offset := 2
+	for offset+4 <= len(data) && data[offset] == 0xFF {
+		marker := data[offset+1]
+		length := int(binary.BigEndian.Uint16(data[offset+2:]))
+		if marker == 0xDA || length < 2 || offset+2+length > len(data) {
+			break
+		}
+		segment := data[offset+4 : offset+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return exifOrientation(segment[6:])
+		}
+		offset += 2 + length
+	}
+	return 1
+}
+
+// exifOrientation reads the orientation tag from the first IFD of the TIFF
+// structure of EXIF data.
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd+2 > len(tiff) {
+		return 1
+	}
+	entries := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < entries; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			break
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			orientation := int(order.Uint16(tiff[entry+8:]))
+			if orientation < 1 || orientation > 8 {
+				return 1
+			}
+			return orientation
+		}
+	}
+	return 1
+}
+
+// orientImage turns the image upright for its EXIF orientation.
+func orientImage(img image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	// Orientations from 5 to 8 swap the width and the height.
+	rotated := orientation >= 5
+	size := image.Rect(0, 0, w, h)
+	if rotated {
+		size = image.Rect(0, 0, h, w)
+	}
+
+	oriented := image.NewRGBA(size)
+	for y := 0; y < size.Dy(); y++ {
+		for x := 0; x < size.Dx(); x++ {
+			var sx, sy int
+			switch orientation {
+			case 2:
+				sx, sy = w-1-x, y
+			case 3:
+				sx, sy = w-1-x, h-1-y
+			case 4:
+				sx, sy = x, h-1-y
+			case 5:
+				sx, sy = y, x
+			case 6:
+				sx, sy = y, h-1-x
+			case 7:
+				sx, sy = w-1-y, h-1-x
+			case 8:
+				sx, sy = w-1-y, x
+			}
+			oriented.Set(x, y, img.At(bounds.Min.X+sx, bounds.Min.Y+sy))
+		}
+	}
+	return oriented
+}
+
+// stripWebpMetadata removes the EXIF and XMP chunks from the WebP image, as
+// there is no WebP encoder to write a clean copy with.
+func stripWebpMetadata(data []byte) ([]byte, error) {
+	if len(data) < 12 ||
+		string(data[:4]) != "RIFF" ||
+		string(data[8:12]) != "WEBP" {
+		return nil, fmt.Errorf("invalid WebP container")
+	}
+
+	stripped := append([]byte{}, data[:12]...)
+	offset := 12
+	for offset+8 <= len(data) {
+		fourCC := string(data[offset : offset+4])
+		size := int(binary.LittleEndian.Uint32(data[offset+4:]))
+		end := offset + 8 + size + size%2
+		if end > len(data) {
+			return nil, fmt.Errorf("truncated WebP chunk %q", fourCC)
+		}
+
+		switch fourCC {
+		case "EXIF", "XMP ":
+		case "VP8X":
+			chunk := append([]byte{}, data[offset:end]...)
+			if size > 0 {
+				// Clear the flags announcing EXIF and XMP chunks.
+				chunk[8] &^= 0x08 | 0x04
+			}
+			stripped = append(stripped, chunk...)
+		default:
+			stripped = append(stripped, data[offset:end]...)
+		}
+		offset = end
+	}
+	binary.LittleEndian.PutUint32(stripped[4:], uint32(len(stripped)-8))
+
+	return stripped, nil
+}
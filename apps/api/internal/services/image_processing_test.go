@@ -0,0 +1,164 @@
+package services
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestImage(width, height int, fill color.Color) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, fill)
+		}
+	}
+	return img
+}
+
+// withExifOrientation inserts an EXIF segment with the orientation tag after
+// the start of image marker of the JPEG image.
+func withExifOrientation(data []byte, orientation uint16) []byte {
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	tiff = binary.BigEndian.AppendUint16(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, 0x0112)
+	tiff = binary.BigEndian.AppendUint16(tiff, 3)
+	tiff = binary.BigEndian.AppendUint32(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0)
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+
+	segment := []byte{0xFF, 0xE1}
+	segment = binary.BigEndian.AppendUint16(segment, uint16(len(payload)+2))
+	segment = append(segment, payload...)
+
+	return append(append(append([]byte{}, data[:2]...), segment...), data[2:]...)
+}
+
+func TestProcessImage(t *testing.T) {
+	t.Run("should rotate JPEG images and strip EXIF", func(t *testing.T) {
+		var encoded bytes.Buffer
+		img := newTestImage(40, 20, color.RGBA{200, 10, 10, 255})
+		require.NoError(t, jpeg.Encode(&encoded, img, nil))
+		data := withExifOrientation(encoded.Bytes(), 6)
+		require.Equal(t, 6, jpegOrientation(data))
+
+		processed, err := processImage(data, "image/jpeg")
+		require.NoError(t, err)
+		assert.Equal(t, 20, processed.width)
+		assert.Equal(t, 40, processed.height)
+		assert.False(t, bytes.Contains(processed.data, []byte("Exif")))
+		assert.Equal(t, 1, jpegOrientation(processed.data))
+	})
+
+	t.Run("should generate variants no wider than the image", func(t *testing.T) {
+		var encoded bytes.Buffer
+		img := newTestImage(800, 400, color.RGBA{10, 200, 10, 255})
+		require.NoError(t, png.Encode(&encoded, img))
+
+		processed, err := processImage(encoded.Bytes(), "image/png")
+		require.NoError(t, err)
+		require.Len(t, processed.variants, 3)
+
+		sizes := map[string][2]int{}
+		for _, variant := range processed.variants {
+			assert.Equal(t, "image/jpeg", variant.contentType)
+			sizes[variant.name] = [2]int{variant.width, variant.height}
+		}
+		assert.Equal(t, map[string][2]int{
+			"thumbnail": {200, 200},
+			"small":     {320, 160},
+			"medium":    {640, 320},
+		}, sizes)
+		assert.Len(t, processed.blurhash, 28)
+	})
+
+	t.Run("should keep transparency of variants", func(t *testing.T) {
+		var encoded bytes.Buffer
+		img := newTestImage(400, 400, color.RGBA{0, 0, 0, 0})
+		require.NoError(t, png.Encode(&encoded, img))
+
+		processed, err := processImage(encoded.Bytes(), "image/png")
+		require.NoError(t, err)
+		for _, variant := range processed.variants {
+			assert.Equal(t, "image/png", variant.contentType)
+		}
+	})
+
+	t.Run("should keep GIF animations", func(t *testing.T) {
+		palette := color.Palette{color.Black, color.White}
+		animation := &gif.GIF{
+			Image: []*image.Paletted{
+				image.NewPaletted(image.Rect(0, 0, 10, 10), palette),
+				image.NewPaletted(image.Rect(0, 0, 10, 10), palette),
+			},
+			Delay: []int{10, 10},
+		}
+		var encoded bytes.Buffer
+		require.NoError(t, gif.EncodeAll(&encoded, animation))
+
+		processed, err := processImage(encoded.Bytes(), "image/gif")
+		require.NoError(t, err)
+		decoded, err := gif.DecodeAll(bytes.NewReader(processed.data))
+		require.NoError(t, err)
+		assert.Len(t, decoded.Image, 2)
+	})
+
+	t.Run("should refuse images that are not valid", func(t *testing.T) {
+		_, err := processImage([]byte("not an image"), "image/png")
+		assert.Error(t, err)
+	})
+}
+
+func TestStripWebpMetadata(t *testing.T) {
+	chunk := func(fourCC string, payload []byte) []byte {
+		data := []byte(fourCC)
+		data = binary.LittleEndian.AppendUint32(data, uint32(len(payload)))
+		data = append(data, payload...)
+		if len(payload)%2 == 1 {
+			data = append(data, 0)
+		}
+		return data
+	}
+	var body []byte
+	body = append(body, "WEBP"...)
+	body = append(body, chunk("VP8X", []byte{0x0C, 0, 0, 0, 0, 0, 0, 0, 0, 0})...)
+	body = append(body, chunk("VP8L", []byte{1, 2, 3})...)
+	body = append(body, chunk("EXIF", []byte("gps data"))...)
+	body = append(body, chunk("XMP ", []byte("<xmp/>"))...)
+	data := binary.LittleEndian.AppendUint32([]byte("RIFF"), uint32(len(body)))
+	data = append(data, body...)
+
+	stripped, err := stripWebpMetadata(data)
+	require.NoError(t, err)
+
+	assert.False(t, bytes.Contains(stripped, []byte("gps data")))
+	assert.False(t, bytes.Contains(stripped, []byte("<xmp/>")))
+	assert.True(t, bytes.Contains(stripped, []byte{1, 2, 3}))
+	assert.Equal(t, byte(0), stripped[20])
+	assert.Equal(
+		t,
+		uint32(len(stripped)-8),
+		binary.LittleEndian.Uint32(stripped[4:]),
+	)
+}
+
+func TestEncodeBlurhash(t *testing.T) {
+	white := encodeBlurhash(newTestImage(8, 8, color.White), 4, 3)
+	black := encodeBlurhash(newTestImage(8, 8, color.Black), 4, 3)
+
+	// The first character encodes the component counts, then the average
+	// colour takes four characters after the maximum AC value.
+	require.Len(t, white, 28)
+	assert.Equal(t, "L", white[:1])
+	assert.Equal(t, "TSUA", white[2:6])
+	assert.Equal(t, "0000", black[2:6])
+}
@@ -0,0 +1,314 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"golang.org/x/net/html/charset"
+
+	"apps/api/internal/config"
+	"apps/api/internal/models"
+)
+
+const (
+	linkPreviewMaxRedirects    = 3
+	linkPreviewMaxTitle        = 300
+	linkPreviewMaxDescription  = 1000
+	linkPreviewMaxSiteName     = 100
+	linkPreviewMaxImageUrl     = 2048
+	linkPreviewUserAgent       = "Mozilla/5.0 (compatible; LinkPreviewBot/1.0)"
+	linkPreviewAcceptedContent = "text/html,application/xhtml+xml"
+)
+
+var (
+	ErrLinkPreviewAddressBlocked = errors.New("Address is not public")
+	ErrLinkPreviewNotHtml        = errors.New("Page is not HTML")
+	ErrLinkPreviewNoTitle        = errors.New("Page has no title")
+)
+
+// blockedPrefixes are the special purpose ranges next to the loopback,
+// private, link-local and multicast ones that are not reachable publicly.
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.0.2.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("64:ff9b:1::/48"),
+	netip.MustParsePrefix("100::/64"),
+	netip.MustParsePrefix("2001::/23"),
+	netip.MustParsePrefix("2001:db8::/32"),
+	netip.MustParsePrefix("2002::/16"),
+}
+
+// isPublicAddr reports whether the address is publicly routable.
+func isPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() ||
+		addr.IsLoopback() ||
+		addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() ||
+		addr.IsUnspecified() {
+		return false
+	}
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// LinkPreviewFetcher fetches the OpenGraph and Twitter card metadata of web
+// pages. Only public addresses are connected to, which is checked after
+// resolving the host name so that no name can point to internal services.
+type LinkPreviewFetcher struct {
+	client   *http.Client
+	maxBytes int64
+}
+
+func NewLinkPreviewFetcher(
+	config *config.LinkPreviewsConfig,
+) *LinkPreviewFetcher {
+	return newLinkPreviewFetcher(config, isPublicAddr)
+}
+
+func newLinkPreviewFetcher(
+	config *config.LinkPreviewsConfig,
+	allowAddr func(netip.Addr) bool,
+) *LinkPreviewFetcher {
+	timeout := time.Duration(config.FetchTimeoutSeconds) * time.Second
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if !allowAddr(addrPort.Addr()) {
+				return ErrLinkPreviewAddressBlocked
+			}
+			return nil
+		},
+	}
+	transport := &http.Transport{
+		DialContext:           dialer.DialContext,
+		MaxIdleConns:          10,
+		IdleConnTimeout:       30 * time.Second,
+		ResponseHeaderTimeout: timeout,
+		TLSHandshakeTimeout:   timeout,
+		// Proxies are not used, they would connect on behalf of the
+		// fetcher to any address.
+		Proxy: nil,
+	}
+
+	return &LinkPreviewFetcher{
+		client: &http.Client{
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) > linkPreviewMaxRedirects {
+					return errors.New("Too many redirects")
+				}
+				if !isHttpUrl(req.URL) {
+					return errors.New("Redirect to unsupported URL")
+				}
+				return nil
+			},
+			Timeout:   timeout,
+			Transport: transport,
+		},
+		maxBytes: int64(config.MaxBytes),
+	}
+}
+
+// Fetch downloads the page and returns its preview metadata.
+func (f *LinkPreviewFetcher) Fetch(
+	ctx context.Context,
+	rawUrl string,
+) (*models.LinkPreviewFetched, error) {
+	pageUrl, err := url.Parse(rawUrl)
+	if err != nil {
+		return nil, err
+	}
+	if !isHttpUrl(pageUrl) {
+		return nil, errors.New("Unsupported URL")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", pageUrl.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", linkPreviewAcceptedContent)
+	req.Header.Set("User-Agent", linkPreviewUserAgent)
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Unexpected status %d", resp.StatusCode)
+	}
+	contentType := resp.Header.Get("Content-Type")
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return nil, ErrLinkPreviewNotHtml
+	}
+
+	body, err := charset.NewReader(
+		io.LimitReader(resp.Body, f.maxBytes),
+		contentType,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseLinkPreview(body, resp.Request.URL)
+}
+
+// parseLinkPreview reads the metadata from the head of the page, preferring
+// OpenGraph over Twitter card properties over the plain HTML title and
+// description.
+func parseLinkPreview(
+	r io.Reader,
+	pageUrl *url.URL,
+) (*models.LinkPreviewFetched, error) {
+	meta := map[string]string{}
+	var title strings.Builder
+	inTitle := false
+
+	tokenizer := html.NewTokenizer(r)
+tokens:
+	for {
+		tokenType := tokenizer.Next()
+		switch tokenType {
+		case html.ErrorToken:
+			// The page may be cut off by the size cap, the metadata read so
+			// far is used.
+			if err := tokenizer.Err(); !errors.Is(err, io.EOF) &&
+				!errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil, err
+			}
+			break tokens
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			switch token.DataAtom {
+			case atom.Body:
+				break tokens
+			case atom.Title:
+				inTitle = tokenType == html.StartTagToken && title.Len() == 0
+			case atom.Meta:
+				key, content := metaProperty(token)
+				if _, ok := meta[key]; key != "" && !ok {
+					meta[key] = content
+				}
+			}
+		case html.TextToken:
+			if inTitle {
+				title.Write(tokenizer.Text())
+			}
+		case html.EndTagToken:
+			token := tokenizer.Token()
+			if token.DataAtom == atom.Head {
+				break tokens
+			}
+			if token.DataAtom == atom.Title {
+				inTitle = false
+			}
+		}
+	}
+
+	first := func(keys ...string) string {
+		for _, key := range keys {
+			if value := cleanText(meta[key]); value != "" {
+				return value
+			}
+		}
+		return ""
+	}
+
+	pageTitle := first("og:title", "twitter:title")
+	if pageTitle == "" {
+		pageTitle = cleanText(title.String())
+	}
+
+	fetched := &models.LinkPreviewFetched{
+		Description: optionalText(
+			first("og:description", "twitter:description", "description"),
+			linkPreviewMaxDescription,
+		),
+		SiteName: optionalText(first("og:site_name"), linkPreviewMaxSiteName),
+		Title:    optionalText(pageTitle, linkPreviewMaxTitle),
+	}
+	if fetched.Title == nil {
+		return nil, ErrLinkPreviewNoTitle
+	}
+
+	image := first("og:image:secure_url", "og:image", "twitter:image")
+	if image != "" {
+		imageUrl, err := pageUrl.Parse(image)
+		if err == nil && isHttpUrl(imageUrl) &&
+			len(imageUrl.String()) <= linkPreviewMaxImageUrl {
+			image := imageUrl.String()
+			fetched.ImageUrl = &image
+		}
+	}
+
+	return fetched, nil
+}
+
+// metaProperty returns the lowercased property or name of the meta tag with
+// its content.
+func metaProperty(token html.Token) (string, string) {
+	var key, content string
+	for _, attr := range token.Attr {
+		switch attr.Key {
+		case "property", "name":
+			if key == "" {
+				key = strings.ToLower(strings.TrimSpace(attr.Val))
+			}
+		case "content":
+			content = attr.Val
+		}
+	}
+	return key, content
+}
+
+func isHttpUrl(u *url.URL) bool {
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func cleanText(s string) string {
+	return strings.Join(strings.Fields(strings.ToValidUTF8(s, "")), " ")
+}
+
+// optionalText returns nil for empty text and truncates it to limit runes.
+func optionalText(s string, limit int) *string {
+	if s == "" {
+		return nil
+	}
+	if utf8.RuneCountInString(s) > limit {
+		s = strings.TrimSpace(string([]rune(s)[:limit-1])) + "…"
+	}
+	return &s
+}
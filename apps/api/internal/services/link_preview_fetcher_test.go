@@ -0,0 +1,220 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"apps/api/internal/config"
+)
+
+func newTestLinkPreviewFetcher(maxBytes int) *LinkPreviewFetcher {
+	return newLinkPreviewFetcher(
+		&config.LinkPreviewsConfig{FetchTimeoutSeconds: 1, MaxBytes: maxBytes},
+		func(netip.Addr) bool { return true },
+	)
+}
+
+func serveHtml(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(body))
+	}
+}
+
+func TestLinkPreviewFetcher_Fetch(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/og", serveHtml(`<!doctype html>
+<html><head>
+<title>Page title</title>
+<meta property="og:title" content="  OpenGraph   title ">
+<meta property="og:description" content="OpenGraph description">
+<meta property="og:image" content="/images/cover.png">
+<meta property="og:site_name" content="Example">
+<meta name="twitter:title" content="Twitter title">
+</head><body><meta property="og:title" content="Body title"></body></html>`))
+	mux.HandleFunc("/twitter", serveHtml(`<html><head>
+<title>Page title</title>
+<meta name="twitter:title" content="Twitter title">
+<meta name="twitter:description" content="Twitter description">
+<meta name="twitter:image" content="https://cdn.example.com/card.jpg">
+</head></html>`))
+	mux.HandleFunc("/plain", serveHtml(`<html><head>
+<title>
+  Plain &amp; simple
+</title>
+<meta name="description" content="Plain description">
+<meta property="og:image" content="javascript:alert(1)">
+</head></html>`))
+	mux.HandleFunc("/untitled", serveHtml(`<html><head></head></html>`))
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/og", http.StatusFound)
+	})
+	mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	})
+	mux.HandleFunc("/json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"title":"JSON"}`))
+	})
+	mux.HandleFunc("/missing", http.NotFound)
+	mux.HandleFunc("/latin1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=iso-8859-1")
+		w.Write([]byte("<html><head><title>Caf\xe9</title></head></html>"))
+	})
+	mux.HandleFunc("/large", serveHtml(
+		"<html><head><title>Large</title>"+
+			strings.Repeat("<!-- padding -->", 1000)+
+			`<meta property="og:description" content="Too far">`+
+			"</head></html>",
+	))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	fetcher := newTestLinkPreviewFetcher(1 << 10)
+	ctx := context.Background()
+
+	t.Run("OpenGraph", func(t *testing.T) {
+		fetched, err := fetcher.Fetch(ctx, server.URL+"/og")
+		require.NoError(t, err)
+		assert.Equal(t, "OpenGraph title", *fetched.Title)
+		assert.Equal(t, "OpenGraph description", *fetched.Description)
+		assert.Equal(t, server.URL+"/images/cover.png", *fetched.ImageUrl)
+		assert.Equal(t, "Example", *fetched.SiteName)
+	})
+
+	t.Run("Twitter card", func(t *testing.T) {
+		fetched, err := fetcher.Fetch(ctx, server.URL+"/twitter")
+		require.NoError(t, err)
+		assert.Equal(t, "Twitter title", *fetched.Title)
+		assert.Equal(t, "Twitter description", *fetched.Description)
+		assert.Equal(t, "https://cdn.example.com/card.jpg", *fetched.ImageUrl)
+		assert.Nil(t, fetched.SiteName)
+	})
+
+	t.Run("Plain HTML", func(t *testing.T) {
+		fetched, err := fetcher.Fetch(ctx, server.URL+"/plain")
+		require.NoError(t, err)
+		assert.Equal(t, "Plain & simple", *fetched.Title)
+		assert.Equal(t, "Plain description", *fetched.Description)
+		assert.Nil(t, fetched.ImageUrl)
+	})
+
+	t.Run("Charset", func(t *testing.T) {
+		fetched, err := fetcher.Fetch(ctx, server.URL+"/latin1")
+		require.NoError(t, err)
+		assert.Equal(t, "Café", *fetched.Title)
+	})
+
+	t.Run("Redirect", func(t *testing.T) {
+		fetched, err := fetcher.Fetch(ctx, server.URL+"/redirect")
+		require.NoError(t, err)
+		assert.Equal(t, "OpenGraph title", *fetched.Title)
+		assert.Equal(t, server.URL+"/images/cover.png", *fetched.ImageUrl)
+	})
+
+	t.Run("Size cap", func(t *testing.T) {
+		fetched, err := fetcher.Fetch(ctx, server.URL+"/large")
+		require.NoError(t, err)
+		assert.Equal(t, "Large", *fetched.Title)
+		assert.Nil(t, fetched.Description)
+	})
+
+	failures := map[string]string{
+		"Too many redirects": "/loop",
+		"Not HTML":           "/json",
+		"Not found":          "/missing",
+		"No title":           "/untitled",
+	}
+	for name, path := range failures {
+		t.Run(name, func(t *testing.T) {
+			_, err := fetcher.Fetch(ctx, server.URL+path)
+			assert.Error(t, err)
+		})
+	}
+
+	t.Run("Unsupported scheme", func(t *testing.T) {
+		_, err := fetcher.Fetch(ctx, "ftp://example.com/file")
+		assert.Error(t, err)
+	})
+}
+
+func TestLinkPreviewFetcher_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		}),
+	)
+	defer server.Close()
+	defer close(release)
+
+	fetcher := newTestLinkPreviewFetcher(1 << 10)
+	start := time.Now()
+	_, err := fetcher.Fetch(context.Background(), server.URL)
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 3*time.Second)
+}
+
+func TestLinkPreviewFetcher_BlocksPrivateAddresses(t *testing.T) {
+	server := httptest.NewServer(serveHtml(
+		"<html><head><title>Internal</title></head></html>",
+	))
+	defer server.Close()
+
+	fetcher := NewLinkPreviewFetcher(&config.LinkPreviewsConfig{
+		FetchTimeoutSeconds: 1,
+		MaxBytes:            1 << 10,
+	})
+	_, err := fetcher.Fetch(context.Background(), server.URL)
+	assert.ErrorIs(t, err, ErrLinkPreviewAddressBlocked)
+
+	// Redirects are followed through the same dialer.
+	redirect := httptest.NewServer(
+		http.RedirectHandler(server.URL, http.StatusFound),
+	)
+	defer redirect.Close()
+	_, err = fetcher.Fetch(context.Background(), redirect.URL)
+	assert.ErrorIs(t, err, ErrLinkPreviewAddressBlocked)
+}
+
+func TestIsPublicAddr(t *testing.T) {
+	tests := map[string]bool{
+		"93.184.215.14":        true,
+		"2606:2800:21f:cb07::": true,
+		"127.0.0.1":            false,
+		"10.1.2.3":             false,
+		"172.16.0.1":           false,
+		"192.168.1.1":          false,
+		"169.254.169.254":      false,
+		"100.64.0.1":           false,
+		"0.0.0.0":              false,
+		"198.18.0.1":           false,
+		"224.0.0.1":            false,
+		"255.255.255.255":      false,
+		"::1":                  false,
+		"::":                   false,
+		"fc00::1":              false,
+		"fe80::1":              false,
+		"ff02::1":              false,
+		"::ffff:127.0.0.1":     false,
+		"::ffff:10.0.0.1":      false,
+		"64:ff9b::a00:1":       false,
+		"2001:db8::1":          false,
+	}
+	for addr, public := range tests {
+		t.Run(addr, func(t *testing.T) {
+			assert.Equal(t, public, isPublicAddr(netip.MustParseAddr(addr)))
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"apps/api/internal/config"
+	"apps/api/internal/repositories"
+)
+
+const (
+	// linkPreviewQueueSize is the number of links waiting for a worker.
+	// Links that do not fit are picked up by the next sweep.
+	linkPreviewQueueSize = 256
+	// linkPreviewFetchTimeout is how long a fetch may take before the link
+	// is fetched again, e.g. after the process exited.
+	linkPreviewFetchTimeout = 5 * time.Minute
+)
+
+// LinkPreviewService fetches the previews of links in posts in the
+// background with a pool of workers. Besides the links it is told about, it
+// periodically sweeps for links that were never fetched.
+type LinkPreviewService struct {
+	fetcher         *LinkPreviewFetcher
+	interval        time.Duration
+	linkPreviewRepo *repositories.LinkPreviewRepo
+	queue           chan string
+	refresh         time.Duration
+	workers         int
+}
+
+func NewLinkPreviewService(
+	fetcher *LinkPreviewFetcher,
+	linkPreviewRepo *repositories.LinkPreviewRepo,
+	config *config.LinkPreviewsConfig,
+) *LinkPreviewService {
+	return &LinkPreviewService{
+		fetcher:         fetcher,
+		interval:        time.Minute,
+		linkPreviewRepo: linkPreviewRepo,
+		queue:           make(chan string, linkPreviewQueueSize),
+		refresh:         time.Duration(config.RefreshHours) * time.Hour,
+		workers:         max(1, config.Workers),
+	}
+}
+
+// Enqueue schedules the links for fetching. It never blocks.
+func (s *LinkPreviewService) Enqueue(urls ...string) {
+	for _, url := range urls {
+		select {
+		case s.queue <- url:
+		default:
+		}
+	}
+}
+
+// Run fetches links until ctx is done and waits for the workers to finish
+// the links they are fetching.
+func (s *LinkPreviewService) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for range s.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case url := <-s.queue:
+					s.fetch(ctx, url)
+				}
+			}
+		}()
+	}
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.sweep(ctx)
+
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sweep enqueues links that were never fetched or whose fetch timed out, as
+// many as the queue has room for.
+func (s *LinkPreviewService) sweep(ctx context.Context) {
+	room := cap(s.queue) - len(s.queue)
+	if room == 0 {
+		return
+	}
+
+	urls, err := s.linkPreviewRepo.GetUnfetchedLinkPreviewUrls(
+		ctx,
+		time.Now().Add(-linkPreviewFetchTimeout),
+		room,
+	)
+	if err != nil {
+		log.Printf("Failed to find unfetched links: %v", err)
+		return
+	}
+	s.Enqueue(urls...)
+}
+
+func (s *LinkPreviewService) fetch(ctx context.Context, url string) {
+	now := time.Now()
+	err := s.linkPreviewRepo.ClaimLinkPreviewFetch(
+		ctx,
+		url,
+		now.Add(-s.refresh),
+		now.Add(-linkPreviewFetchTimeout),
+	)
+	if errors.Is(err, repositories.ErrLinkPreviewNotDue) {
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to claim link %s: %v", url, err)
+		return
+	}
+
+	// Pages that cannot be fetched are marked as failed and retried once
+	// they are due for a refresh. Fetches cut off by the shutdown are
+	// retried once the fetch timed out.
+	fetched, err := s.fetcher.Fetch(ctx, url)
+	if ctx.Err() != nil {
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to fetch link %s: %v", url, err)
+	}
+
+	err = s.linkPreviewRepo.CompleteLinkPreviewFetch(ctx, url, fetched)
+	if err != nil {
+		log.Printf("Failed to complete link %s: %v", url, err)
+	}
+}
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// LocalBlobStore keeps blobs as files below a directory.
+type LocalBlobStore struct {
+	root string
+}
+
+func NewLocalBlobStore(root string) (*LocalBlobStore, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("Failed to create media directory: %w", err)
+	}
+
+	return &LocalBlobStore{root: root}, nil
+}
+
+// Put writes the blob to a temporary file first, so that readers never see
+// a partially written blob.
+func (s *LocalBlobStore) Put(
+	_ context.Context,
+	key string,
+	body io.Reader,
+	size int64,
+	_ string,
+) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("Failed to create blob directory: %w", err)
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("Failed to create blob: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	written, err := io.Copy(file, io.LimitReader(body, size))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to write blob: %w", err)
+	}
+	if written != size {
+		return fmt.Errorf(
+			"Failed to write blob: read %d of %d bytes",
+			written,
+			size,
+		)
+	}
+
+	if err := os.Rename(file.Name(), path); err != nil {
+		return fmt.Errorf("Failed to write blob: %w", err)
+	}
+
+	return nil
+}
+
+func (s *LocalBlobStore) Get(
+	ctx context.Context,
+	key string,
+) (io.ReadCloser, error) {
+	return s.GetFrom(ctx, key, 0)
+}
+
+func (s *LocalBlobStore) GetFrom(
+	_ context.Context,
+	key string,
+	offset int64,
+) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("Failed to open blob: %w", ErrBlobNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to open blob: %w", err)
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("Failed to seek blob: %w", err)
+	}
+
+	return file, nil
+}
+
+func (s *LocalBlobStore) Delete(_ context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("Failed to delete blob: %w", err)
+	}
+
+	return nil
+}
+
+// path maps the key to a file below the root, refusing keys that would
+// escape it.
+func (s *LocalBlobStore) path(key string) (string, error) {
+	if !filepath.IsLocal(filepath.FromSlash(key)) {
+		return "", fmt.Errorf("Invalid blob key %q", key)
+	}
+
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,230 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"sync"
+	"time"
+
+	"apps/api/internal/config"
+	"apps/api/internal/models"
+	"apps/api/internal/repositories"
+)
+
+const (
+	// mediaProcessingQueueSize is the number of media waiting for a worker.
+	// Media that do not fit are picked up by the next sweep.
+	mediaProcessingQueueSize = 256
+	// mediaProcessingTimeout is how long processing may take before the
+	// media is processed again, e.g. after the process exited.
+	mediaProcessingTimeout = 10 * time.Minute
+)
+
+// MediaProcessor processes uploaded images in the background with a pool of
+// workers. Besides the uploads it is told about, it periodically sweeps for
+// media that were never processed.
+type MediaProcessor struct {
+	blobStore BlobStore
+	interval  time.Duration
+	mediaRepo *repositories.MediaRepo
+	queue     chan string
+	workers   int
+}
+
+func NewMediaProcessor(
+	blobStore BlobStore,
+	mediaRepo *repositories.MediaRepo,
+	config *config.MediaConfig,
+) *MediaProcessor {
+	return &MediaProcessor{
+		blobStore: blobStore,
+		interval:  time.Minute,
+		mediaRepo: mediaRepo,
+		queue:     make(chan string, mediaProcessingQueueSize),
+		workers:   max(1, config.ProcessingWorkers),
+	}
+}
+
+// Enqueue schedules the media for processing. It never blocks.
+func (p *MediaProcessor) Enqueue(mediaId string) {
+	select {
+	case p.queue <- mediaId:
+	default:
+	}
+}
+
+// Run processes media until ctx is done and waits for the workers to finish
+// the media they are processing.
+func (p *MediaProcessor) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for range p.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case mediaId := <-p.queue:
+					p.process(ctx, mediaId)
+				}
+			}
+		}()
+	}
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.sweep(ctx)
+
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sweep enqueues media that are pending or whose processing timed out, as
+// many as the queue has room for.
+func (p *MediaProcessor) sweep(ctx context.Context) {
+	room := cap(p.queue) - len(p.queue)
+	if room == 0 {
+		return
+	}
+
+	ids, err := p.mediaRepo.GetUnprocessedMediaIds(
+		ctx,
+		time.Now().Add(-mediaProcessingTimeout),
+		room,
+	)
+	if err != nil {
+		log.Printf("Failed to find unprocessed media: %v", err)
+		return
+	}
+	for _, id := range ids {
+		p.Enqueue(id)
+	}
+}
+
+func (p *MediaProcessor) process(ctx context.Context, mediaId string) {
+	media, err := p.mediaRepo.ClaimMediaProcessing(
+		ctx,
+		mediaId,
+		time.Now().Add(-mediaProcessingTimeout),
+	)
+	if errors.Is(err, repositories.ErrMediaNotPending) {
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to claim media %s: %v", mediaId, err)
+		return
+	}
+
+	if !isProcessableImage(media.ContentType) {
+		err := p.mediaRepo.CompleteMediaProcessing(ctx, media.ID, nil)
+		if err != nil {
+			log.Printf("Failed to complete media %s: %v", media.ID, err)
+		}
+		return
+	}
+
+	processed, err := p.processImage(ctx, media)
+	if err != nil {
+		// Media left processing are retried once the processing timed out,
+		// only images that cannot be processed are marked as failed.
+		var processingErr *imageProcessingError
+		if errors.As(err, &processingErr) {
+			err = p.mediaRepo.FailMediaProcessing(ctx, media.ID)
+		}
+		log.Printf("Failed to process media %s: %v", media.ID, err)
+		return
+	}
+
+	err = p.mediaRepo.CompleteMediaProcessing(ctx, media.ID, processed)
+	if err != nil {
+		log.Printf("Failed to complete media %s: %v", media.ID, err)
+	}
+}
+
+type imageProcessingError struct {
+	err error
+}
+
+func (e *imageProcessingError) Error() string {
+	return e.err.Error()
+}
+
+func (e *imageProcessingError) Unwrap() error {
+	return e.err
+}
+
+// processImage stores the variants of the image and replaces it with its
+// copy without metadata. Errors of the image itself are returned as an
+// imageProcessingError.
+func (p *MediaProcessor) processImage(
+	ctx context.Context,
+	media *models.Media,
+) (*models.MediaProcessed, error) {
+	blob, err := p.blobStore.Get(ctx, media.StorageKey)
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(blob)
+	blob.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := processImage(data, media.ContentType)
+	if err != nil {
+		return nil, &imageProcessingError{err: err}
+	}
+
+	processed := &models.MediaProcessed{
+		Blurhash:  result.blurhash,
+		Height:    result.height,
+		SizeBytes: int64(len(result.data)),
+		Width:     result.width,
+	}
+	for _, variant := range result.variants {
+		key := media.StorageKey + "-" + variant.name
+		err := p.blobStore.Put(
+			ctx,
+			key,
+			bytes.NewReader(variant.data),
+			int64(len(variant.data)),
+			variant.contentType,
+		)
+		if err != nil {
+			return nil, err
+		}
+		processed.Variants = append(processed.Variants, &models.MediaVariant{
+			ContentType: variant.contentType,
+			Height:      variant.height,
+			MediaId:     media.ID,
+			Name:        variant.name,
+			SizeBytes:   int64(len(variant.data)),
+			StorageKey:  key,
+			Width:       variant.width,
+		})
+	}
+
+	err = p.blobStore.Put(
+		ctx,
+		media.StorageKey,
+		bytes.NewReader(result.data),
+		int64(len(result.data)),
+		media.ContentType,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return processed, nil
+}
@@ -0,0 +1,212 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"apps/api/internal/config"
+	"apps/api/internal/models"
+	"apps/api/internal/repositories"
+)
+
+var (
+	ErrMediaNotReady        = errors.New("Media not ready")
+	ErrMediaVariantNotFound = errors.New("Media variant not found")
+	ErrUnsupportedMediaType = errors.New("Unsupported media type")
+)
+
+// orphanedMediaBatchSize is the number of orphaned media deleted per
+// transaction.
+const orphanedMediaBatchSize = 100
+
+// MediaService stores uploads in the blob store, hands them to the media
+// processor and periodically deletes uploads that were not attached to a
+// post in time.
+type MediaService struct {
+	allowedContentTypes []string
+	blobStore           BlobStore
+	interval            time.Duration
+	mediaProcessor      *MediaProcessor
+	mediaRepo           *repositories.MediaRepo
+	orphanRetention     time.Duration
+}
+
+// MediaContent is the file of a media, or of one of its variants, opened for
+// reading.
+type MediaContent struct {
+	io.ReadSeekCloser
+	ContentType string
+	CreatedAt   time.Time
+}
+
+func NewMediaService(
+	blobStore BlobStore,
+	mediaProcessor *MediaProcessor,
+	mediaRepo *repositories.MediaRepo,
+	config *config.MediaConfig,
+) *MediaService {
+	return &MediaService{
+		allowedContentTypes: config.AllowedContentTypes,
+		blobStore:           blobStore,
+		interval:            time.Hour,
+		mediaProcessor:      mediaProcessor,
+		mediaRepo:           mediaRepo,
+		orphanRetention: time.Duration(
+			config.OrphanRetentionHours,
+		) * time.Hour,
+	}
+}
+
+// Upload stores the data for the owner and schedules its processing. The
+// content type is sniffed from the data rather than trusted from the client,
+// and ErrUnsupportedMediaType is returned when it is not allowed.
+func (s *MediaService) Upload(
+	ctx context.Context,
+	ownerId string,
+	data []byte,
+) (*models.Media, error) {
+	contentType, _, _ := strings.Cut(http.DetectContentType(data), ";")
+	if !slices.Contains(s.allowedContentTypes, contentType) {
+		return nil, fmt.Errorf(
+			"Failed to upload %s: %w",
+			contentType,
+			ErrUnsupportedMediaType,
+		)
+	}
+
+	key, err := newMediaKey()
+	if err != nil {
+		return nil, err
+	}
+
+	// The row is created first, so that a blob is never stored without one
+	// and a failed upload is deleted with the orphans at the latest.
+	media, err := s.mediaRepo.CreateMedia(ctx, models.MediaCreate{
+		ContentType: contentType,
+		OwnerId:     ownerId,
+		SizeBytes:   int64(len(data)),
+		StorageKey:  key,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.blobStore.Put(
+		ctx,
+		key,
+		bytes.NewReader(data),
+		int64(len(data)),
+		contentType,
+	)
+	if err != nil {
+		_ = s.mediaRepo.DeleteMedia(ctx, media.ID)
+		return nil, err
+	}
+
+	s.mediaProcessor.Enqueue(media.ID)
+
+	return media, nil
+}
+
+// OpenMedia opens the file of the media, or of its variant when variant is
+// not empty. ErrMediaNotReady is returned until the media is processed, as
+// the original may still carry metadata such as where it was taken.
+func (s *MediaService) OpenMedia(
+	ctx context.Context,
+	id string,
+	variant string,
+) (*MediaContent, error) {
+	media, err := s.mediaRepo.GetMediaById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if media.Status != models.MediaStatusReady {
+		return nil, fmt.Errorf("Failed to open media: %w", ErrMediaNotReady)
+	}
+
+	contentType := media.ContentType
+	key := media.StorageKey
+	size := media.SizeBytes
+	if variant != "" {
+		i := slices.IndexFunc(
+			media.Variants,
+			func(v *models.MediaVariant) bool { return v.Name == variant },
+		)
+		if i < 0 {
+			return nil, fmt.Errorf(
+				"Failed to open media variant %s: %w",
+				variant,
+				ErrMediaVariantNotFound,
+			)
+		}
+		contentType = media.Variants[i].ContentType
+		key = media.Variants[i].StorageKey
+		size = media.Variants[i].SizeBytes
+	}
+
+	return &MediaContent{
+		ReadSeekCloser: newBlobReader(ctx, s.blobStore, key, size),
+		ContentType:    contentType,
+		CreatedAt:      media.CreatedAt,
+	}, nil
+}
+
+func (s *MediaService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.deleteOrphans(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *MediaService) deleteOrphans(ctx context.Context) {
+	createdBefore := time.Now().Add(-s.orphanRetention)
+
+	total := 0
+	for {
+		deleted, err := s.mediaRepo.DeleteOrphanedMedia(
+			ctx,
+			createdBefore,
+			orphanedMediaBatchSize,
+			s.blobStore.Delete,
+		)
+		total += deleted
+		if err != nil {
+			log.Printf("Failed to delete orphaned media: %v", err)
+			break
+		}
+		if deleted < orphanedMediaBatchSize {
+			break
+		}
+	}
+	if total > 0 {
+		log.Printf("Deleted %d orphaned media", total)
+	}
+}
+
+// newMediaKey returns a random, unguessable blob key for an upload.
+func newMediaKey() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("Failed to generate media key: %w", err)
+	}
+
+	return "media/" + hex.EncodeToString(id), nil
+}
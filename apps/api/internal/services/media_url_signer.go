@@ -0,0 +1,100 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"apps/api/internal/config"
+)
+
+var (
+	ErrInvalidMediaURLSignature = errors.New("Invalid media URL signature")
+	ErrMediaURLExpired          = errors.New("Media URL expired")
+)
+
+// MediaURLSigner signs the URLs media are downloaded through, so that media
+// of posts which are not public can only be fetched by the viewers the posts
+// were served to, and only for a while.
+type MediaURLSigner struct {
+	base       string
+	expiration time.Duration
+	now        func() time.Time
+	secret     []byte
+}
+
+func NewMediaURLSigner(config *config.MediaConfig) (*MediaURLSigner, error) {
+	if config.URLSecret == "" {
+		return nil, errors.New("A secret to sign media URLs is required")
+	}
+
+	return &MediaURLSigner{
+		base: config.URLBase,
+		expiration: time.Duration(
+			config.URLExpirationMinutes,
+		) * time.Minute,
+		now:    time.Now,
+		secret: []byte(config.URLSecret),
+	}, nil
+}
+
+// Expires returns when the URLs signed now expire. It is rounded, so that
+// URLs and the responses including them stay the same for a whole period,
+// and URLs are valid for one to two periods.
+func (s *MediaURLSigner) Expires() time.Time {
+	return s.Signed().Add(2 * s.expiration)
+}
+
+// Signed returns when the period of the URLs signed now started, which is
+// when they last changed.
+func (s *MediaURLSigner) Signed() time.Time {
+	return s.now().Truncate(s.expiration)
+}
+
+// URL returns the signed URL of the media, or of its variant when variant is
+// not empty.
+func (s *MediaURLSigner) URL(mediaId string, variant string) string {
+	expires := s.Expires().Unix()
+
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", s.sign(mediaId, variant, expires))
+	if variant != "" {
+		query.Set("variant", variant)
+	}
+
+	return s.base + "/" + url.PathEscape(mediaId) + "?" + query.Encode()
+}
+
+// Verify checks the signature and the expiry of a media URL.
+func (s *MediaURLSigner) Verify(
+	mediaId string,
+	variant string,
+	expires int64,
+	signature string,
+) error {
+	expected := s.sign(mediaId, variant, expires)
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return ErrInvalidMediaURLSignature
+	}
+	if s.now().Unix() > expires {
+		return ErrMediaURLExpired
+	}
+
+	return nil
+}
+
+func (s *MediaURLSigner) sign(
+	mediaId string,
+	variant string,
+	expires int64,
+) string {
+	mac := hmac.New(sha256.New, s.secret)
+	fmt.Fprintf(mac, "%s\n%s\n%d", mediaId, variant, expires)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"apps/api/internal/config"
+)
+
+func newTestMediaURLSigner(t *testing.T, now *time.Time) *MediaURLSigner {
+	signer, err := NewMediaURLSigner(&config.MediaConfig{
+		URLBase:              "https://cdn.example.com/media",
+		URLExpirationMinutes: 60,
+		URLSecret:            "secret",
+	})
+	require.NoError(t, err)
+	signer.now = func() time.Time { return *now }
+	return signer
+}
+
+// verifyTestMediaURL verifies the URL the way the download endpoint does.
+func verifyTestMediaURL(
+	t *testing.T,
+	signer *MediaURLSigner,
+	mediaURL string,
+) error {
+	parsed, err := url.Parse(mediaURL)
+	require.NoError(t, err)
+	query := parsed.Query()
+	expires, err := strconv.ParseInt(query.Get("expires"), 10, 64)
+	require.NoError(t, err)
+
+	return signer.Verify(
+		parsed.Path[len("/media/"):],
+		query.Get("variant"),
+		expires,
+		query.Get("signature"),
+	)
+}
+
+func TestMediaURLSigner(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 20, 0, 0, time.UTC)
+	signer := newTestMediaURLSigner(t, &now)
+
+	t.Run("should require a secret", func(t *testing.T) {
+		_, err := NewMediaURLSigner(&config.MediaConfig{})
+		assert.Error(t, err)
+	})
+
+	t.Run("should verify signed URLs", func(t *testing.T) {
+		mediaURL := signer.URL("media-id", "thumbnail")
+		assert.Regexp(
+			t,
+			`^https://cdn\.example\.com/media/media-id\?expires=\d+`+
+				`&signature=[\w-]+&variant=thumbnail$`,
+			mediaURL,
+		)
+		assert.NoError(t, verifyTestMediaURL(t, signer, mediaURL))
+	})
+
+	t.Run("should keep URLs for a period", func(t *testing.T) {
+		first := signer.URL("media-id", "")
+		later := now.Add(30 * time.Minute)
+		assert.Equal(t, first, newTestMediaURLSigner(t, &later).URL("media-id", ""))
+		assert.Equal(
+			t,
+			time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+			signer.Expires(),
+		)
+		assert.Equal(
+			t,
+			time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+			signer.Signed(),
+		)
+	})
+
+	t.Run("should refuse tampered URLs", func(t *testing.T) {
+		expires := signer.Expires().Unix()
+		signature := signer.sign("media-id", "thumbnail", expires)
+
+		assert.ErrorIs(
+			t,
+			signer.Verify("other-id", "thumbnail", expires, signature),
+			ErrInvalidMediaURLSignature,
+		)
+		assert.ErrorIs(
+			t,
+			signer.Verify("media-id", "", expires, signature),
+			ErrInvalidMediaURLSignature,
+		)
+		assert.ErrorIs(
+			t,
+			signer.Verify("media-id", "thumbnail", expires+3600, signature),
+			ErrInvalidMediaURLSignature,
+		)
+	})
+
+	t.Run("should refuse expired URLs", func(t *testing.T) {
+		mediaURL := signer.URL("media-id", "")
+		expired := now.Add(2 * time.Hour)
+
+		assert.ErrorIs(
+			t,
+			verifyTestMediaURL(t, newTestMediaURLSigner(t, &expired), mediaURL),
+			ErrMediaURLExpired,
+		)
+	})
+}
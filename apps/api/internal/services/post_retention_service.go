@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"apps/api/internal/config"
+	"apps/api/internal/repositories"
+)
+
+// PostRetentionService periodically purges posts that have stayed in the
+// trash longer than the configured retention period.
+type PostRetentionService struct {
+	interval  time.Duration
+	postRepo  *repositories.PostRepo
+	retention time.Duration
+}
+
+func NewPostRetentionService(
+	postRepo *repositories.PostRepo,
+	config *config.PostsConfig,
+) *PostRetentionService {
+	return &PostRetentionService{
+		interval: time.Hour,
+		postRepo: postRepo,
+		retention: time.Duration(
+			config.TrashRetentionDays,
+		) * 24 * time.Hour,
+	}
+}
+
+func (s *PostRetentionService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.purge(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *PostRetentionService) purge(ctx context.Context) {
+	purged, err := s.postRepo.PurgeTrashedPosts(
+		ctx,
+		time.Now().Add(-s.retention),
+	)
+	if err != nil {
+		log.Printf("Failed to purge trashed posts: %v", err)
+		return
+	}
+	if purged > 0 {
+		log.Printf("Purged %d trashed posts", purged)
+	}
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"apps/api/internal/config"
+	"apps/api/internal/models"
+)
+
+// pendingPostViewBatches bounds the buffer, in batches, while the store
+// fails, so that an outage cannot grow it without bounds.
+const pendingPostViewBatches = 10
+
+// PostViewStore persists batches of post views.
+type PostViewStore interface {
+	AddPostViews(ctx context.Context, views []models.PostView) error
+}
+
+// PostViewRecorder buffers post views in memory and writes them to the store
+// in batches, when the buffer is full or the flush interval elapses. Views
+// are deduplicated per viewer, post and UTC day before they are written.
+// Views still buffered when Run stops are left for a final Flush.
+type PostViewRecorder struct {
+	batchSize int
+	full      chan struct{}
+	interval  time.Duration
+	mu        sync.Mutex
+	pending   map[models.PostView]struct{}
+	store     PostViewStore
+}
+
+func NewPostViewRecorder(
+	store PostViewStore,
+	config *config.PostsConfig,
+) *PostViewRecorder {
+	return &PostViewRecorder{
+		batchSize: config.ViewsBatchSize,
+		full:      make(chan struct{}, 1),
+		interval: time.Duration(
+			config.ViewsFlushIntervalSeconds,
+		) * time.Second,
+		pending: map[models.PostView]struct{}{},
+		store:   store,
+	}
+}
+
+// Record buffers a view of the post by the viewer. It never blocks on the
+// store.
+func (r *PostViewRecorder) Record(postId string, viewerKey string) {
+	view := models.PostView{
+		Day:       time.Now().UTC().Truncate(24 * time.Hour),
+		PostId:    postId,
+		ViewerKey: viewerKey,
+	}
+
+	r.mu.Lock()
+	r.pending[view] = struct{}{}
+	full := len(r.pending) >= r.batchSize
+	r.mu.Unlock()
+
+	if full {
+		select {
+		case r.full <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Run flushes the buffer until ctx is done.
+func (r *PostViewRecorder) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-r.full:
+		}
+		r.Flush(ctx)
+	}
+}
+
+// Flush writes the buffered views to the store. Views of a failed batch are
+// put back into the buffer to be retried by the next flush, as long as it
+// holds less than pendingPostViewBatches batches, the rest is dropped.
+func (r *PostViewRecorder) Flush(ctx context.Context) {
+	r.mu.Lock()
+	pending := r.pending
+	r.pending = make(map[models.PostView]struct{}, len(pending))
+	r.mu.Unlock()
+
+	if len(pending) == 0 {
+		return
+	}
+
+	views := make([]models.PostView, 0, len(pending))
+	for view := range pending {
+		views = append(views, view)
+	}
+
+	for start := 0; start < len(views); start += r.batchSize {
+		end := min(start+r.batchSize, len(views))
+		if err := r.store.AddPostViews(ctx, views[start:end]); err != nil {
+			log.Printf("Failed to flush %d post views: %v", end-start, err)
+			r.requeue(views[start:end])
+		}
+	}
+}
+
+// requeue puts the views back into the buffer, up to its limit.
+func (r *PostViewRecorder) requeue(views []models.PostView) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	limit := r.batchSize * pendingPostViewBatches
+	for i, view := range views {
+		if len(r.pending) >= limit {
+			log.Printf("Dropped %d post views", len(views)-i)
+			return
+		}
+		r.pending[view] = struct{}{}
+	}
+}
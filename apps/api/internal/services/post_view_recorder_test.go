@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"apps/api/internal/config"
+	"apps/api/internal/models"
+)
+
+type fakePostViewStore struct {
+	mu      sync.Mutex
+	batches [][]models.PostView
+	err     error
+}
+
+func (s *fakePostViewStore) AddPostViews(
+	_ context.Context,
+	views []models.PostView,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.batches = append(s.batches, views)
+	return nil
+}
+
+func TestPostViewRecorder_Flush(t *testing.T) {
+	store := &fakePostViewStore{}
+	recorder := NewPostViewRecorder(store, &config.PostsConfig{
+		ViewsBatchSize:            2,
+		ViewsFlushIntervalSeconds: 60,
+	})
+
+	recorder.Record("post-1", "user:a")
+	recorder.Record("post-1", "user:a")
+	recorder.Record("post-1", "user:b")
+	recorder.Record("post-2", "user:a")
+	recorder.Flush(context.Background())
+
+	var views []models.PostView
+	for _, batch := range store.batches {
+		assert.LessOrEqual(t, len(batch), 2)
+		views = append(views, batch...)
+	}
+	assert.Len(t, store.batches, 2)
+	assert.Len(t, views, 3)
+
+	recorder.Flush(context.Background())
+	assert.Len(t, store.batches, 2)
+}
+
+func TestPostViewRecorder_FlushRetries(t *testing.T) {
+	store := &fakePostViewStore{err: errors.New("unavailable")}
+	recorder := NewPostViewRecorder(store, &config.PostsConfig{
+		ViewsBatchSize:            1,
+		ViewsFlushIntervalSeconds: 60,
+	})
+
+	for i := range pendingPostViewBatches + 5 {
+		recorder.Record("post-1", fmt.Sprintf("user:%d", i))
+	}
+	recorder.Flush(context.Background())
+	assert.Empty(t, store.batches)
+
+	store.err = nil
+	recorder.Flush(context.Background())
+	assert.Len(t, store.batches, pendingPostViewBatches)
+}
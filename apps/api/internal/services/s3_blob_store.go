@@ -0,0 +1,286 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"apps/api/internal/config"
+)
+
+// s3EmptyPayloadHash is the SHA-256 of an empty request body.
+const s3EmptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// S3BlobStore keeps blobs in a bucket of an S3 compatible service, such as
+// AWS S3 or MinIO. Requests are signed with AWS Signature Version 4 and
+// address the bucket in the path, which every S3 compatible service
+// supports.
+type S3BlobStore struct {
+	accessKey string
+	bucket    string
+	client    *http.Client
+	endpoint  *url.URL
+	region    string
+	secretKey string
+}
+
+func NewS3BlobStore(config *config.MediaConfig) (*S3BlobStore, error) {
+	if config.S3Bucket == "" || config.S3Endpoint == "" {
+		return nil, fmt.Errorf("S3 bucket and endpoint are required")
+	}
+	endpoint, err := url.Parse(config.S3Endpoint)
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("Invalid S3 endpoint %q", config.S3Endpoint)
+	}
+
+	return &S3BlobStore{
+		accessKey: config.S3AccessKey,
+		bucket:    config.S3Bucket,
+		client:    &http.Client{Timeout: 5 * time.Minute},
+		endpoint:  endpoint,
+		region:    config.S3Region,
+		secretKey: config.S3SecretKey,
+	}, nil
+}
+
+// Put sends the body without signing it, so that it does not have to be
+// read twice.
+func (s *S3BlobStore) Put(
+	ctx context.Context,
+	key string,
+	body io.Reader,
+	size int64,
+	contentType string,
+) error {
+	req, err := s.newRequest(ctx, http.MethodPut, key, body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	if size == 0 {
+		req.Body = http.NoBody
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	res, err := s.send(req, "UNSIGNED-PAYLOAD")
+	if err != nil {
+		return fmt.Errorf("Failed to put blob: %w", err)
+	}
+	res.Body.Close()
+
+	return nil
+}
+
+func (s *S3BlobStore) Get(
+	ctx context.Context,
+	key string,
+) (io.ReadCloser, error) {
+	return s.GetFrom(ctx, key, 0)
+}
+
+func (s *S3BlobStore) GetFrom(
+	ctx context.Context,
+	key string,
+	offset int64,
+) (io.ReadCloser, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	res, err := s.send(req, s3EmptyPayloadHash)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get blob: %w", err)
+	}
+
+	return res.Body, nil
+}
+
+func (s *S3BlobStore) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.send(req, s3EmptyPayloadHash)
+	if err != nil {
+		return fmt.Errorf("Failed to delete blob: %w", err)
+	}
+	res.Body.Close()
+
+	return nil
+}
+
+// newRequest builds a request for the object stored under key, or for the
+// bucket itself when key is empty.
+func (s *S3BlobStore) newRequest(
+	ctx context.Context,
+	method string,
+	key string,
+	body io.Reader,
+) (*http.Request, error) {
+	path := "/" + s.bucket
+	if key != "" {
+		path += "/" + key
+	}
+	target := *s.endpoint
+	target.Path = strings.TrimSuffix(target.Path, "/") + path
+	target.RawPath = s3EscapePath(target.Path)
+
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create S3 request: %w", err)
+	}
+
+	return req, nil
+}
+
+// send signs and sends the request. Responses other than 2xx are turned
+// into errors, ErrBlobNotFound for missing objects.
+func (s *S3BlobStore) send(
+	req *http.Request,
+	payloadHash string,
+) (*http.Response, error) {
+	signS3Request(
+		req,
+		s.accessKey,
+		s.secretKey,
+		s.region,
+		payloadHash,
+		time.Now(),
+	)
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return res, nil
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, ErrBlobNotFound
+	}
+	message, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+	return nil, fmt.Errorf("S3 responded with %s: %s", res.Status, message)
+}
+
+// signS3Request adds the AWS Signature Version 4 headers to the request. The
+// host, Range and x-amz-* headers are signed.
+func signS3Request(
+	req *http.Request,
+	accessKey string,
+	secretKey string,
+	region string,
+	payloadHash string,
+	now time.Time,
+) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	day := amzDate[:8]
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		name = strings.ToLower(name)
+		if name == "range" || strings.HasPrefix(name, "x-amz-") {
+			headers[name] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		s3EscapePath(req.URL.Path),
+		s3CanonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := day + "/" + region + "/s3/aws4_request"
+	canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hex.EncodeToString(canonicalRequestHash[:]),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+secretKey), day)
+	signingKey = hmacSHA256(signingKey, region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKey,
+		scope,
+		signedHeaders,
+		signature,
+	))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3Escape percent-encodes everything but unreserved characters, and slashes
+// when keepSlash is set, as Signature Version 4 requires.
+func s3Escape(value string, keepSlash bool) string {
+	var escaped strings.Builder
+	for _, b := range []byte(value) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9',
+			b == '-', b == '.', b == '_', b == '~', keepSlash && b == '/':
+			escaped.WriteByte(b)
+		default:
+			fmt.Fprintf(&escaped, "%%%02X", b)
+		}
+	}
+	return escaped.String()
+}
+
+func s3EscapePath(path string) string {
+	return s3Escape(path, true)
+}
+
+func s3CanonicalQuery(query url.Values) string {
+	params := make([]string, 0, len(query))
+	for name, values := range query {
+		for _, value := range values {
+			params = append(
+				params,
+				s3Escape(name, false)+"="+s3Escape(value, false),
+			)
+		}
+	}
+	slices.Sort(params)
+	return strings.Join(params, "&")
+}
@@ -0,0 +1,62 @@
+//go:build integration
+
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	"apps/api/internal/config"
+)
+
+// TestS3BlobStore runs against MinIO, as a stand-in for S3.
+func TestS3BlobStore(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := testcontainers.GenericContainer(
+		ctx,
+		testcontainers.GenericContainerRequest{
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image:        "minio/minio:latest",
+				Cmd:          []string{"server", "/data"},
+				ExposedPorts: []string{"9000/tcp"},
+				Env: map[string]string{
+					"MINIO_ROOT_USER":     "minioadmin",
+					"MINIO_ROOT_PASSWORD": "minioadmin",
+				},
+				WaitingFor: wait.ForHTTP("/minio/health/live").
+					WithPort("9000/tcp").
+					WithStartupTimeout(30 * time.Second),
+			},
+			Started: true,
+		},
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = container.Terminate(ctx) })
+
+	endpoint, err := container.PortEndpoint(ctx, "9000/tcp", "http")
+	require.NoError(t, err)
+
+	store, err := NewS3BlobStore(&config.MediaConfig{
+		S3AccessKey: "minioadmin",
+		S3Bucket:    "media",
+		S3Endpoint:  endpoint,
+		S3Region:    "us-east-1",
+		S3SecretKey: "minioadmin",
+	})
+	require.NoError(t, err)
+
+	req, err := store.newRequest(ctx, http.MethodPut, "", nil)
+	require.NoError(t, err)
+	res, err := store.send(req, s3EmptyPayloadHash)
+	require.NoError(t, err)
+	res.Body.Close()
+
+	testBlobStore(t, store)
+}
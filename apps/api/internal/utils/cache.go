@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// CheckNotModified sets the ETag and Last-Modified validators of the
+// response and reports whether the conditional headers of the request allow
+// answering with 304 Not Modified. If-Modified-Since is only evaluated when
+// If-None-Match is absent (RFC 9110 section 13.2.2), and at the precision of
+// HTTP dates, which is a second.
+func CheckNotModified(
+	c echo.Context,
+	etag string,
+	lastModified time.Time,
+) bool {
+	header := c.Response().Header()
+	if etag != "" {
+		header.Set("ETag", etag)
+	}
+	if !lastModified.IsZero() {
+		header.Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	req := c.Request()
+	if ifNoneMatch := req.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		return etag != "" && MatchesIfNoneMatch(ifNoneMatch, etag)
+	}
+
+	ifModifiedSince := req.Header.Get("If-Modified-Since")
+	if ifModifiedSince == "" || lastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(ifModifiedSince)
+	if err != nil {
+		return false
+	}
+	return !lastModified.Truncate(time.Second).After(since)
+}
@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCacheTestContext(
+	headers map[string]string,
+) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	return echo.New().NewContext(req, rec), rec
+}
+
+func TestCheckNotModified(t *testing.T) {
+	lastModified := time.Date(2025, 1, 2, 3, 4, 5, 600, time.UTC)
+
+	t.Run("should set validators", func(t *testing.T) {
+		c, rec := newCacheTestContext(nil)
+
+		assert.False(t, CheckNotModified(c, `"1"`, lastModified))
+		assert.Equal(t, `"1"`, rec.Header().Get("ETag"))
+		assert.Equal(
+			t,
+			"Thu, 02 Jan 2025 03:04:05 GMT",
+			rec.Header().Get("Last-Modified"),
+		)
+	})
+
+	t.Run("should match If-None-Match weakly", func(t *testing.T) {
+		c, _ := newCacheTestContext(map[string]string{
+			"If-None-Match": `W/"1"`,
+		})
+		assert.True(t, CheckNotModified(c, `"1"`, lastModified))
+
+		c, _ = newCacheTestContext(map[string]string{
+			"If-None-Match": `"2"`,
+		})
+		assert.False(t, CheckNotModified(c, `"1"`, lastModified))
+	})
+
+	t.Run(
+		"should prefer If-None-Match over If-Modified-Since",
+		func(t *testing.T) {
+			c, _ := newCacheTestContext(map[string]string{
+				"If-None-Match":     `"2"`,
+				"If-Modified-Since": "Fri, 03 Jan 2025 00:00:00 GMT",
+			})
+			assert.False(t, CheckNotModified(c, `"1"`, lastModified))
+		},
+	)
+
+	t.Run("should compare If-Modified-Since in seconds", func(t *testing.T) {
+		c, _ := newCacheTestContext(map[string]string{
+			"If-Modified-Since": "Thu, 02 Jan 2025 03:04:05 GMT",
+		})
+		assert.True(t, CheckNotModified(c, `"1"`, lastModified))
+
+		c, _ = newCacheTestContext(map[string]string{
+			"If-Modified-Since": "Thu, 02 Jan 2025 03:04:04 GMT",
+		})
+		assert.False(t, CheckNotModified(c, `"1"`, lastModified))
+	})
+}
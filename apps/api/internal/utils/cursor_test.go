@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor(t *testing.T) {
+	type position struct {
+		CreatedAt time.Time `json:"c"`
+		Id        string    `json:"i"`
+	}
+
+	t.Run("should round trip position", func(t *testing.T) {
+		expected := position{
+			CreatedAt: time.Date(2025, 1, 2, 3, 4, 5, 6, time.UTC),
+			Id:        "550e8400-e29b-41d4-a716-446655440000",
+		}
+
+		cursor, err := EncodeCursor(expected)
+		require.NoError(t, err)
+		assert.NotContains(t, cursor, "=")
+
+		var actual position
+		require.NoError(t, DecodeCursor(cursor, &actual))
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("should fail on malformed cursor", func(t *testing.T) {
+		var actual position
+		assert.Error(t, DecodeCursor("not a cursor", &actual))
+		assert.Error(t, DecodeCursor("bm90IGpzb24", &actual))
+	})
+}
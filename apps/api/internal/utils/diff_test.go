@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffLines(t *testing.T) {
+	t.Run("should return only equal lines for same text", func(t *testing.T) {
+		diff := DiffLines("a\nb", "a\nb")
+
+		assert.Equal(t, []DiffLine{
+			{Op: DiffOpEqual, Text: "a"},
+			{Op: DiffOpEqual, Text: "b"},
+		}, diff)
+	})
+
+	t.Run("should mark replaced line as delete and insert", func(t *testing.T) {
+		diff := DiffLines("a\nb\nc", "a\nx\nc")
+
+		assert.Equal(t, []DiffLine{
+			{Op: DiffOpEqual, Text: "a"},
+			{Op: DiffOpDelete, Text: "b"},
+			{Op: DiffOpInsert, Text: "x"},
+			{Op: DiffOpEqual, Text: "c"},
+		}, diff)
+	})
+
+	t.Run("should handle empty before and after", func(t *testing.T) {
+		assert.Equal(t, []DiffLine{
+			{Op: DiffOpInsert, Text: "a"},
+		}, DiffLines("", "a"))
+		assert.Equal(t, []DiffLine{
+			{Op: DiffOpDelete, Text: "a"},
+		}, DiffLines("a", ""))
+		assert.Empty(t, DiffLines("", ""))
+	})
+
+	t.Run("should keep appended lines at the end", func(t *testing.T) {
+		diff := DiffLines("a", "a\nb\nc")
+
+		assert.Equal(t, []DiffLine{
+			{Op: DiffOpEqual, Text: "a"},
+			{Op: DiffOpInsert, Text: "b"},
+			{Op: DiffOpInsert, Text: "c"},
+		}, diff)
+	})
+}
@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsEmoji(t *testing.T) {
+	tests := map[string]bool{
+		"👍":             true,
+		"❤️":            true,
+		"❤":             true,
+		"👍🏽":            true,
+		"🇫🇷":            true,
+		"1️⃣":           true,
+		"#⃣":            true,
+		"👨‍👩‍👧‍👦":       true,
+		"🏳️‍🌈":          true,
+		"🏴󠁧󠁢󠁳󠁣󠁴󠁿":       true,
+		"":              false,
+		"lol":           false,
+		"<b>":           false,
+		"https://x.com": false,
+		"1":             false,
+		"👍👍":            false,
+		"👍 ":            false,
+		"a👍":            false,
+		"🇫":             false,
+		"👍‍":            false,
+		"🏴󠁧󠁢":           false,
+		"🏽":             false,
+	}
+	for emoji, valid := range tests {
+		t.Run(emoji, func(t *testing.T) {
+			assert.Equal(t, valid, IsEmoji(emoji))
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchesIfNoneMatch(t *testing.T) {
+	assert.True(t, MatchesIfNoneMatch(`"3"`, `"3"`))
+	assert.True(t, MatchesIfNoneMatch(`W/"3"`, `"3"`))
+	assert.True(t, MatchesIfNoneMatch(`"1", W/"3"`, `W/"3"`))
+	assert.True(t, MatchesIfNoneMatch(`*`, `"3"`))
+	assert.False(t, MatchesIfNoneMatch(`"2"`, `"3"`))
+}
+
+func TestWeakETag(t *testing.T) {
+	assert.Equal(t, WeakETag("a", "b"), WeakETag("a", "b"))
+	assert.NotEqual(t, WeakETag("a", "b"), WeakETag("ab"))
+	assert.Regexp(t, `^W/"[0-9a-f]{32}"$`, WeakETag("a"))
+}
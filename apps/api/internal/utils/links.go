@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// maxLinkLength is the length of the longest URL extracted from content.
+const maxLinkLength = 2048
+
+var linkRegexp = regexp.MustCompile("(?i)\\bhttps?://[^\\s<>\"'`]+")
+
+// ExtractLinks returns the http and https URLs found in the content in order
+// of first appearance, without their fragments. Punctuation ending a sentence
+// and closing brackets without an opening one in the URL are not part of it.
+func ExtractLinks(content string) []string {
+	var links []string
+	seen := map[string]bool{}
+	for _, match := range linkRegexp.FindAllString(content, -1) {
+		link := trimLink(match)
+		if len(link) > maxLinkLength {
+			continue
+		}
+		parsed, err := url.Parse(link)
+		if err != nil || parsed.Host == "" || parsed.User != nil {
+			continue
+		}
+		parsed.Fragment = ""
+		parsed.RawFragment = ""
+		link = parsed.String()
+		if !seen[link] {
+			seen[link] = true
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
+func trimLink(link string) string {
+	for link != "" {
+		last := link[len(link)-1]
+		switch {
+		case strings.IndexByte(".,;:!?*_~", last) >= 0:
+			link = link[:len(link)-1]
+		case last == ')' &&
+			strings.Count(link, "(") < strings.Count(link, ")"),
+			last == ']' &&
+				strings.Count(link, "[") < strings.Count(link, "]"):
+			link = link[:len(link)-1]
+		default:
+			return link
+		}
+	}
+	return link
+}
@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractLinks(t *testing.T) {
+	t.Run("should extract unique links in order", func(t *testing.T) {
+		links := ExtractLinks(
+			"See https://example.com/a, then http://example.org.\n" +
+				"Again https://example.com/a#top!",
+		)
+
+		assert.Equal(
+			t,
+			[]string{"https://example.com/a", "http://example.org"},
+			links,
+		)
+	})
+
+	t.Run("should handle brackets", func(t *testing.T) {
+		links := ExtractLinks(
+			"[docs](https://example.com/docs) " +
+				"(https://en.wikipedia.org/wiki/Go_(language))",
+		)
+
+		assert.Equal(
+			t,
+			[]string{
+				"https://example.com/docs",
+				"https://en.wikipedia.org/wiki/Go_(language)",
+			},
+			links,
+		)
+	})
+
+	t.Run("should ignore other schemes and credentials", func(t *testing.T) {
+		links := ExtractLinks(
+			"ftp://example.com javascript:alert(1) https://user:pw@example.com",
+		)
+
+		assert.Empty(t, links)
+	})
+}
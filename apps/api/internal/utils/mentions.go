@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// MaxHandleLength is the length of the longest user handle.
+const MaxHandleLength = 30
+
+var (
+	// HandleRegexp matches handles of 1 to 30 lowercase letters, digits and
+	// underscores.
+	HandleRegexp  = regexp.MustCompile(`^[a-z0-9_]{1,30}$`)
+	mentionRegexp = regexp.MustCompile(`@([A-Za-z0-9_]{1,30})`)
+)
+
+// Mention is an @handle in content. Offset and Length count UTF-16 code
+// units, as string indexes do in JavaScript, Kotlin and Swift's NSString.
+type Mention struct {
+	Handle string
+	Length int
+	Offset int
+}
+
+// ExtractMentions returns the @handle mentions in the content in order of
+// appearance, with their lowercased handles. Mentions are not part of words,
+// so email addresses are no mentions, and longer handles are ignored.
+func ExtractMentions(content string) []Mention {
+	var mentions []Mention
+	offset, counted := 0, 0
+	for _, match := range mentionRegexp.FindAllStringSubmatchIndex(content, -1) {
+		start, end := match[0], match[1]
+		before, _ := utf8.DecodeLastRuneInString(content[:start])
+		after, _ := utf8.DecodeRuneInString(content[end:])
+		if start > 0 && isWordRune(before) ||
+			end < len(content) && isWordRune(after) {
+			continue
+		}
+
+		offset += utf16Length(content[counted:start])
+		counted = start
+		mentions = append(mentions, Mention{
+			Handle: strings.ToLower(content[match[2]:match[3]]),
+			Length: end - start,
+			Offset: offset,
+		})
+	}
+	return mentions
+}
+
+// HandleFromEmail derives a handle from the local part of the email address.
+func HandleFromEmail(email string) string {
+	local, _, _ := strings.Cut(strings.ToLower(email), "@")
+	handle := strings.Map(func(r rune) rune {
+		if isHandleRune(r) {
+			return r
+		}
+		return '_'
+	}, local)
+	handle = strings.Trim(handle, "_")
+	if handle == "" {
+		return "user"
+	}
+	if len(handle) > MaxHandleLength {
+		handle = handle[:MaxHandleLength]
+	}
+	return handle
+}
+
+func isHandleRune(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_'
+}
+
+// isWordRune reports whether the rune continues a word, which a mention can
+// neither follow nor be followed by.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '@'
+}
+
+func utf16Length(s string) int {
+	n := 0
+	for _, r := range s {
+		n += utf16.RuneLen(r)
+	}
+	return n
+}
@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractMentions(t *testing.T) {
+	t.Run("should extract lowercased handles with offsets", func(t *testing.T) {
+		mentions := ExtractMentions("Hi @Alice and @bob_1, @alice again.")
+
+		assert.Equal(t, []Mention{
+			{Handle: "alice", Length: 6, Offset: 3},
+			{Handle: "bob_1", Length: 6, Offset: 14},
+			{Handle: "alice", Length: 6, Offset: 22},
+		}, mentions)
+	})
+
+	t.Run("should count offsets in UTF-16 code units", func(t *testing.T) {
+		mentions := ExtractMentions("é 🎉 (@bob)")
+
+		assert.Equal(t, []Mention{{Handle: "bob", Length: 4, Offset: 6}}, mentions)
+	})
+
+	t.Run("should ignore emails and words", func(t *testing.T) {
+		mentions := ExtractMentions(
+			"mail bob@example.com, a@@b, @" +
+				"a_handle_that_is_way_too_long_x or x@bob",
+		)
+
+		assert.Empty(t, mentions)
+	})
+}
+
+func TestHandleFromEmail(t *testing.T) {
+	assert.Equal(t, "john_doe", HandleFromEmail("John.Doe@example.com"))
+	assert.Equal(t, "user", HandleFromEmail("...@example.com"))
+	assert.Len(t, HandleFromEmail("a123456789012345678901234567890@x.y"), 30)
+	assert.Regexp(t, HandleRegexp, HandleFromEmail("Zoë+tag@example.com"))
+}
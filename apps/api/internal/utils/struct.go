@@ -28,6 +28,9 @@ func ExtractNonNilFieldsByTag(st any, tag string) map[string]any {
 	for i := range typ.NumField() {
 		field := typ.Field(i)
 		dbTag := field.Tag.Get(tag)
+		if dbTag == "" || dbTag == "-" {
+			continue
+		}
 		fieldValue := val.Field(i)
 		if !fieldValue.IsNil() {
 			result[dbTag] = fieldValue.Elem().Interface()
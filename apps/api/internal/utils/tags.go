@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+var hashtagRegexp = regexp.MustCompile(
+	`(?:^|[^\p{L}\p{N}_&#/])#([\p{L}_][\p{L}\p{N}_]{0,49})`,
+)
+
+// ExtractHashtags returns the normalized #hashtags found in the content in
+// order of first appearance.
+func ExtractHashtags(content string) []string {
+	var tags []string
+	for _, match := range hashtagRegexp.FindAllStringSubmatch(content, -1) {
+		tags = append(tags, match[1])
+	}
+	return NormalizeTags(tags)
+}
+
+// NormalizeTags lowercases the tags, strips a leading # and drops duplicates
+// while keeping the order of first appearance.
+func NormalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = NormalizeTag(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+func NormalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+}
@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractHashtags(t *testing.T) {
+	t.Run("should extract normalized unique hashtags", func(t *testing.T) {
+		tags := ExtractHashtags("#Go is fun, #golang and #go again.\n#Go_1")
+
+		assert.Equal(t, []string{"go", "golang", "go_1"}, tags)
+	})
+
+	t.Run("should ignore anchors, entities and numbers", func(t *testing.T) {
+		tags := ExtractHashtags(
+			"see https://example.com/#section, &#39; issue #42 a#b",
+		)
+
+		assert.Empty(t, tags)
+	})
+
+	t.Run("should support unicode letters", func(t *testing.T) {
+		assert.Equal(t, []string{"привет"}, ExtractHashtags("(#Привет)"))
+	})
+}
+
+func TestNormalizeTags(t *testing.T) {
+	assert.Equal(
+		t,
+		[]string{"go", "web"},
+		NormalizeTags([]string{"#Go", " go ", "Web", ""}),
+	)
+}